@@ -0,0 +1,42 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// Adjudicate finalizes the time of a finished subtimer
+// the recorded time is replaced by official, which is used as is for ranking, and the subtimer is locked against further changes.
+// Only possible for stopped or forfeited subtimers which haven't been adjudicated yet
+func (t *Timer) Adjudicate(id int, official time.Duration, note string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if s.adjudicated {
+		return errSubtimerAdjudicated(id)
+	}
+	if s.state != Stopped && s.state != Forfeited {
+		return &StateError{Op: "Adjudicate", Current: s.state}
+	}
+	if official < 0 {
+		return fmt.Errorf("Only positive values for official are allowed")
+	}
+
+	s.Time = official
+	s.adjudicated = true
+	s.note = note
+	t.emit(Event{Type: EventSubtimerAdjudicated, Elapsed: t.elapsed, Subtimer: intPtr(id), Note: note})
+
+	return nil
+}
+
+// provisional reports whether s has a recorded time which hasn't been adjudicated yet
+func (s *subtimer) provisional() bool {
+	return (s.state == Stopped || s.state == Forfeited) && !s.adjudicated
+}
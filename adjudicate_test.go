@@ -0,0 +1,59 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestAdjudicate(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2))
+	defer tm.Close()
+	adjudicated := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventSubtimerAdjudicated}}))
+	if err := tm.SetSubTimerOffset(1, 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 5 * time.Second, Do: timertest.StopSubTimer(1)},
+	)
+	var stateErr *timer.StateError
+	if err := tm.Adjudicate(2, time.Second, ""); !errors.As(err, &stateErr) {
+		t.Errorf("adjudicating a running subtimer returned %v, want a state error", err)
+	}
+	if err := tm.Adjudicate(1, -time.Second, ""); err == nil {
+		t.Errorf("adjudicating a negative time succeeded, want an error")
+	}
+	if e := tm.RaceResult().Entries[0]; !e.Provisional || e.Compensated != 3*time.Second {
+		t.Errorf("entry before adjudication is provisional %v at %v, want provisional at 3s", e.Provisional, e.Compensated)
+	}
+
+	if err := tm.Adjudicate(1, 6*time.Second, "video review"); err != nil {
+		t.Fatal(err)
+	}
+	e := timertest.AssertEmitsType(t, adjudicated.C, timer.EventSubtimerAdjudicated, 0)
+	if e.Note != "video review" || e.Subtimer == nil || *e.Subtimer != 1 {
+		t.Errorf("event has note %q for subtimer %v, want video review for subtimer 1", e.Note, e.Subtimer)
+	}
+	// official times are neither compensated nor changed anymore
+	entry := tm.RaceResult().Entries[0]
+	if entry.Provisional || entry.Time != 6*time.Second || entry.Compensated != 6*time.Second || entry.Note != "video review" {
+		t.Errorf("entry after adjudication is %+v, want an official time of 6s", entry)
+	}
+	locked := map[string]error{
+		"Adjudicate":       tm.Adjudicate(1, 7*time.Second, ""),
+		"UndoStopSubTimer": tm.UndoStopSubTimer(1),
+	}
+	_, locked["StopSubTimer"] = tm.StopSubTimer(1)
+	for op, err := range locked {
+		if !errors.Is(err, timer.ErrSubtimerAdjudicated) {
+			t.Errorf("%v returned %v, want %v", op, err, timer.ErrSubtimerAdjudicated)
+		}
+	}
+}
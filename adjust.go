@@ -0,0 +1,70 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// Adjust shifts the elapsed time of the timer by d, e.g. to apply a time bonus or correct a late start
+// a positive d moves the elapsed time forward, a negative one backward. Running subtimers are shifted as well.
+// Only possible when in Running or Paused state and the elapsed time doesn't drop below 0 or a negative start offset
+func (t *Timer) Adjust(d time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	t.refreshElapsed()
+	if t.state != Running && t.state != Paused {
+		return t.logFailed("Adjust", &StateError{Op: "Adjust", Current: t.state})
+	}
+	floor := time.Duration(0)
+	if t.startOffset < 0 {
+		floor = t.startOffset
+	}
+	if t.elapsed+d < floor {
+		return t.logFailed("Adjust", fmt.Errorf("Adjust would move the elapsed time below %v", floor))
+	}
+	t.adjust(d)
+
+	return nil
+}
+
+// SetElapsed sets the elapsed time of the timer to d, e.g. to continue a previously recorded session
+// in Reset state the timer starts counting from d instead of the start offset, in Paused state it continues from d when resumed
+func (t *Timer) SetElapsed(d time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if d < 0 {
+		return t.logFailed("SetElapsed", fmt.Errorf("Only positive values for d are allowed"))
+	}
+
+	switch t.state {
+	case Reset:
+		t.seek = d
+		t.elapsed = d
+		t.emit(Event{Type: EventElapsedAdjusted, Elapsed: d, Delta: d})
+	case Paused:
+		// the elapsed time of the last tick may lag behind the pause, measure from the pause itself
+		delta := d - t.pauseTime.Sub(t.startTime)
+		t.startTime = t.startTime.Add(-delta)
+		t.elapsed = d
+		t.emit(Event{Type: EventElapsedAdjusted, Elapsed: d, Delta: delta})
+	default:
+		return t.logFailed("SetElapsed", &StateError{Op: "SetElapsed", Current: t.state})
+	}
+
+	return nil
+}
+
+// adjust shifts the elapsed time of a running or paused timer by d
+func (t *Timer) adjust(d time.Duration) {
+	t.startTime = t.startTime.Add(-d)
+	t.elapsed += d
+	t.emit(Event{Type: EventElapsedAdjusted, Elapsed: t.elapsed, Delta: d})
+	t.armAlarms()
+}
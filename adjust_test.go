@@ -0,0 +1,145 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestAdjust(t *testing.T) {
+	tests := []struct {
+		name     string
+		pause    bool
+		d        time.Duration
+		elapsed  time.Duration
+		subtimer time.Duration
+	}{
+		{"forward while running", false, 5 * time.Second, 8 * time.Second, 8 * time.Second},
+		{"backward while running", false, -time.Second, 2 * time.Second, 2 * time.Second},
+		{"forward while paused", true, 5 * time.Second, 8 * time.Second, 8 * time.Second},
+		{"backward to zero", true, -2 * time.Second, time.Second, time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock, timer.WithSubtimers(1))
+			defer tm.Close()
+			adjusted := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventElapsedAdjusted}}))
+
+			timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start}, timertest.Step{After: 2 * time.Second})
+			if test.pause {
+				timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Pause}, timertest.Step{After: time.Second})
+			}
+			if err := tm.Adjust(test.d); err != nil {
+				t.Fatal(err)
+			}
+			e := timertest.AssertEmitsType(t, adjusted.C, timer.EventElapsedAdjusted, 0)
+			if e.Delta != test.d || e.Elapsed != 2*time.Second+test.d {
+				t.Errorf("adjust event has delta %v at %v, want %v at %v", e.Delta, e.Elapsed, test.d, 2*time.Second+test.d)
+			}
+			if test.pause {
+				timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Resume})
+			}
+
+			clock.Advance(time.Second)
+			if d := tm.Elapsed(); d != test.elapsed {
+				t.Errorf("elapsed is %v, want %v", d, test.elapsed)
+			}
+			// running subtimers are shifted as well
+			if d, err := tm.StopSubTimer(1); err != nil || d != test.subtimer {
+				t.Errorf("subtimer stopped at %v, %v, want %v", d, err, test.subtimer)
+			}
+		})
+	}
+}
+
+func TestAdjustInvalid(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	var stateErr *timer.StateError
+	if err := tm.Adjust(time.Second); !errors.As(err, &stateErr) {
+		t.Errorf("adjusting before start returned %v, want a state error", err)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start}, timertest.Step{After: time.Second})
+	if err := tm.Adjust(-2 * time.Second); err == nil {
+		t.Errorf("adjusting below zero succeeded, want an error")
+	}
+	if d := tm.Elapsed(); d != time.Second {
+		t.Errorf("elapsed is %v after a failed adjustment, want 1s", d)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+	if err := tm.Adjust(time.Second); !errors.As(err, &stateErr) {
+		t.Errorf("adjusting a stopped timer returned %v, want a state error", err)
+	}
+}
+
+func TestSetElapsed(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []timertest.Step
+	}{
+		{"reset", nil},
+		{"paused", []timertest.Step{
+			{Do: timertest.Start},
+			{After: 2 * time.Second, Do: timertest.Pause},
+			{After: time.Second},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock)
+			defer tm.Close()
+			timertest.Run(t, tm, clock, test.steps...)
+
+			if err := tm.SetElapsed(10 * time.Second); err != nil {
+				t.Fatal(err)
+			}
+			if d := tm.Elapsed(); d != 10*time.Second {
+				t.Errorf("elapsed is %v, want 10s", d)
+			}
+			if tm.State() == timer.Paused {
+				timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Resume})
+			} else {
+				timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+			}
+			// the timer continues from the new elapsed time
+			clock.Advance(time.Second)
+			if d := tm.Elapsed(); d != 11*time.Second {
+				t.Errorf("elapsed is %v, want 11s", d)
+			}
+		})
+	}
+}
+
+func TestSetElapsedInvalid(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	if err := tm.SetElapsed(-time.Second); err == nil {
+		t.Errorf("setting a negative elapsed time succeeded, want an error")
+	}
+	var stateErr *timer.StateError
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	if err := tm.SetElapsed(time.Second); !errors.As(err, &stateErr) {
+		t.Errorf("setting the elapsed time while running returned %v, want a state error", err)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+	if err := tm.SetElapsed(time.Second); !errors.As(err, &stateErr) {
+		t.Errorf("setting the elapsed time while stopped returned %v, want a state error", err)
+	}
+	// the next run starts from the start offset again
+	prepare(t, tm)
+	if d := tm.Elapsed(); d != 0 {
+		t.Errorf("elapsed is %v after reset, want 0", d)
+	}
+}
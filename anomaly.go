@@ -0,0 +1,130 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// Severity describes how important an event is
+type Severity int
+
+const (
+	// SeverityInfo is used for regular events
+	SeverityInfo Severity = iota
+	// SeverityWarning is used for events which indicate a possible problem
+	SeverityWarning
+	// SeverityError is used for events which indicate that the timing is likely wrong
+	SeverityError
+)
+
+// AnomalyKind describes the different abnormal clock conditions which are detected
+type AnomalyKind int
+
+const (
+	// AnomalyTickGap is detected when the time between two ticks is much larger than the tick interval
+	AnomalyTickGap AnomalyKind = iota
+	// AnomalyClockDivergence is detected when the wall clock and the monotonic clock advanced by different amounts
+	AnomalyClockDivergence
+	// AnomalyNegativeElapsed is detected when the computed elapsed time is negative
+	AnomalyNegativeElapsed
+)
+
+const (
+	// minTickGapThreshold is the lower bound of the default tick gap threshold of twice the loop interval
+	minTickGapThreshold        = 250 * time.Millisecond
+	defaultDivergenceThreshold = 50 * time.Millisecond
+)
+
+// Anomaly holds the measurements of a detected clock anomaly
+type Anomaly struct {
+	Kind AnomalyKind `json:"kind"`
+	// Expected is the value which would have been measured on a healthy host
+	Expected time.Duration `json:"expected"`
+	// Measured is the value which was actually measured
+	Measured time.Duration `json:"measured"`
+}
+
+func (a Anomaly) String() string {
+	switch a.Kind {
+	case AnomalyTickGap:
+		return fmt.Sprintf("tick gap of %v, expected %v", a.Measured, a.Expected)
+	case AnomalyClockDivergence:
+		return fmt.Sprintf("wall clock advanced %v while monotonic clock advanced %v", a.Measured, a.Expected)
+	case AnomalyNegativeElapsed:
+		return fmt.Sprintf("negative elapsed time of %v", a.Measured)
+	default:
+		return "unknown anomaly"
+	}
+}
+
+// SetAnomalyThresholds sets the thresholds used for detecting clock anomalies
+// tickGap is the maximum allowed time between two ticks, divergence the maximum allowed difference between wall and monotonic clock per tick.
+// Setting 0 for a threshold sets it back to the default. The default tick gap is twice the interval the loop wakes up at, at least 250ms
+func (t *Timer) SetAnomalyThresholds(tickGap, divergence time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if tickGap < 0 || divergence < 0 {
+		return fmt.Errorf("Only positive values for anomaly thresholds are allowed")
+	}
+	if divergence == 0 {
+		divergence = defaultDivergenceThreshold
+	}
+	t.tickGapThreshold = tickGap
+	t.divergenceThreshold = divergence
+
+	return nil
+}
+
+// tickGapLimit returns the tick gap threshold, by default it follows the loop interval
+func (t *Timer) tickGapLimit() time.Duration {
+	if t.tickGapThreshold > 0 {
+		return t.tickGapThreshold
+	}
+	if limit := 2 * t.loopInterval(); limit > minTickGapThreshold {
+		return limit
+	}
+
+	return minTickGapThreshold
+}
+
+// detectAnomalies checks the tick at now against the previous tick and emits an event for every detected anomaly
+func (t *Timer) detectAnomalies(now time.Time) {
+	// runs started with a negative offset count up from below zero
+	if t.elapsed < 0 && t.elapsed < t.startOffset {
+		t.emitAnomaly(Anomaly{Kind: AnomalyNegativeElapsed, Measured: t.elapsed})
+	}
+
+	last := t.lastTick
+	t.lastTick = now
+	if last.IsZero() {
+		return
+	}
+
+	monotonic := now.Sub(last)
+	t.jitter.add(monotonic, t.loopInterval())
+	if monotonic > t.tickGapLimit() {
+		t.emitAnomaly(Anomaly{
+			Kind:     AnomalyTickGap,
+			Expected: t.loopInterval(),
+			Measured: monotonic,
+		})
+	}
+
+	// Round(0) strips the monotonic reading so Sub uses the wall clock
+	wall := now.Round(0).Sub(last.Round(0))
+	divergence := wall - monotonic
+	if divergence < 0 {
+		divergence = -divergence
+	}
+	if divergence > t.divergenceThreshold {
+		t.emitAnomaly(Anomaly{Kind: AnomalyClockDivergence, Expected: monotonic, Measured: wall})
+	}
+}
+
+func (t *Timer) emitAnomaly(a Anomaly) {
+	severity := SeverityWarning
+	if a.Kind == AnomalyNegativeElapsed {
+		severity = SeverityError
+	}
+	t.emit(Event{Type: EventAnomaly, Severity: severity, Elapsed: t.elapsed, Anomaly: &a})
+}
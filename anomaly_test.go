@@ -0,0 +1,57 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestTickGapThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		tickGap  time.Duration
+		want     bool
+	}{
+		{"default with default interval", 0, 0, false},
+		{"default with 1s interval", time.Second, 0, false},
+		{"explicit threshold below the interval", time.Second, 500 * time.Millisecond, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(test.interval), timer.WithTickerIntervalDuration(test.interval))
+			defer tm.Close()
+			if err := tm.SetAnomalyThresholds(test.tickGap, 0); err != nil {
+				t.Fatal(err)
+			}
+			anomalies := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventAnomaly}}))
+
+			step := test.interval
+			if step == 0 {
+				step = 10 * time.Millisecond
+			}
+			steps := []timertest.Step{{Do: timertest.Start}}
+			for i := 0; i < 5; i++ {
+				steps = append(steps, timertest.Step{After: step, Do: func(*timer.Timer) error {
+					// gives the loop time to process the tick before the clock moves on
+					time.Sleep(10 * time.Millisecond)
+					return nil
+				}})
+			}
+			timertest.Run(t, tm, clock, steps...)
+
+			if test.want {
+				e := timertest.AssertEmits(t, anomalies.C, 0)
+				if e.Anomaly == nil || e.Anomaly.Kind != timer.AnomalyTickGap {
+					t.Fatalf("got anomaly %+v, want a tick gap", e.Anomaly)
+				}
+				return
+			}
+			timertest.AssertNoEmit(t, anomalies.C, 100*time.Millisecond)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// bests holds the personal best and the best segment of every subtimer across runs
+// improved is set if the last stop set a new personal best, previousPB is the personal best it replaced
+type bests struct {
+	pb         time.Duration
+	segments   map[int]time.Duration
+	improved   bool
+	previousPB time.Duration
+}
+
+// BestSegment describes a segment which was faster than the best segment recorded before
+type BestSegment struct {
+	Time time.Duration `json:"time"`
+	// Previous is the best segment time before this one
+	Previous time.Duration `json:"previous"`
+}
+
+// PersonalBest returns the final time of the fastest completed run
+// ok is false if no run has been completed yet
+func (t *Timer) PersonalBest() (pb time.Duration, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.bests.pb, t.bests.pb > 0
+}
+
+// SumOfBest returns the sum of the best segments of all subtimers
+// ok is false if no segment has been recorded yet
+func (t *Timer) SumOfBest() (sob time.Duration, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, d := range t.bests.segments {
+		sob += d
+	}
+
+	return sob, len(t.bests.segments) > 0
+}
+
+// BestSegments returns the best segment time of every subtimer keyed by id
+// the segment of a subtimer is the time between the previous split and its own
+func (t *Timer) BestSegments() map[int]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segments := make(map[int]time.Duration, len(t.bests.segments))
+	for id, d := range t.bests.segments {
+		segments[id] = d
+	}
+
+	return segments
+}
+
+// SetBests sets the personal best and best segments, e.g. loaded from a previous session
+// Setting 0 for pb and nil for segments clears them
+func (t *Timer) SetBests(pb time.Duration, segments map[int]time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if pb < 0 {
+		return fmt.Errorf("Only positive values for pb are allowed")
+	}
+	t.bests.pb = pb
+	t.bests.segments = make(map[int]time.Duration, len(segments))
+	for id, d := range segments {
+		if d <= 0 {
+			return fmt.Errorf("Segment of subtimer %v has to be positive", id)
+		}
+		t.bests.segments[id] = d
+	}
+
+	return nil
+}
+
+// updateBests updates the best segments with the splits of the finished run and the personal best if it was completed
+func (t *Timer) updateBests() {
+	r := t.reportLocked()
+	if t.bests.segments == nil {
+		t.bests.segments = make(map[int]time.Duration)
+	}
+	for id, d := range segments(r.Splits) {
+		if best, ok := t.bests.segments[id]; d > 0 && (!ok || d < best) {
+			t.bests.segments[id] = d
+		}
+	}
+	t.bests.improved = false
+	if r.Completed() && (t.bests.pb == 0 || r.FinalTime < t.bests.pb) {
+		t.bests.improved = true
+		t.bests.previousPB = t.bests.pb
+		t.bests.pb = r.FinalTime
+	}
+}
+
+// revertPersonalBest restores the personal best replaced by the last stop when the stop is undone
+func (t *Timer) revertPersonalBest() {
+	if !t.bests.improved {
+		return
+	}
+	t.bests.pb = t.bests.previousPB
+	t.bests.improved = false
+	t.bests.previousPB = 0
+}
+
+// checkBestSegment marks s as gold and emits EventBestSegment if its segment is faster than the best one recorded before
+// it has to be called right after s was stopped
+func (t *Timer) checkBestSegment(id int, s *subtimer) {
+	var previous time.Duration
+	for other, o := range t.subtimers {
+		if other != id && o.state == Stopped && o.Time <= s.Time && o.Time > previous {
+			previous = o.Time
+		}
+	}
+	d := s.Time - previous
+	best, ok := t.bests.segments[id]
+	if !ok || d <= 0 || d >= best {
+		return
+	}
+
+	s.gold = true
+	s.previousBest = best
+	t.bests.segments[id] = d
+	t.emit(Event{Type: EventBestSegment, Elapsed: t.elapsed, Subtimer: intPtr(id), BestSegment: &BestSegment{Time: d, Previous: best}})
+}
+
+// revertBestSegment restores the best segment s replaced when its stop is undone
+func (t *Timer) revertBestSegment(id int, s *subtimer) {
+	if !s.gold {
+		return
+	}
+	t.bests.segments[id] = s.previousBest
+	s.gold = false
+	s.previousBest = 0
+}
@@ -0,0 +1,129 @@
+package timer_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+// finishRun runs tm from reset with subtimers stopped at the given times and stops it after the last one
+func finishRun(t *testing.T, tm *timer.Timer, clock *timertest.Clock, splits ...time.Duration) {
+	t.Helper()
+
+	steps := []timertest.Step{{Do: timertest.Start}}
+	var last time.Duration
+	for i, d := range splits {
+		steps = append(steps, timertest.Step{After: d - last, Do: timertest.StopSubTimer(i + 1)})
+		last = d
+	}
+	steps = append(steps, timertest.Step{Do: timertest.Stop})
+	timertest.Run(t, tm, clock, steps...)
+	prepare(t, tm)
+}
+
+func TestPersonalBest(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2))
+	defer tm.Close()
+
+	if _, ok := tm.PersonalBest(); ok {
+		t.Errorf("found a personal best before the first run")
+	}
+	if _, ok := tm.SumOfBest(); ok {
+		t.Errorf("found a sum of best before the first run")
+	}
+
+	finishRun(t, tm, clock, 2*time.Second, 5*time.Second)
+	finishRun(t, tm, clock, time.Second, 6*time.Second)
+	// unfinished runs only count for the best segments
+	finishRun(t, tm, clock, 500*time.Millisecond)
+
+	if pb, ok := tm.PersonalBest(); !ok || pb != 5*time.Second {
+		t.Errorf("personal best is %v, %v, want 5s", pb, ok)
+	}
+	want := map[int]time.Duration{1: 500 * time.Millisecond, 2: 3 * time.Second}
+	if got := tm.BestSegments(); !reflect.DeepEqual(got, want) {
+		t.Errorf("best segments are %v, want %v", got, want)
+	}
+	if sob, ok := tm.SumOfBest(); !ok || sob != 3500*time.Millisecond {
+		t.Errorf("sum of best is %v, %v, want 3.5s", sob, ok)
+	}
+}
+
+func TestSetBests(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+
+	if err := tm.SetBests(-time.Second, nil); err == nil {
+		t.Errorf("setting a negative personal best succeeded, want an error")
+	}
+	if err := tm.SetBests(time.Second, map[int]time.Duration{1: 0}); err == nil {
+		t.Errorf("setting an empty segment succeeded, want an error")
+	}
+	if err := tm.SetBests(10*time.Second, map[int]time.Duration{1: 4 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+
+	// slower runs keep the loaded bests
+	finishRun(t, tm, clock, 11*time.Second)
+	if pb, _ := tm.PersonalBest(); pb != 10*time.Second {
+		t.Errorf("personal best is %v, want 10s", pb)
+	}
+	if sob, _ := tm.SumOfBest(); sob != 4*time.Second {
+		t.Errorf("sum of best is %v, want 4s", sob)
+	}
+
+	if err := tm.SetBests(0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tm.PersonalBest(); ok {
+		t.Errorf("found a personal best after clearing it")
+	}
+	if _, ok := tm.SumOfBest(); ok {
+		t.Errorf("found a sum of best after clearing it")
+	}
+}
+
+func TestBestSegmentEvents(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2, 3))
+	defer tm.Close()
+	golds := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventBestSegment}}))
+	if err := tm.SetBests(0, map[int]time.Duration{1: 2 * time.Second, 2: 3 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{After: 4 * time.Second, Do: timertest.StopSubTimer(2)},
+		// subtimers without a best segment can't be gold
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(3)},
+	)
+	e := timertest.AssertEmitsType(t, golds.C, timer.EventBestSegment, 0)
+	if want := (timer.BestSegment{Time: time.Second, Previous: 2 * time.Second}); e.Subtimer == nil || *e.Subtimer != 1 || e.BestSegment == nil || *e.BestSegment != want {
+		t.Errorf("best segment event for subtimer %v with %+v, want subtimer 1 with %+v", e.Subtimer, e.BestSegment, want)
+	}
+	timertest.AssertNoEmit(t, golds.C, 10*time.Millisecond)
+	for _, s := range tm.Report().Subtimers {
+		if s.Gold != (s.ID == 1) {
+			t.Errorf("subtimer %v has gold %v", s.ID, s.Gold)
+		}
+	}
+	if d := tm.BestSegments()[1]; d != time.Second {
+		t.Errorf("best segment of subtimer 1 is %v, want 1s", d)
+	}
+
+	// undoing the stop restores the replaced best segment
+	if err := tm.UndoStopSubTimer(1); err != nil {
+		t.Fatal(err)
+	}
+	if d := tm.BestSegments()[1]; d != 2*time.Second {
+		t.Errorf("best segment of subtimer 1 is %v after the undo, want 2s", d)
+	}
+}
@@ -0,0 +1,133 @@
+package timer
+
+import "time"
+
+// StopAllSubTimers stops all running and paused subtimers at the same time
+// the completion policy is applied once after all subtimers have been stopped. It returns the recorded times keyed by id.
+// Only works when the timer is running or paused
+func (t *Timer) StopAllSubTimers() (map[int]time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stopped, err := t.stopAllSubTimersLocked()
+
+	return stopped, t.logFailed("StopAllSubTimers", err)
+}
+
+func (t *Timer) stopAllSubTimersLocked() (map[int]time.Duration, error) {
+	if t.closed {
+		return nil, ErrClosed
+	}
+	t.refreshElapsed()
+	if t.state != Running && t.state != Paused {
+		return nil, &StateError{Op: "StopAllSubTimers", Current: t.state}
+	}
+	stopped := make(map[int]time.Duration)
+	for id, s := range t.subtimers {
+		if s.state != Running && s.state != Paused {
+			continue
+		}
+		t.stopSubTimer(s)
+		stopped[id] = s.Time
+	}
+	t.finishBulk(EventSubtimersStopped, stopped)
+
+	return stopped, nil
+}
+
+// ForfeitAllRunning forfeits all running and paused subtimers at the same time
+// the completion policy is applied once after all subtimers have been forfeited. It returns the frozen times keyed by id.
+// Only works when the timer is running or paused
+func (t *Timer) ForfeitAllRunning() (map[int]time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	forfeited, err := t.forfeitAllRunningLocked()
+
+	return forfeited, t.logFailed("ForfeitAllRunning", err)
+}
+
+func (t *Timer) forfeitAllRunningLocked() (map[int]time.Duration, error) {
+	if t.closed {
+		return nil, ErrClosed
+	}
+	t.refreshElapsed()
+	if t.state != Running && t.state != Paused {
+		return nil, &StateError{Op: "ForfeitAllRunning", Current: t.state}
+	}
+	forfeited := make(map[int]time.Duration)
+	for id, s := range t.subtimers {
+		if s.state != Running && s.state != Paused {
+			continue
+		}
+		t.forfeitSubTimer(s)
+		forfeited[id] = s.Time
+	}
+	t.finishBulk(EventSubtimersForfeited, forfeited)
+
+	return forfeited, nil
+}
+
+// ResetAllSubTimers discards the recorded data of all subtimers
+// while the timer is running or paused the subtimers restart from the current time, otherwise they return to Reset state.
+// Settings like offsets, seeds and pause budgets are kept. Adjudicated subtimers are not reset.
+// Only works when the timer is reset, running or paused
+func (t *Timer) ResetAllSubTimers() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("ResetAllSubTimers", t.resetAllSubTimersLocked())
+}
+
+func (t *Timer) resetAllSubTimersLocked() error {
+	if t.closed {
+		return ErrClosed
+	}
+	t.refreshElapsed()
+	if t.state != Reset && t.state != Running && t.state != Paused {
+		return &StateError{Op: "ResetAllSubTimers", Current: t.state}
+	}
+	reset := make(map[int]time.Duration)
+	for id, s := range t.subtimers {
+		if s.adjudicated {
+			continue
+		}
+		*s = subtimer{
+			offset:          s.offset,
+			adjustment:      s.adjustment,
+			throttle:        s.throttle,
+			pauseBudget:     s.pauseBudget,
+			forfeitOnBudget: s.forfeitOnBudget,
+			name:            s.name,
+			description:     s.description,
+			tags:            s.tags,
+			seed:            s.seed,
+			bracket:         s.bracket,
+			state:           Reset,
+		}
+		if t.state == Running || t.state == Paused {
+			s.state = Running
+			s.start = t.elapsed
+		}
+		reset[id] = 0
+	}
+	if len(reset) > 0 {
+		t.emit(Event{Type: EventSubtimersReset, Elapsed: t.elapsed, Subtimers: reset})
+	}
+
+	return nil
+}
+
+// finishBulk emits a single event for a bulk operation and applies the completion policy
+func (t *Timer) finishBulk(typ EventType, affected map[int]time.Duration) {
+	if len(affected) == 0 {
+		return
+	}
+	t.emit(Event{Type: typ, Elapsed: t.elapsed, Subtimers: affected})
+
+	if t.stopOnSubtimersStop && t.checkSubTimerFinish() {
+		if t.stopTimerLocked() == nil {
+			t.autoStopped = true
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestBulkOpsRequireState(t *testing.T) {
+	ops := []struct {
+		name  string
+		op    timer.Operation
+		reset bool
+	}{
+		{"stop all", timer.StopAllSubsOp{}, false},
+		{"forfeit all", timer.ForfeitAllSubsOp{}, false},
+		{"reset all", timer.ResetAllSubsOp{}, true},
+	}
+	tests := []struct {
+		name  string
+		steps []timertest.Step
+		// allowed reports whether op is allowed in the state reached by steps
+		allowed func(reset bool) bool
+	}{
+		{"reset", nil, func(reset bool) bool { return reset }},
+		{"running", []timertest.Step{{Do: timertest.Start}}, func(bool) bool { return true }},
+		{"paused", []timertest.Step{{Do: timertest.Start}, {After: time.Second, Do: timertest.Pause}}, func(bool) bool { return true }},
+		{"stopped", []timertest.Step{{Do: timertest.Start}, {After: time.Second, Do: timertest.Stop}}, func(bool) bool { return false }},
+	}
+
+	for _, op := range ops {
+		for _, test := range tests {
+			t.Run(op.name+" "+test.name, func(t *testing.T) {
+				clock := timertest.NewClock(time.Now())
+				tm := newTimer(t, clock)
+				defer tm.Close()
+				if err := tm.AddSubTimer(1); err != nil {
+					t.Fatal(err)
+				}
+				timertest.Run(t, tm, clock, test.steps...)
+
+				err := tm.ApplyOp(op.op)
+				if test.allowed(op.reset) {
+					if err != nil {
+						t.Fatalf("got error %v, want nil", err)
+					}
+					return
+				}
+				if !errors.Is(err, timer.ErrInvalidState) {
+					t.Fatalf("got error %v, want %v", err, timer.ErrInvalidState)
+				}
+			})
+		}
+	}
+}
+
+func TestBulkOpsAfterClose(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	if err := tm.AddSubTimer(1); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	tm.Close()
+
+	if _, err := tm.StopAllSubTimers(); err != timer.ErrClosed {
+		t.Errorf("StopAllSubTimers returned %v, want %v", err, timer.ErrClosed)
+	}
+	if _, err := tm.ForfeitAllRunning(); err != timer.ErrClosed {
+		t.Errorf("ForfeitAllRunning returned %v, want %v", err, timer.ErrClosed)
+	}
+	if err := tm.ResetAllSubTimers(); err != timer.ErrClosed {
+		t.Errorf("ResetAllSubTimers returned %v, want %v", err, timer.ErrClosed)
+	}
+}
@@ -0,0 +1,59 @@
+package timer
+
+import "time"
+
+// On calls f for every event of type typ and returns a function removing the callback
+// callbacks are called in order from their own goroutine, so they may call methods of the timer.
+// Tick events are coalesced if f falls behind. Callbacks don't depend on t.Updates being drained, closing the timer removes them
+func (t *Timer) On(typ EventType, f func(e Event)) (remove func()) {
+	sub := t.Subscribe(WithFilter(Filter{Types: []EventType{typ}}), WithOverflow(Coalesce))
+	t.mu.Lock()
+	t.goWorker(roleCallback, func() {
+		for e := range sub.C {
+			f(e)
+		}
+	})
+	t.mu.Unlock()
+
+	return sub.Close
+}
+
+// OnStart calls f every time the timer is started
+func (t *Timer) OnStart(f func()) (remove func()) {
+	return t.On(EventStarted, func(Event) {
+		f()
+	})
+}
+
+// OnPause calls f with the elapsed time every time the timer is paused
+func (t *Timer) OnPause(f func(elapsed time.Duration)) (remove func()) {
+	return t.onElapsed(EventPaused, f)
+}
+
+// OnResume calls f with the elapsed time every time the timer is resumed
+func (t *Timer) OnResume(f func(elapsed time.Duration)) (remove func()) {
+	return t.onElapsed(EventResumed, f)
+}
+
+// OnStop calls f with the final time every time the timer is stopped
+func (t *Timer) OnStop(f func(elapsed time.Duration)) (remove func()) {
+	return t.onElapsed(EventStopped, f)
+}
+
+// OnReset calls f every time the timer is reset
+func (t *Timer) OnReset(f func()) (remove func()) {
+	return t.On(EventReset, func(Event) {
+		f()
+	})
+}
+
+// OnTick calls f with the elapsed time on every update of the running timer
+func (t *Timer) OnTick(f func(elapsed time.Duration)) (remove func()) {
+	return t.onElapsed(EventTick, f)
+}
+
+func (t *Timer) onElapsed(typ EventType, f func(elapsed time.Duration)) func() {
+	return t.On(typ, func(e Event) {
+		f(e.Elapsed)
+	})
+}
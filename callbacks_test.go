@@ -0,0 +1,113 @@
+package timer_test
+
+import "sync/atomic"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestCallbacksWithoutUpdatesReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(tm *timer.Timer, n *int32) func()
+		// want is the minimum number of calls while the timer runs for 300ms
+		want int32
+	}{
+		{"OnTick", func(tm *timer.Timer, n *int32) func() {
+			return tm.OnTick(func(time.Duration) { atomic.AddInt32(n, 1) })
+		}, 5},
+		{"On tick events", func(tm *timer.Timer, n *int32) func() {
+			return tm.On(timer.EventTick, func(timer.Event) { atomic.AddInt32(n, 1) })
+		}, 5},
+		{"OnStart", func(tm *timer.Timer, n *int32) func() {
+			return tm.OnStart(func() { atomic.AddInt32(n, 1) })
+		}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := timer.New()
+			defer tm.Close()
+			if err := tm.ResetTimer(); err != nil {
+				t.Fatal(err)
+			}
+			var n int32
+			remove := test.register(tm, &n)
+			defer remove()
+
+			if err := tm.StartTimer(); err != nil {
+				t.Fatal(err)
+			}
+			deadline := time.Now().Add(300 * time.Millisecond)
+			for atomic.LoadInt32(&n) < test.want && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			if got := atomic.LoadInt32(&n); got < test.want {
+				t.Errorf("callback was called %v times in 300ms, want at least %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLifecycleCallbacks(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	starts := make(chan struct{}, 4)
+	resets := make(chan struct{}, 4)
+	paused := make(chan time.Duration, 4)
+	resumed := make(chan time.Duration, 4)
+	stopped := make(chan time.Duration, 4)
+	removeStart := tm.OnStart(func() { starts <- struct{}{} })
+	tm.OnReset(func() { resets <- struct{}{} })
+	tm.OnPause(func(d time.Duration) { paused <- d })
+	tm.OnResume(func(d time.Duration) { resumed <- d })
+	// callbacks may call methods of the timer
+	tm.OnStop(func(time.Duration) { stopped <- tm.Report().FinalTime })
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.Pause},
+		timertest.Step{After: time.Second, Do: timertest.Resume},
+		timertest.Step{After: time.Second, Do: timertest.Stop},
+	)
+	receive := func(name string, c <-chan time.Duration, want time.Duration) {
+		t.Helper()
+		select {
+		case d := <-c:
+			if d != want {
+				t.Errorf("%v called with %v, want %v", name, d, want)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%v not called", name)
+		}
+	}
+	receive("OnPause", paused, time.Second)
+	receive("OnResume", resumed, time.Second)
+	receive("OnStop", stopped, 2*time.Second)
+	select {
+	case <-starts:
+	case <-time.After(time.Second):
+		t.Errorf("OnStart not called")
+	}
+
+	// removed callbacks aren't called anymore
+	removeStart()
+	prepare(t, tm)
+	select {
+	case <-resets:
+	case <-time.After(time.Second):
+		t.Errorf("OnReset not called")
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	select {
+	case <-starts:
+		t.Errorf("removed OnStart callback was called")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
@@ -0,0 +1,65 @@
+package timer
+
+// ProtocolVersion is the version of the JSON encoding of events, reports and results
+// it is increased on every incompatible change
+const ProtocolVersion = 1
+
+// Feature names an optional feature of the library
+type Feature string
+
+const (
+	// FeatureCountdown is set when timers can count down to a target
+	FeatureCountdown Feature = "countdown"
+	// FeatureResumeAfterStop is set when stopped timers can be resumed if allowed by the config
+	FeatureResumeAfterStop Feature = "resume-after-stop"
+	// FeatureSubtimerSplits is set when subtimers can record splits at shared segments
+	FeatureSubtimerSplits Feature = "subtimer-splits"
+	// FeatureSubtimerPause is set when single subtimers can be paused
+	FeatureSubtimerPause Feature = "subtimer-pause"
+	// FeatureRelay is set when subtimers can be handed over between team members
+	FeatureRelay Feature = "relay"
+	// FeatureAdjudication is set when official times can be set for subtimers
+	FeatureAdjudication Feature = "adjudication"
+	// FeaturePrediction is set when tick events carry predicted final times
+	FeaturePrediction Feature = "prediction"
+	// FeatureClockSync is set when clients can synchronize their clock with a timer host
+	FeatureClockSync Feature = "clock-sync"
+)
+
+// CapabilitySet describes the features and protocol version supported by the library
+type CapabilitySet struct {
+	Protocol int       `json:"protocol"`
+	Features []Feature `json:"features"`
+}
+
+// supportedFeatures lists all features implemented by this version of the library
+var supportedFeatures = []Feature{
+	FeatureCountdown,
+	FeatureResumeAfterStop,
+	FeatureSubtimerSplits,
+	FeatureSubtimerPause,
+	FeatureRelay,
+	FeatureAdjudication,
+	FeaturePrediction,
+	FeatureClockSync,
+}
+
+// Capabilities returns the features and protocol version supported by the library
+// network clients and plugins should use it to negotiate behavior instead of relying on version strings
+func Capabilities() CapabilitySet {
+	features := make([]Feature, len(supportedFeatures))
+	copy(features, supportedFeatures)
+
+	return CapabilitySet{Protocol: ProtocolVersion, Features: features}
+}
+
+// Supports reports whether f is part of the capability set
+func (c CapabilitySet) Supports(f Feature) bool {
+	for _, feature := range c.Features {
+		if feature == f {
+			return true
+		}
+	}
+
+	return false
+}
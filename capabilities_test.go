@@ -0,0 +1,40 @@
+package timer_test
+
+import "encoding/json"
+
+import "testing"
+
+import "github.com/onestay/timer-core"
+
+func TestCapabilities(t *testing.T) {
+	c := timer.Capabilities()
+	if c.Protocol != timer.ProtocolVersion {
+		t.Errorf("protocol is %v, want %v", c.Protocol, timer.ProtocolVersion)
+	}
+	for _, f := range []timer.Feature{timer.FeatureCountdown, timer.FeatureRelay, timer.FeatureClockSync} {
+		if !c.Supports(f) {
+			t.Errorf("%v is not supported", f)
+		}
+	}
+	if c.Supports("teleport") {
+		t.Errorf("unknown feature is supported")
+	}
+
+	// modifying the returned set doesn't change the supported features
+	c.Features[0] = "teleport"
+	if !timer.Capabilities().Supports(timer.FeatureCountdown) || timer.Capabilities().Supports("teleport") {
+		t.Errorf("modifying the capability set changed the supported features")
+	}
+
+	data, err := json.Marshal(timer.Capabilities())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded timer.CapabilitySet
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Protocol != timer.ProtocolVersion || !decoded.Supports(timer.FeaturePrediction) {
+		t.Errorf("decoded %+v, want the supported capabilities", decoded)
+	}
+}
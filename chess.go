@@ -0,0 +1,275 @@
+package timer
+
+import "fmt"
+
+import "sync"
+
+import "time"
+
+// IncrementMode describes how time is added to a player of a chess clock after each move
+type IncrementMode int
+
+const (
+	// NoIncrement never adds time
+	NoIncrement IncrementMode = iota
+	// Fischer adds the increment after every move
+	Fischer
+	// Bronstein adds back the time used for the move, at most the increment
+	Bronstein
+)
+
+// ChessConfig configures a chess clock
+type ChessConfig struct {
+	// Players is the number of players. Setting 0 uses 2 players
+	Players int
+	// Initial is the time every player starts with
+	Initial   time.Duration
+	Mode      IncrementMode
+	Increment time.Duration
+	// OnFlag is called when the time of a player runs out. It is called without holding any lock
+	OnFlag func(player int)
+	// Clock is the clock used for measuring. Setting nil uses the real clock
+	Clock Clock
+}
+
+// ChessClock tracks the remaining time of multiple players who take turns
+// ending the turn of one player starts the clock of the next one. Players are numbered from 0
+type ChessClock struct {
+	cfg       ChessConfig
+	mu        sync.Mutex
+	remaining []time.Duration
+	moves     []int
+	// active is the player whose clock runs, -1 before the start. turnStart is the time the active clock was started
+	active    int
+	turnStart time.Time
+	running   bool
+	flagged   int
+	// turn counts the started turns so a late flag alarm of an earlier turn is ignored
+	turn int
+	flag Alarm
+}
+
+// NewChessClock returns a new chess clock. The clock is started by Start
+func NewChessClock(cfg ChessConfig) (*ChessClock, error) {
+	if cfg.Players == 0 {
+		cfg.Players = 2
+	}
+	if cfg.Players < 2 {
+		return nil, fmt.Errorf("A chess clock needs at least two players")
+	}
+	if cfg.Initial <= 0 {
+		return nil, fmt.Errorf("Only positive values for Initial are allowed")
+	}
+	if cfg.Increment < 0 {
+		return nil, fmt.Errorf("Only positive values for Increment are allowed")
+	}
+	if cfg.Mode != NoIncrement && cfg.Mode != Fischer && cfg.Mode != Bronstein {
+		return nil, fmt.Errorf("Unknown increment mode %v", cfg.Mode)
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = realClock{}
+	}
+
+	c := &ChessClock{
+		cfg:       cfg,
+		remaining: make([]time.Duration, cfg.Players),
+		moves:     make([]int, cfg.Players),
+		active:    -1,
+		flagged:   -1,
+	}
+	for i := range c.remaining {
+		c.remaining[i] = cfg.Initial
+	}
+
+	return c, nil
+}
+
+// Start starts the clock of player
+// only possible before the first turn
+func (c *ChessClock) Start(player int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.active != -1 {
+		return &StateError{Op: "Start", Current: c.state()}
+	}
+	if player < 0 || player >= len(c.remaining) {
+		return fmt.Errorf("Player %v does not exist", player)
+	}
+	c.active = player
+	c.run()
+
+	return nil
+}
+
+// Switch ends the turn of the active player, applies the increment and starts the clock of the next player
+// it returns the player whose clock is running now. If the time of the active player has already run out
+// the player is flagged instead and Switch returns the flagged player and ErrFlagged
+func (c *ChessClock) Switch() (int, error) {
+	c.mu.Lock()
+
+	if !c.running {
+		c.mu.Unlock()
+		return c.active, &StateError{Op: "Switch", Current: c.state()}
+	}
+
+	used := c.cfg.Clock.Now().Sub(c.turnStart)
+	if c.remaining[c.active] <= used {
+		player := c.active
+		c.flagPlayer()
+		c.mu.Unlock()
+
+		if c.cfg.OnFlag != nil {
+			c.cfg.OnFlag(player)
+		}
+		return player, ErrFlagged
+	}
+
+	c.halt()
+	switch c.cfg.Mode {
+	case Fischer:
+		c.remaining[c.active] += c.cfg.Increment
+	case Bronstein:
+		if used < c.cfg.Increment {
+			c.remaining[c.active] += used
+		} else {
+			c.remaining[c.active] += c.cfg.Increment
+		}
+	}
+	c.moves[c.active]++
+	c.active = (c.active + 1) % len(c.remaining)
+	c.run()
+	active := c.active
+	c.mu.Unlock()
+
+	return active, nil
+}
+
+// Pause stops the clock of the active player without ending the turn
+func (c *ChessClock) Pause() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return &StateError{Op: "Pause", Current: c.state()}
+	}
+	c.halt()
+
+	return nil
+}
+
+// Resume continues the clock of the active player after Pause
+func (c *ChessClock) Resume() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.running || c.active == -1 || c.flagged != -1 {
+		return &StateError{Op: "Resume", Current: c.state()}
+	}
+	c.run()
+
+	return nil
+}
+
+// Remaining returns the remaining time of player
+func (c *ChessClock) Remaining(player int) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if player < 0 || player >= len(c.remaining) {
+		return 0
+	}
+	d := c.remaining[player]
+	if c.running && player == c.active {
+		d -= c.cfg.Clock.Now().Sub(c.turnStart)
+	}
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
+
+// Moves returns the number of finished turns of player
+func (c *ChessClock) Moves(player int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if player < 0 || player >= len(c.moves) {
+		return 0
+	}
+
+	return c.moves[player]
+}
+
+// Active returns the player whose turn it is. It is -1 before the start
+func (c *ChessClock) Active() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.active
+}
+
+// Flagged returns the player whose time ran out
+// ok is false while all players have time left
+func (c *ChessClock) Flagged() (player int, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.flagged, c.flagged != -1
+}
+
+// state maps the chess clock to a timer state for errors
+// it is Reset before the start and Stopped once a player has flagged
+func (c *ChessClock) state() State {
+	switch {
+	case c.active == -1:
+		return Reset
+	case c.flagged != -1:
+		return Stopped
+	case c.running:
+		return Running
+	}
+
+	return Paused
+}
+
+// run starts the clock of the active player and schedules its flag
+// the flag only fires for the turn it was scheduled for, an alarm firing late after a switch is ignored
+func (c *ChessClock) run() {
+	c.running = true
+	c.turnStart = c.cfg.Clock.Now()
+	c.turn++
+	player, turn := c.active, c.turn
+	c.flag = c.cfg.Clock.AfterFunc(c.remaining[player], func() {
+		c.mu.Lock()
+		if !c.running || c.turn != turn {
+			c.mu.Unlock()
+			return
+		}
+		c.flagPlayer()
+		c.mu.Unlock()
+
+		if c.cfg.OnFlag != nil {
+			c.cfg.OnFlag(player)
+		}
+	})
+}
+
+// flagPlayer stops the clock because the time of the active player has run out
+// OnFlag has to be called by the caller after releasing the lock
+func (c *ChessClock) flagPlayer() {
+	c.halt()
+	c.remaining[c.active] = 0
+	c.flagged = c.active
+}
+
+// halt stops the clock of the active player and books the time used
+func (c *ChessClock) halt() {
+	c.remaining[c.active] -= c.cfg.Clock.Now().Sub(c.turnStart)
+	c.running = false
+	if c.flag != nil {
+		c.flag.Stop()
+		c.flag = nil
+	}
+}
@@ -0,0 +1,87 @@
+package timer_test
+
+import "errors"
+
+import "sync"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestChessSwitchAtFlag(t *testing.T) {
+	const initial = time.Second
+
+	tests := []struct {
+		name    string
+		used    time.Duration
+		flagged bool
+	}{
+		{"switch before the flag", initial - time.Millisecond, false},
+		{"switch at the flag", initial, true},
+		{"switch after the flag", initial + time.Millisecond, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			var mu sync.Mutex
+			var flags []int
+			c, err := timer.NewChessClock(timer.ChessConfig{
+				Initial: initial,
+				Clock:   clock,
+				OnFlag: func(player int) {
+					mu.Lock()
+					flags = append(flags, player)
+					mu.Unlock()
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Start(0); err != nil {
+				t.Fatal(err)
+			}
+
+			clock.Advance(test.used)
+			active, err := c.Switch()
+			if !test.flagged {
+				if err != nil || active != 1 {
+					t.Fatalf("Switch returned %v, %v, want 1, nil", active, err)
+				}
+				if d := c.Remaining(0); d != initial-test.used {
+					t.Errorf("remaining time of player 0 is %v, want %v", d, initial-test.used)
+				}
+				if _, ok := c.Flagged(); ok {
+					t.Errorf("a player is flagged")
+				}
+				return
+			}
+
+			// the flag alarm may have flagged the player before the switch
+			if !errors.Is(err, timer.ErrFlagged) && !errors.Is(err, timer.ErrInvalidState) {
+				t.Fatalf("Switch returned %v, want ErrFlagged or a state error", err)
+			}
+			if player, ok := c.Flagged(); !ok || player != 0 {
+				t.Errorf("flagged player is %v, %v, want 0, true", player, ok)
+			}
+			if a := c.Active(); a != 0 {
+				t.Errorf("active player is %v, want 0", a)
+			}
+			if d := c.Remaining(0); d != 0 {
+				t.Errorf("remaining time of player 0 is %v, want 0", d)
+			}
+
+			// OnFlag is called exactly once, also if the alarm fires late
+			time.Sleep(50 * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			if len(flags) != 1 || flags[0] != 0 {
+				t.Errorf("OnFlag was called with %v, want [0]", flags)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package timer
+
+import "time"
+
+// Clock provides the current time and timing primitives to a timer
+// the default clock uses the time package. Replacing it allows driving a timer deterministically
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, f func()) Alarm
+}
+
+// Ticker delivers ticks at intervals like time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Alarm is a pending function call created by Clock.AfterFunc
+type Alarm interface {
+	// Stop prevents the call and reports whether it was still pending
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Alarm {
+	return time.AfterFunc(d, f)
+}
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r realTicker) C() <-chan time.Time {
+	return r.t.C
+}
+
+func (r realTicker) Stop() {
+	r.t.Stop()
+}
+
+// SetClock replaces the clock used by the timer
+// Only works when timer is stopped. Setting nil sets it back to the real clock
+func (t *Timer) SetClock(c Clock) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.setClockLocked(c)
+}
+
+func (t *Timer) setClockLocked(c Clock) error {
+	if t.state != Stopped {
+		return &StateError{Op: "SetClock", Current: t.state}
+	}
+	if c == nil {
+		c = realClock{}
+	}
+	t.clock = c
+	t.source = nil
+	t.epoch = c.Now()
+
+	return nil
+}
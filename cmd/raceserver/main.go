@@ -0,0 +1,30 @@
+// Command raceserver is a reference race server built on timer-core
+//
+// It runs a single race timer with one subtimer per runner, broadcasts all events over WebSocket,
+// accepts control commands over a small REST API, persists the report of every finished run and
+// aggregates the results of consecutive heats into a series.
+package main
+
+import "flag"
+
+import "log"
+
+import "net/http"
+
+import "github.com/onestay/timer-core"
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dataDir := flag.String("data", "raceserver-data", "directory for persisted reports and series results")
+	runners := flag.Int("runners", 4, "number of runners, each gets a subtimer with ids starting at 1")
+	bestOf := flag.Int("best-of", 3, "series score is the best time of this many heats")
+	flag.Parse()
+
+	s, err := newServer(*dataDir, *runners, timer.BestOf(*bestOf))
+	if err != nil {
+		log.Fatalf("creating server: %v", err)
+	}
+
+	log.Printf("listening on %v", *addr)
+	log.Fatal(http.ListenAndServe(*addr, s.routes()))
+}
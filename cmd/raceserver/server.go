@@ -0,0 +1,325 @@
+package main
+
+import "encoding/json"
+
+import "fmt"
+
+import "io/ioutil"
+
+import "log"
+
+import "net/http"
+
+import "os"
+
+import "path/filepath"
+
+import "strconv"
+
+import "strings"
+
+import "sync"
+
+import "github.com/gorilla/websocket"
+
+import "github.com/onestay/timer-core"
+
+const (
+	seriesFile   = "series.json"
+	snapshotFile = "snapshot.json"
+)
+
+type server struct {
+	// mu serializes all operations on the timer
+	mu       sync.Mutex
+	t        *timer.Timer
+	series   *timer.Series
+	runners  int
+	dataDir  string
+	upgrader websocket.Upgrader
+}
+
+func newServer(dataDir string, runners int, aggregate timer.Aggregate) (*server, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &server{
+		t:       timer.New(),
+		series:  timer.NewSeries(aggregate),
+		runners: runners,
+		dataDir: dataDir,
+	}
+	if err := s.loadSeries(); err != nil {
+		return nil, err
+	}
+	restored, err := s.loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	if !restored {
+		if err := s.reset(); err != nil {
+			return nil, err
+		}
+	}
+
+	go s.persistRuns()
+
+	return s, nil
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", s.handleWebSocket)
+	mux.HandleFunc("/report", s.handleReport)
+	mux.HandleFunc("/results", s.handleResults)
+	mux.HandleFunc("/series", s.handleSeries)
+	mux.HandleFunc("/capabilities", s.handleCapabilities)
+	mux.HandleFunc("/control/", s.handleControl)
+	mux.HandleFunc("/subtimers/", s.handleSubtimer)
+
+	return mux
+}
+
+// reset resets the timer and adds one subtimer per runner
+func (s *server) reset() error {
+	if err := s.t.ResetTimer(); err != nil {
+		return err
+	}
+	for id := 1; id <= s.runners; id++ {
+		if err := s.t.AddSubTimer(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleControl handles POST /control/{start,pause,resume,stop,reset,heat}
+func (s *server) handleControl(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	annotated := s.t.WithPayload(r.RemoteAddr)
+	var err error
+	switch strings.TrimPrefix(r.URL.Path, "/control/") {
+	case "start":
+		err = annotated.StartTimer()
+	case "pause":
+		err = annotated.PauseTimer()
+	case "resume":
+		err = annotated.ResumeTimer()
+	case "stop":
+		err = annotated.StopTimer()
+	case "reset":
+		err = s.reset()
+	case "heat":
+		err = s.finishHeat()
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	s.saveSnapshot()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleSubtimer handles POST /subtimers/{id}/{stop,split,forfeit}
+func (s *server) handleSubtimer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/subtimers/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.Error(w, "invalid subtimer id", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch parts[1] {
+	case "stop":
+		_, err = s.t.WithPayload(r.RemoteAddr).StopSubTimer(id)
+	case "split":
+		err = s.t.ApplyOp(timer.SplitOp{ID: id})
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	s.saveSnapshot()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) handleReport(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	report := s.t.Report()
+	s.mu.Unlock()
+
+	writeJSON(w, report)
+}
+
+func (s *server) handleResults(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	results := s.t.RaceResult()
+	s.mu.Unlock()
+
+	writeJSON(w, results)
+}
+
+func (s *server) handleSeries(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.series.Standings())
+}
+
+func (s *server) handleCapabilities(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, timer.Capabilities())
+}
+
+// handleWebSocket streams all events of the timer to the client as JSON frames
+func (s *server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sub := s.t.Subscribe(timer.WithOverflow(timer.Coalesce))
+	defer sub.Close()
+
+	// the read loop only exists to notice when the client goes away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case e := <-sub.C:
+			if err := conn.WriteJSON(e); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// finishHeat adds the results of the stopped run to the series and persists the series
+// it must be called with s.mu held
+func (s *server) finishHeat() error {
+	if !s.t.IsStopped() {
+		return fmt.Errorf("heat can only be finished when the timer is stopped")
+	}
+	s.series.AddHeat(s.t.RaceResult())
+
+	return s.saveJSON(seriesFile, s.series.Heats())
+}
+
+// persistRuns writes the report of every stopped run to the data directory
+func (s *server) persistRuns() {
+	sub := s.t.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventStopped}}))
+	for e := range sub.C {
+		s.mu.Lock()
+		report := s.t.Report()
+		s.mu.Unlock()
+
+		name := fmt.Sprintf("run-%v.json", e.Time.UTC().Format("20060102T150405.000"))
+		if err := s.saveJSON(name, report); err != nil {
+			log.Printf("persisting run: %v", err)
+		}
+	}
+}
+
+// saveSnapshot writes a snapshot of the timer so the race survives a crash of the server
+// it must be called with s.mu held
+func (s *server) saveSnapshot() {
+	if err := s.saveJSON(snapshotFile, s.t.Snapshot()); err != nil {
+		log.Printf("persisting snapshot: %v", err)
+	}
+}
+
+// loadSnapshot restores the timer from the last snapshot and reports whether one existed
+func (s *server) loadSnapshot() (bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(s.dataDir, snapshotFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var snap timer.Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return false, fmt.Errorf("reading %v: %v", snapshotFile, err)
+	}
+	if err := s.t.RestoreSnapshot(snap); err != nil {
+		return false, fmt.Errorf("restoring %v: %v", snapshotFile, err)
+	}
+	log.Printf("restored %v subtimers from %v", len(snap.Subtimers), snapshotFile)
+
+	return true, nil
+}
+
+func (s *server) loadSeries() error {
+	data, err := ioutil.ReadFile(filepath.Join(s.dataDir, seriesFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var heats []timer.RaceResult
+	if err := json.Unmarshal(data, &heats); err != nil {
+		return fmt.Errorf("reading %v: %v", seriesFile, err)
+	}
+	for _, heat := range heats {
+		s.series.AddHeat(heat)
+	}
+
+	return nil
+}
+
+// saveJSON atomically writes v as JSON to name in the data directory
+func (s *server) saveJSON(name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.dataDir, name)
+	if err := ioutil.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
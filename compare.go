@@ -0,0 +1,101 @@
+package timer
+
+import "time"
+
+// SplitComparison compares a single split of two runs
+type SplitComparison struct {
+	ID int `json:"id"`
+	// Delta is the difference of the split times, positive if b is behind a
+	Delta time.Duration `json:"delta"`
+	// SegmentDelta is the difference of the segment times leading up to the split, positive if b lost time
+	SegmentDelta time.Duration `json:"segmentDelta"`
+}
+
+// RunComparison is the result of comparing two runs
+type RunComparison struct {
+	// Splits holds the comparison of every split which exists in both runs in the order of a
+	Splits []SplitComparison `json:"splits"`
+	// Total is the difference of the final times, positive if b is slower than a
+	Total time.Duration `json:"total"`
+	// Diverged is the index into the splits of a at which the split order of both runs stops matching
+	// It is -1 if both runs have the same splits in the same order
+	Diverged int `json:"diverged"`
+	// LeadChanges holds the ids of the splits at which the run in the lead changed
+	LeadChanges []int `json:"leadChanges"`
+}
+
+// CompareRuns compares two runs split by split
+// a is used as the reference so positive deltas mean that b is behind
+func CompareRuns(a, b Report) RunComparison {
+	c := RunComparison{
+		Splits:      make([]SplitComparison, 0),
+		Total:       b.FinalTime - a.FinalTime,
+		Diverged:    -1,
+		LeadChanges: make([]int, 0),
+	}
+
+	segmentsA := segments(a.Splits)
+	segmentsB := segments(b.Splits)
+	for i, s := range a.Splits {
+		if c.Diverged == -1 && (i >= len(b.Splits) || b.Splits[i].ID != s.ID) {
+			c.Diverged = i
+		}
+	}
+	if c.Diverged == -1 && len(b.Splits) > len(a.Splits) {
+		c.Diverged = len(a.Splits)
+	}
+
+	// lead is the sign of the last non zero delta
+	lead := 0
+	for _, s := range a.Splits {
+		sb, ok := findSplit(b.Splits, s.ID)
+		if !ok {
+			continue
+		}
+
+		delta := sb.Time - s.Time
+		c.Splits = append(c.Splits, SplitComparison{
+			ID:           s.ID,
+			Delta:        delta,
+			SegmentDelta: segmentsB[s.ID] - segmentsA[s.ID],
+		})
+
+		sign := 0
+		if delta > 0 {
+			sign = 1
+		} else if delta < 0 {
+			sign = -1
+		}
+		if sign == 0 {
+			continue
+		}
+		if lead != 0 && sign != lead {
+			c.LeadChanges = append(c.LeadChanges, s.ID)
+		}
+		lead = sign
+	}
+
+	return c
+}
+
+// segments returns the segment time of every split keyed by id
+func segments(splits []SubtimerResult) map[int]time.Duration {
+	segments := make(map[int]time.Duration, len(splits))
+	var last time.Duration
+	for _, s := range splits {
+		segments[s.ID] = s.Time - last
+		last = s.Time
+	}
+
+	return segments
+}
+
+func findSplit(splits []SubtimerResult, id int) (SubtimerResult, bool) {
+	for _, s := range splits {
+		if s.ID == id {
+			return s, true
+		}
+	}
+
+	return SubtimerResult{}, false
+}
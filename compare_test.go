@@ -0,0 +1,88 @@
+package timer_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// runReport returns a report of a run finishing at final with the splits at the given times keyed by id in order
+func runReport(final time.Duration, splits ...timer.SubtimerResult) timer.Report {
+	return timer.Report{FinalTime: final, Splits: splits}
+}
+
+func splitAt(id int, d time.Duration) timer.SubtimerResult {
+	return timer.SubtimerResult{ID: id, Time: d}
+}
+
+func TestCompareRuns(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b timer.Report
+		want timer.RunComparison
+	}{
+		{
+			name: "same splits",
+			a:    runReport(30*time.Second, splitAt(1, 10*time.Second), splitAt(2, 20*time.Second), splitAt(3, 30*time.Second)),
+			b:    runReport(31*time.Second, splitAt(1, 12*time.Second), splitAt(2, 19*time.Second), splitAt(3, 31*time.Second)),
+			want: timer.RunComparison{
+				Splits: []timer.SplitComparison{
+					{ID: 1, Delta: 2 * time.Second, SegmentDelta: 2 * time.Second},
+					{ID: 2, Delta: -time.Second, SegmentDelta: -3 * time.Second},
+					{ID: 3, Delta: time.Second, SegmentDelta: 2 * time.Second},
+				},
+				Total:       time.Second,
+				Diverged:    -1,
+				LeadChanges: []int{2, 3},
+			},
+		},
+		{
+			name: "tie keeps the lead",
+			a:    runReport(20*time.Second, splitAt(1, 10*time.Second), splitAt(2, 20*time.Second)),
+			b:    runReport(20*time.Second, splitAt(1, 10*time.Second), splitAt(2, 20*time.Second)),
+			want: timer.RunComparison{
+				Splits: []timer.SplitComparison{
+					{ID: 1},
+					{ID: 2},
+				},
+				Diverged:    -1,
+				LeadChanges: []int{},
+			},
+		},
+		{
+			name: "different order",
+			a:    runReport(20*time.Second, splitAt(1, 10*time.Second), splitAt(2, 20*time.Second)),
+			b:    runReport(25*time.Second, splitAt(2, 15*time.Second), splitAt(1, 25*time.Second)),
+			want: timer.RunComparison{
+				Splits: []timer.SplitComparison{
+					{ID: 1, Delta: 15 * time.Second, SegmentDelta: 0},
+					{ID: 2, Delta: -5 * time.Second, SegmentDelta: 5 * time.Second},
+				},
+				Total:       5 * time.Second,
+				Diverged:    0,
+				LeadChanges: []int{2},
+			},
+		},
+		{
+			name: "more splits in b",
+			a:    runReport(10*time.Second, splitAt(1, 10*time.Second)),
+			b:    runReport(20*time.Second, splitAt(1, 9*time.Second), splitAt(2, 20*time.Second)),
+			want: timer.RunComparison{
+				Splits:      []timer.SplitComparison{{ID: 1, Delta: -time.Second, SegmentDelta: -time.Second}},
+				Total:       10 * time.Second,
+				Diverged:    1,
+				LeadChanges: []int{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := timer.CompareRuns(test.a, test.b); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+const defaultArmTimeout = 5 * time.Second
+
+// ArmReset arms a reset of the timer which has to be confirmed by ConfirmReset within timeout
+// Setting 0 for timeout uses the default of 5 seconds. Only possible when in Stopped state
+func (t *Timer) ArmReset(timeout time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if !t.checkValidState(resetOp) {
+		return &StateError{Op: "ArmReset", Current: t.state}
+	}
+
+	return t.arm(resetOp, timeout)
+}
+
+// ConfirmReset resets the timer if a reset has been armed and the timeout hasn't passed yet
+func (t *Timer) ConfirmReset() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("ConfirmReset", t.confirmResetLocked())
+}
+
+func (t *Timer) confirmResetLocked() error {
+	if err := t.confirm(resetOp); err != nil {
+		return err
+	}
+
+	return t.resetTimerLocked()
+}
+
+// ArmStop arms a stop of the timer which has to be confirmed by ConfirmStop within timeout
+// Setting 0 for timeout uses the default of 5 seconds. Only possible when in Running or Paused state
+func (t *Timer) ArmStop(timeout time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if !t.checkValidState(stopOp) {
+		return &StateError{Op: "ArmStop", Current: t.state}
+	}
+
+	return t.arm(stopOp, timeout)
+}
+
+// ConfirmStop stops the timer if a stop has been armed and the timeout hasn't passed yet
+func (t *Timer) ConfirmStop() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("ConfirmStop", t.confirmStopLocked())
+}
+
+func (t *Timer) confirmStopLocked() error {
+	if err := t.confirm(stopOp); err != nil {
+		return err
+	}
+
+	return t.stopTimerLocked()
+}
+
+// Disarm cancels any armed operation
+func (t *Timer) Disarm() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.disarmLocked()
+}
+
+func (t *Timer) disarmLocked() {
+	t.armedDeadline = time.Time{}
+}
+
+func (t *Timer) arm(op operation, timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("Only positive values for timeout are allowed")
+	}
+	if timeout == 0 {
+		timeout = defaultArmTimeout
+	}
+	t.armedOp = op
+	t.armedDeadline = t.clock.Now().Add(timeout)
+
+	return nil
+}
+
+func (t *Timer) confirm(op operation) error {
+	armed := !t.armedDeadline.IsZero() && t.armedOp == op
+	expired := t.clock.Now().After(t.armedDeadline)
+	t.disarmLocked()
+	if !armed {
+		return fmt.Errorf("Operation has to be armed before it can be confirmed")
+	}
+	if expired {
+		return fmt.Errorf("Armed operation has expired")
+	}
+
+	return nil
+}
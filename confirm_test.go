@@ -0,0 +1,127 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestConfirmStop(t *testing.T) {
+	tests := []struct {
+		name string
+		// do runs between starting the timer and confirming the stop
+		do      func(tm *timer.Timer, clock *timertest.Clock) error
+		stopped bool
+	}{
+		{
+			name: "within timeout",
+			do: func(tm *timer.Timer, clock *timertest.Clock) error {
+				err := tm.ArmStop(2 * time.Second)
+				clock.Advance(2 * time.Second)
+				return err
+			},
+			stopped: true,
+		},
+		{
+			name: "default timeout",
+			do: func(tm *timer.Timer, clock *timertest.Clock) error {
+				err := tm.ArmStop(0)
+				clock.Advance(4 * time.Second)
+				return err
+			},
+			stopped: true,
+		},
+		{
+			name: "expired",
+			do: func(tm *timer.Timer, clock *timertest.Clock) error {
+				err := tm.ArmStop(2 * time.Second)
+				clock.Advance(3 * time.Second)
+				return err
+			},
+		},
+		{
+			name: "not armed",
+			do: func(*timer.Timer, *timertest.Clock) error {
+				return nil
+			},
+		},
+		{
+			name: "disarmed",
+			do: func(tm *timer.Timer, clock *timertest.Clock) error {
+				err := tm.ArmStop(time.Second)
+				tm.Disarm()
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock)
+			defer tm.Close()
+			timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+			if err := test.do(tm, clock); err != nil {
+				t.Fatal(err)
+			}
+
+			err := tm.ConfirmStop()
+			if test.stopped {
+				if err != nil {
+					t.Fatalf("got error %v, want nil", err)
+				}
+				if s := tm.State(); s != timer.Stopped {
+					t.Errorf("state is %v, want %v", s, timer.Stopped)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("confirming succeeded, want an error")
+			}
+			if s := tm.State(); s != timer.Running {
+				t.Errorf("state is %v, want %v", s, timer.Running)
+			}
+		})
+	}
+}
+
+func TestConfirmReset(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	var stateErr *timer.StateError
+	if err := tm.ArmReset(time.Second); !errors.As(err, &stateErr) {
+		t.Errorf("arming a reset of a running timer returned %v, want a state error", err)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{After: time.Second, Do: timertest.Stop})
+
+	if err := tm.ArmStop(time.Second); !errors.As(err, &stateErr) {
+		t.Errorf("arming a stop of a stopped timer returned %v, want a state error", err)
+	}
+	if err := tm.ArmReset(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	// an armed reset can't be confirmed as a stop and the failed confirmation disarms it
+	if err := tm.ConfirmStop(); err == nil {
+		t.Errorf("confirming a stop succeeded, want an error")
+	}
+	if err := tm.ConfirmReset(); err == nil {
+		t.Errorf("confirming after the failed confirmation succeeded, want an error")
+	}
+
+	if err := tm.ArmReset(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.ConfirmReset(); err != nil {
+		t.Fatal(err)
+	}
+	if s := tm.State(); s != timer.Reset {
+		t.Errorf("state is %v, want %v", s, timer.Reset)
+	}
+}
@@ -0,0 +1,88 @@
+package timer
+
+import "io"
+
+import "time"
+
+// TimerController covers the control and query surface of a Timer
+// applications can depend on it instead of *Timer to replace the timer with a mock in their own tests
+type TimerController interface {
+	StartTimer() error
+	StartTimerAt(ts time.Time) error
+	StartTimerAtSynced(ts time.Time, c *ClientSync) error
+	CancelScheduledStart() bool
+	ScheduledStart() (at time.Time, ok bool)
+	StopTimer() error
+	ResetTimer() error
+	PauseTimer() error
+	PauseFor(d time.Duration) error
+	ResumeTimer() error
+	CancelAutoResume() bool
+	ArmReset(timeout time.Duration) error
+	ConfirmReset() error
+	ArmStop(timeout time.Duration) error
+	ConfirmStop() error
+	Disarm()
+	Lap() (LapResult, error)
+	Split() (LapResult, error)
+	ApplyOp(op Operation) error
+
+	AddSubTimer(id int) error
+	AddSubTimerAt(id int, offset time.Duration) error
+	AddNamedSubTimer(name string) (int, error)
+	StopSubTimer(id int) (time.Duration, error)
+	UndoStopSubTimer(id int) error
+	SkipSubTimer(id int) error
+	SplitSubTimer(id int) (time.Duration, error)
+	PauseSubTimer(id int) error
+	ResumeSubTimer(id int) error
+	Handoff(id int, member string) error
+	Adjudicate(id int, official time.Duration, note string) error
+	StopAllSubTimers() (map[int]time.Duration, error)
+	ForfeitAllRunning() (map[int]time.Duration, error)
+	ResetAllSubTimers() error
+	Trigger(source string) (time.Duration, error)
+	AddAdjustment(a Adjustment) error
+	Adjust(d time.Duration) error
+	SetElapsed(d time.Duration) error
+	SetStartOffset(offset time.Duration) error
+	StartOffset() time.Duration
+	SetTargetDuration(d time.Duration) error
+	TargetDuration() time.Duration
+	NotifyAt(d time.Duration) <-chan struct{}
+	NotifyAtEach(ds ...time.Duration) <-chan time.Duration
+	SetFrameRate(fps float64, rounding FrameRounding) error
+	FrameRate() float64
+	Frames() int64
+	GameTime() time.Duration
+	PauseGameTime() error
+	ResumeGameTime() error
+	GameTimePaused() bool
+	SetGameTime(d time.Duration) error
+	Close() error
+
+	Subscribe(opts ...SubscribeOption) *Subscription
+	SubscribeUpdates() (<-chan time.Duration, func())
+	Report() Report
+	RaceResult() RaceResult
+	SplitMatrix() []SegmentStandings
+	SubTimer(id int) (SubtimerInfo, error)
+	Legs(id int) ([]Leg, error)
+	PredictedFinish() time.Duration
+	LiveDelta() (delta LiveDelta, ok bool)
+	PersonalBest() (pb time.Duration, ok bool)
+	SumOfBest() (sob time.Duration, ok bool)
+	Laps() []LapResult
+	State() State
+	IsRunning() bool
+	IsPaused() bool
+	IsStopped() bool
+	Elapsed() time.Duration
+	ActiveTime() time.Duration
+	WallTime() time.Duration
+	Remaining() time.Duration
+	Ledger() []Adjustment
+	Save(w io.Writer) error
+}
+
+var _ TimerController = (*Timer)(nil)
@@ -0,0 +1,96 @@
+package timer
+
+import "sync"
+
+import "time"
+
+// Countdown emits the time remaining until a wall clock target
+// it runs independently of the state of the timer
+type Countdown struct {
+	t      *Timer
+	clock  Clock
+	target time.Time
+	ticker Ticker
+	done   chan struct{}
+	once   sync.Once
+	// start is set for the countdown to a scheduled start, it ends with EventStarted instead of running into overtime
+	start bool
+}
+
+// CountdownTo starts a countdown to target which emits EventCountdown on every update
+// the remaining time is measured against the wall clock, so corrections of the system clock are respected. target is an absolute instant, its time zone doesn't matter.
+// EventCountdownExpired is emitted once the target is reached, after which the countdown continues in overtime with negative remaining times until it is stopped
+func (t *Timer) CountdownTo(target time.Time) *Countdown {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.countdownLocked(target, false)
+}
+
+func (t *Timer) countdownLocked(target time.Time, start bool) *Countdown {
+	c := &Countdown{
+		t:      t,
+		clock:  t.clock,
+		target: target,
+		ticker: t.clock.NewTicker(t.updateInterval),
+		done:   make(chan struct{}),
+		start:  start,
+	}
+	t.goLabeled(roleCountdown, c.run)
+
+	return c
+}
+
+// Target returns the target of the countdown
+func (c *Countdown) Target() time.Time {
+	return c.target
+}
+
+// Remaining returns the time left until the target. It is negative in overtime
+func (c *Countdown) Remaining() time.Duration {
+	return c.remaining(c.clock.Now())
+}
+
+// Stop stops the countdown
+func (c *Countdown) Stop() {
+	c.once.Do(func() {
+		c.ticker.Stop()
+		close(c.done)
+	})
+}
+
+// remaining returns the time left at now. Round(0) strips the monotonic reading so the wall clock is used
+func (c *Countdown) remaining(now time.Time) time.Duration {
+	return c.target.Round(0).Sub(now.Round(0))
+}
+
+func (c *Countdown) run() {
+	expired := false
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.ticker.C():
+			now := c.clock.Now()
+			remaining := c.remaining(now)
+			c.t.mu.Lock()
+			// the countdown may have been stopped while waiting for the lock
+			select {
+			case <-c.done:
+				c.t.mu.Unlock()
+				return
+			default:
+			}
+			if c.start && remaining <= 0 {
+				c.t.mu.Unlock()
+				continue
+			}
+			if !expired && remaining <= 0 {
+				expired = true
+				c.t.emit(Event{Type: EventCountdownExpired, Time: now, Elapsed: c.t.elapsed, Remaining: remaining})
+			}
+			c.t.emit(Event{Type: EventCountdown, Time: now, Elapsed: c.t.elapsed, Remaining: remaining})
+			c.t.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestCountdownTo(t *testing.T) {
+	now := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	clock := timertest.NewClock(now)
+	tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(time.Second))
+	defer tm.Close()
+	sub := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventCountdown, timer.EventCountdownExpired}}), timer.WithOverflow(timer.Unbounded))
+
+	// the time zone of the target doesn't matter
+	target := now.Add(2 * time.Second).In(time.FixedZone("UTC+2", 2*60*60))
+	c := tm.CountdownTo(target)
+	defer c.Stop()
+	if !c.Target().Equal(target) {
+		t.Errorf("target is %v, want %v", c.Target(), target)
+	}
+	if d := c.Remaining(); d != 2*time.Second {
+		t.Errorf("remaining is %v, want 2s", d)
+	}
+
+	// the countdown runs independently of the timer, which is never started
+	for _, want := range []time.Duration{time.Second, 0, -time.Second} {
+		clock.Advance(time.Second)
+		if want == 0 {
+			e := timertest.AssertEmits(t, sub.C, 0)
+			if e.Type != timer.EventCountdownExpired || e.Remaining != 0 {
+				t.Errorf("got %v event with %v remaining, want the countdown to expire", e.Type, e.Remaining)
+			}
+		}
+		e := timertest.AssertEmits(t, sub.C, 0)
+		if e.Type != timer.EventCountdown || e.Remaining != want {
+			t.Errorf("got %v event with %v remaining, want a countdown with %v remaining", e.Type, e.Remaining, want)
+		}
+	}
+
+	c.Stop()
+	c.Stop()
+	clock.Advance(time.Second)
+	timertest.AssertNoEmit(t, sub.C, 10*time.Millisecond)
+}
@@ -0,0 +1,90 @@
+package timer
+
+import "time"
+
+// NewCountdown initializes and returns a new timer counting down from target to zero with the given options applied
+// target has to be positive, otherwise the timer counts up. The updates channel receives the remaining time and tick events carry it in Remaining.
+// Once zero is reached EventCountdownExpired is emitted and the timer is stopped, regardless of the update interval
+func NewCountdown(target time.Duration, opts ...Option) *Timer {
+	t := New(opts...)
+	if target > 0 {
+		t.countdown = target
+	}
+
+	return t
+}
+
+// Remaining returns the time left until a countdown timer reaches zero. It is 0 for timers counting up
+func (t *Timer) Remaining() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.remaining()
+}
+
+func (t *Timer) remaining() time.Duration {
+	if t.countdown == 0 || t.elapsed >= t.countdown {
+		return 0
+	}
+
+	return t.countdown - t.elapsed
+}
+
+// update returns the value sent on the updates channel
+func (t *Timer) update() time.Duration {
+	if t.countdown > 0 {
+		return t.remaining()
+	}
+
+	return t.elapsed
+}
+
+// checkCountdown clamps the elapsed time of a countdown timer which reached zero and reports whether it did
+func (t *Timer) checkCountdown() bool {
+	if t.countdown == 0 || t.elapsed < t.countdown {
+		return false
+	}
+	t.elapsed = t.countdown
+
+	return true
+}
+
+// armCountdown schedules the tick expiring a running countdown timer at zero
+// so the countdown expires on time even if the loop is late or waits for a consumer
+func (t *Timer) armCountdown() {
+	t.disarmCountdown()
+	if t.countdown == 0 || t.state != Running {
+		return
+	}
+
+	t.countdownAlarm = t.clock.AfterFunc(t.countdown-t.currentElapsed(), func() {
+		t.mu.Lock()
+		// an alarm which fired while being disarmed or for a previous phase finds the countdown not expired yet
+		if t.countdown == 0 || t.state != Running || t.clock.Now().Sub(t.startTime) < t.countdown {
+			t.mu.Unlock()
+			return
+		}
+		t.alarmTick(t.startTime.Add(t.countdown))
+	})
+}
+
+func (t *Timer) disarmCountdown() {
+	if t.countdownAlarm == nil {
+		return
+	}
+	t.countdownAlarm.Stop()
+	t.countdownAlarm = nil
+}
+
+// expire finishes a countdown timer after the final update was sent
+// timers counting down a sequence of phases continue with the next phase instead
+func (t *Timer) expire() {
+	t.emit(Event{Type: EventCountdownExpired, Elapsed: t.elapsed})
+	if t.nextPhase() {
+		return
+	}
+	if t.phases != nil {
+		t.emit(Event{Type: EventPhasesFinished, Elapsed: t.elapsed})
+	}
+	t.stopTimerLocked()
+}
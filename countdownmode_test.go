@@ -0,0 +1,79 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+// waitState polls tm until it is in state s or the real time within has passed
+func waitState(t *testing.T, tm *timer.Timer, s timer.State, within time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(within)
+	for tm.State() != s {
+		if time.Now().After(deadline) {
+			t.Fatalf("state is %v after %v, want %v", tm.State(), within, s)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCountdownExpiresWithoutUpdatesReader(t *testing.T) {
+	tm := timer.NewCountdown(50 * time.Millisecond)
+	defer tm.Close()
+	expired := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventCountdownExpired}}))
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.StartTimer(); err != nil {
+		t.Fatal(err)
+	}
+
+	waitState(t, tm, timer.Stopped, 200*time.Millisecond)
+	timertest.AssertEmitsType(t, expired.C, timer.EventCountdownExpired, 0)
+	if d := tm.Elapsed(); d != 50*time.Millisecond {
+		t.Errorf("elapsed is %v, want 50ms", d)
+	}
+}
+
+func TestCountdownOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  time.Duration
+		advance time.Duration
+		state   timer.State
+		remain  time.Duration
+	}{
+		{"before zero", time.Second, 400 * time.Millisecond, timer.Running, 600 * time.Millisecond},
+		{"at zero", time.Second, time.Second, timer.Stopped, 0},
+		{"past zero", time.Second, 3 * time.Second, timer.Stopped, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			// the long update interval leaves expiring to the alarm
+			tm := timer.NewCountdown(test.target, timer.WithClock(clock), timer.WithUpdateIntervalDuration(time.Hour), timer.WithTickerIntervalDuration(time.Hour))
+			defer tm.Close()
+			go func() {
+				for range tm.Updates {
+				}
+			}()
+			if err := tm.ResetTimer(); err != nil {
+				t.Fatal(err)
+			}
+			if err := tm.StartTimer(); err != nil {
+				t.Fatal(err)
+			}
+
+			clock.Advance(test.advance)
+			waitState(t, tm, test.state, time.Second)
+			if d := tm.Remaining(); test.state == timer.Stopped && d != test.remain {
+				t.Errorf("remaining time is %v, want %v", d, test.remain)
+			}
+		})
+	}
+}
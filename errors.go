@@ -0,0 +1,88 @@
+package timer
+
+import "errors"
+
+import "fmt"
+
+var (
+	// ErrClosed is returned by operations on a timer which has been closed
+	ErrClosed = errors.New("Timer is closed")
+	// ErrInvalidState is matched by every StateError
+	ErrInvalidState = errors.New("Invalid state")
+	// ErrSubtimerNotFound and ErrSubtimerExists are matched by SubtimerErrors about missing or duplicate subtimers
+	ErrSubtimerNotFound = errors.New("Subtimer does not exist")
+	ErrSubtimerExists   = errors.New("Subtimer already exists")
+	// ErrSubtimerAdjudicated is matched by SubtimerErrors about subtimers which are locked by Adjudicate
+	ErrSubtimerAdjudicated = errors.New("Subtimer has already been adjudicated")
+	// ErrSubtimerFinished is matched by SubtimerErrors about subtimers which have already been stopped, forfeited or skipped
+	ErrSubtimerFinished = errors.New("Subtimer has already finished")
+	// ErrFlagged is returned by ChessClock.Switch if the time of the active player ran out before the switch
+	ErrFlagged = errors.New("Time of the active player has run out")
+)
+
+// StateError is returned if an operation is not possible in the current state of the timer or subtimer
+// it matches ErrInvalidState with errors.Is
+type StateError struct {
+	Op      string
+	Current State
+}
+
+func (e *StateError) Error() string {
+	return fmt.Sprintf("%v called with invalid state %v", e.Op, e.Current)
+}
+
+// Is reports whether target is ErrInvalidState
+func (e *StateError) Is(target error) bool {
+	return target == ErrInvalidState
+}
+
+// SubtimerError is returned if a subtimer doesn't exist, already exists, has been adjudicated or has already finished
+// Err is ErrSubtimerNotFound, ErrSubtimerExists, ErrSubtimerAdjudicated or ErrSubtimerFinished. Name is set if the subtimer was looked up by name
+type SubtimerError struct {
+	ID   int
+	Name string
+	Err  error
+}
+
+func (e *SubtimerError) Error() string {
+	what := "does not exist"
+	switch e.Err {
+	case ErrSubtimerExists:
+		what = "already exists"
+	case ErrSubtimerAdjudicated:
+		what = "has already been adjudicated"
+	case ErrSubtimerFinished:
+		what = "has already finished"
+	}
+	if e.Name != "" {
+		return fmt.Sprintf("Subtimer with name %q %v", e.Name, what)
+	}
+
+	return fmt.Sprintf("Subtimer with id %v %v", e.ID, what)
+}
+
+// Unwrap returns Err
+func (e *SubtimerError) Unwrap() error {
+	return e.Err
+}
+
+// subtimerStateError returns the error of an operation which requires both the timer and the subtimer s to be running
+func (t *Timer) subtimerStateError(op string, s *subtimer) error {
+	if t.state != Running {
+		return &StateError{Op: op, Current: t.state}
+	}
+
+	return &StateError{Op: op, Current: s.state}
+}
+
+func errSubtimerNotFound(id int) error {
+	return &SubtimerError{ID: id, Err: ErrSubtimerNotFound}
+}
+
+func errSubtimerAdjudicated(id int) error {
+	return &SubtimerError{ID: id, Err: ErrSubtimerAdjudicated}
+}
+
+func errSubtimerFinished(id int) error {
+	return &SubtimerError{ID: id, Err: ErrSubtimerFinished}
+}
@@ -0,0 +1,57 @@
+package timer_test
+
+import "errors"
+
+import "fmt"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestErrors(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+	if _, err := tm.AddNamedSubTimer("alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		msg    string
+	}{
+		{"invalid state", tm.PauseTimer(), timer.ErrInvalidState, "PauseTimer called with invalid state Reset"},
+		{"not running", tm.AddSubTimerAt(1, 0), timer.ErrInvalidState, "AddSubTimerAt called with invalid state Reset"},
+		{"duplicate id", tm.AddSubTimer(1), timer.ErrSubtimerExists, "Subtimer with id 1 already exists"},
+		{"duplicate name", func() error { _, err := tm.AddNamedSubTimer("alice"); return err }(), timer.ErrSubtimerExists, `Subtimer with name "alice" already exists`},
+		{"missing id", tm.SetSubTimerName(3, "bob"), timer.ErrSubtimerNotFound, "Subtimer with id 3 does not exist"},
+		{"missing name", func() error { _, err := tm.SubTimerID("bob"); return err }(), timer.ErrSubtimerNotFound, `Subtimer with name "bob" does not exist`},
+		{"wrapped", fmt.Errorf("starting race: %w", tm.ResumeTimer()), timer.ErrInvalidState, "starting race: ResumeTimer called with invalid state Reset"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !errors.Is(test.err, test.target) {
+				t.Errorf("error %v doesn't match %v", test.err, test.target)
+			}
+			if test.err.Error() != test.msg {
+				t.Errorf("message is %q, want %q", test.err.Error(), test.msg)
+			}
+		})
+	}
+
+	var stateErr *timer.StateError
+	if err := tm.StopTimer(); !errors.As(err, &stateErr) || stateErr.Op != "StopTimer" || stateErr.Current != timer.Reset {
+		t.Errorf("got %v, want a state error of StopTimer in Reset", err)
+	}
+	var subErr *timer.SubtimerError
+	if _, err := tm.StopSubTimer(5); !errors.As(err, &subErr) || subErr.ID != 5 || errors.Is(err, timer.ErrInvalidState) {
+		t.Errorf("got %v, want a subtimer error for id 5", err)
+	}
+}
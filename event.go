@@ -0,0 +1,152 @@
+package timer
+
+import "time"
+
+// EventType describes the kind of an Event
+type EventType int
+
+const (
+	// EventTick is emitted on every update of a running timer
+	EventTick EventType = iota
+	// EventAnomaly is emitted when an abnormal clock condition is detected
+	EventAnomaly
+	// EventPaused is emitted when the timer is paused
+	EventPaused
+	// EventResumed is emitted when the timer is resumed after a pause
+	EventResumed
+	// EventAutoResumeCanceled is emitted when the automatic resume of a pause started by PauseFor is canceled
+	EventAutoResumeCanceled
+	// EventSubscriptionLagging is emitted every time a subscription dropped another DropThreshold events
+	EventSubscriptionLagging
+	// EventStarted is emitted when the timer is started
+	EventStarted
+	// EventStopped is emitted when the timer is stopped
+	EventStopped
+	// EventReset is emitted when the timer is reset
+	EventReset
+	// EventHandoff is emitted when a relay subtimer is handed over to the next member
+	EventHandoff
+	// EventSubtimerSplit is emitted when a subtimer reaches a shared split
+	EventSubtimerSplit
+	// EventSubtimerPaused is emitted when a single subtimer is paused
+	EventSubtimerPaused
+	// EventSubtimerResumed is emitted when a single subtimer is resumed
+	EventSubtimerResumed
+	// EventPauseBudgetExceeded is emitted when a subtimer exceeded its pause budget
+	EventPauseBudgetExceeded
+	// EventSubtimerForfeited is emitted when a subtimer is forfeited
+	EventSubtimerForfeited
+	// EventSubtimersStopped is emitted when all running subtimers are stopped at once
+	EventSubtimersStopped
+	// EventSubtimersForfeited is emitted when all running subtimers are forfeited at once
+	EventSubtimersForfeited
+	// EventSubtimersReset is emitted when all subtimers are reset at once
+	EventSubtimersReset
+	// EventSubtimerAdjudicated is emitted when the official time of a subtimer is set
+	EventSubtimerAdjudicated
+	// EventCountdown is emitted on every update of a countdown started by CountdownTo or while waiting for a scheduled start
+	EventCountdown
+	// EventCountdownExpired is emitted once when a countdown reaches its target or a countdown timer reaches zero
+	EventCountdownExpired
+	// EventAdjusted is emitted when an adjustment is recorded in the ledger
+	EventAdjusted
+	// EventLap is emitted when a lap of the main timer is finished
+	EventLap
+	// EventTimeSourceHealth is emitted when the health status of the time source set by SetTimeSource changes
+	EventTimeSourceHealth
+	// EventSubtimerStopped is emitted when a single subtimer is stopped
+	EventSubtimerStopped
+	// EventSubtimerStopUndone is emitted when the stop of a subtimer is undone
+	EventSubtimerStopUndone
+	// EventSubtimerSkipped is emitted when a subtimer is skipped
+	EventSubtimerSkipped
+	// EventBestSegment is emitted when a subtimer is stopped with a segment faster than its best segment so far
+	EventBestSegment
+	// EventPhase is emitted when a timer counting down a sequence of phases starts its next phase
+	EventPhase
+	// EventPhasesFinished is emitted when the last phase of a timer counting down a sequence of phases expired
+	EventPhasesFinished
+	// EventElapsedAdjusted is emitted when the elapsed time is shifted by Adjust or SetElapsed. Delta holds the shift
+	EventElapsedAdjusted
+	// EventZeroCrossed is emitted when the elapsed time of a run started with a negative offset reaches zero
+	EventZeroCrossed
+	// EventTargetReached is emitted when the timer reaches the target set by SetTargetDuration, right before it stops
+	EventTargetReached
+	// EventGameTimePaused and EventGameTimeResumed are emitted when the game time is paused or resumed on its own
+	EventGameTimePaused
+	EventGameTimeResumed
+	// EventGameTimeSet is emitted when the game time is set by SetGameTime
+	EventGameTimeSet
+)
+
+const (
+	defaultSubscriptionBuffer = 64
+	defaultDropThreshold      = 100
+	// flushTimeout is how long a subscription of a closed timer waits for its consumer to take each remaining event
+	flushTimeout = 100 * time.Millisecond
+)
+
+// periodic reports whether events of type e are emitted on every update
+// periodic events are not logged and may be dropped by lagging subscriptions
+func (e EventType) periodic() bool {
+	return e == EventTick || e == EventCountdown
+}
+
+// Event is a single message delivered to subscribers of a timer
+type Event struct {
+	Type     EventType `json:"type"`
+	Severity Severity  `json:"severity"`
+	// Seq is increased by one for every event emitted by the timer. Consumers can use it to de-duplicate events
+	Seq uint64 `json:"seq"`
+	// Tick is increased by one for every tick of the timer and never reset. It is only set for tick events and allows detecting dropped ticks
+	Tick uint64 `json:"tick,omitempty"`
+	// Time is the wall clock time at which the event was emitted. For ticks it is the instant Elapsed was measured at
+	Time time.Time `json:"time"`
+	// Monotonic is the monotonic clock reading at Time, measured from the creation of the timer
+	// unlike Time it is not affected by changes of the wall clock
+	Monotonic time.Duration `json:"monotonic"`
+	Elapsed   time.Duration `json:"elapsed"`
+	// Frozen is set for tick events sent while the timer is paused
+	Frozen bool `json:"frozen,omitempty"`
+	// Prediction holds the predicted final time. It is only set when a comparison is available
+	Prediction time.Duration `json:"prediction,omitempty"`
+	// Delta is the difference between Prediction and the comparison final time, or the shift for EventElapsedAdjusted events
+	Delta time.Duration `json:"delta,omitempty"`
+	// Live holds the delta of the active subtimer against the comparison for tick events. It is only set when a comparison is available
+	Live *LiveDelta `json:"live,omitempty"`
+	// Remaining is the time left until the target for countdown events and ticks of countdown timers. It is negative in overtime
+	Remaining time.Duration `json:"remaining,omitempty"`
+	// Frames is the elapsed time in whole frames for tick events if frame accurate timing is enabled by SetFrameRate
+	Frames int64 `json:"frames,omitempty"`
+	// GameTime is the game time for tick and game time events
+	GameTime time.Duration `json:"gameTime,omitempty"`
+	// Anomaly holds the measurements for EventAnomaly events
+	Anomaly *Anomaly `json:"anomaly,omitempty"`
+	// Subtimers holds the current time of every subtimer keyed by id for tick events if enabled by SetSubtimerUpdates.
+	// For subtimer stop and bulk subtimer events it holds the times of the affected subtimers
+	Subtimers map[int]time.Duration `json:"subtimers,omitempty"`
+	// ResumeAt is the time of the scheduled automatic resume for EventPaused events started by PauseFor
+	ResumeAt *time.Time `json:"resumeAt,omitempty"`
+	// SourceHealth holds the new health of the time source for EventTimeSourceHealth events
+	SourceHealth *SourceHealth `json:"sourceHealth,omitempty"`
+	// Subscription holds the statistics of the lagging subscription for EventSubscriptionLagging events
+	Subscription *SubscriptionStats `json:"subscription,omitempty"`
+	// Subtimer is the id of the subtimer the event is about. It is nil for events concerning the whole timer
+	Subtimer *int `json:"subtimer,omitempty"`
+	// Segment is the index of the shared segment for EventSubtimerSplit events
+	Segment *int `json:"segment,omitempty"`
+	// Leg is the started leg for EventHandoff events
+	Leg *Leg `json:"leg,omitempty"`
+	// Phase is the started phase for EventPhase events and the current phase for tick events of timers counting down phases
+	Phase *Phase `json:"phase,omitempty"`
+	// Lap is the finished lap for EventLap events
+	Lap *LapResult `json:"lap,omitempty"`
+	// BestSegment is the new best segment for EventBestSegment events
+	BestSegment *BestSegment `json:"bestSegment,omitempty"`
+	// Adjustment is the recorded adjustment for EventAdjusted events
+	Adjustment *Adjustment `json:"adjustment,omitempty"`
+	// Note is the note of the officials for EventSubtimerAdjudicated events
+	Note string `json:"note,omitempty"`
+	// Payload is the user payload attached to the operation which caused the event
+	Payload interface{} `json:"payload,omitempty"`
+}
@@ -0,0 +1,105 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestEventTimestamps(t *testing.T) {
+	epoch := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	clock := timertest.NewClock(epoch)
+	tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+	defer tm.Close()
+	sub := tm.Subscribe(timer.WithOverflow(timer.Unbounded))
+
+	clock.Advance(time.Second)
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	e := timertest.AssertEmitsType(t, sub.C, timer.EventStarted, 0)
+	if want := epoch.Add(time.Second); !e.Time.Equal(want) || e.Monotonic != time.Second {
+		t.Errorf("start event at %v (%v), want %v (1s)", e.Time, e.Monotonic, want)
+	}
+
+	// ticks carry the instant their elapsed time was measured at
+	clock.Advance(time.Second)
+	e = timertest.AssertEmitsType(t, sub.C, timer.EventTick, 0)
+	if want := epoch.Add(2 * time.Second); !e.Time.Equal(want) || e.Monotonic != 2*time.Second {
+		t.Errorf("tick at %v (%v), want %v (2s)", e.Time, e.Monotonic, want)
+	}
+	if e.Elapsed != time.Second {
+		t.Errorf("tick has elapsed %v, want 1s", e.Elapsed)
+	}
+}
+
+func TestEventCounters(t *testing.T) {
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.At(0, (*timer.Timer).StartTimer)
+	s.At(3*time.Second, (*timer.Timer).StopTimer)
+	s.At(3*time.Second, (*timer.Timer).ResetTimer)
+	s.At(4*time.Second, (*timer.Timer).StartTimer)
+	events, err := s.Run(6 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ticks keep counting across resets
+	var ticks []uint64
+	for i, e := range events {
+		if i > 0 && e.Seq != events[i-1].Seq+1 {
+			t.Errorf("event %v has seq %v after %v", e.Type, e.Seq, events[i-1].Seq)
+		}
+		if e.Type == timer.EventTick {
+			ticks = append(ticks, e.Tick)
+		} else if e.Tick != 0 {
+			t.Errorf("%v event has tick %v, want 0", e.Type, e.Tick)
+		}
+	}
+	if len(ticks) < 4 {
+		t.Fatalf("got ticks %v, want at least 4", ticks)
+	}
+	for i, tick := range ticks {
+		if tick != uint64(i+1) {
+			t.Errorf("ticks are %v, want them to count up from 1", ticks)
+			break
+		}
+	}
+}
+
+func TestStateChangeEvents(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	clock := timertest.NewClock(start)
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+	sub := tm.Subscribe(timer.WithFilter(timer.StateChangesOnly()))
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.Pause},
+		timertest.Step{After: time.Second, Do: timertest.Resume},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{After: time.Second, Do: timertest.Stop},
+	)
+	want := []struct {
+		typ     timer.EventType
+		at      time.Duration
+		elapsed time.Duration
+	}{
+		{timer.EventStarted, 0, 0},
+		{timer.EventPaused, time.Second, time.Second},
+		{timer.EventResumed, 2 * time.Second, time.Second},
+		{timer.EventSubtimerStopped, 3 * time.Second, 2 * time.Second},
+		{timer.EventStopped, 4 * time.Second, 3 * time.Second},
+	}
+	for _, w := range want {
+		e := timertest.AssertEmits(t, sub.C, 0)
+		if e.Type != w.typ || !e.Time.Equal(start.Add(w.at)) || e.Elapsed != w.elapsed {
+			t.Errorf("got %v at %v with elapsed %v, want %v at %v with elapsed %v", e.Type, e.Time, e.Elapsed, w.typ, start.Add(w.at), w.elapsed)
+		}
+	}
+	timertest.AssertNoEmit(t, sub.C, 10*time.Millisecond)
+}
@@ -0,0 +1,66 @@
+package timer
+
+import "expvar"
+
+import "fmt"
+
+import "time"
+
+// expvarSubtimer is the summary of a single subtimer published by PublishExpvar
+type expvarSubtimer struct {
+	ID    int           `json:"id"`
+	Name  string        `json:"name,omitempty"`
+	State State         `json:"state"`
+	Time  time.Duration `json:"time"`
+}
+
+// PublishExpvar publishes the state, elapsed time and a summary of all subtimers via expvar
+// the variables are named prefix.state, prefix.elapsed and prefix.subtimers. It returns an error if one of the names is taken
+func (t *Timer) PublishExpvar(prefix string) error {
+	vars := map[string]expvar.Func{
+		prefix + ".state": func() interface{} {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			return t.state
+		},
+		prefix + ".elapsed": func() interface{} {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			return t.currentElapsed()
+		},
+		prefix + ".subtimers": func() interface{} {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			subtimers := make([]expvarSubtimer, 0, len(t.subtimers))
+			for _, id := range t.subtimerIDs() {
+				s := t.subtimers[id]
+				subtimers = append(subtimers, expvarSubtimer{ID: id, Name: s.name, State: s.state, Time: t.subtimerElapsed(s)})
+			}
+
+			return subtimers
+		},
+	}
+	for name := range vars {
+		if expvar.Get(name) != nil {
+			return fmt.Errorf("Expvar %v is already published", name)
+		}
+	}
+	for name, f := range vars {
+		expvar.Publish(name, f)
+	}
+
+	return nil
+}
+
+// WithExpvar publishes the timer via expvar under prefix, see PublishExpvar
+// like expvar.Publish it panics if prefix is already in use
+func WithExpvar(prefix string) Option {
+	return func(t *Timer) {
+		if err := t.PublishExpvar(prefix); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package timer_test
+
+import "expvar"
+
+import "fmt"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestPublishExpvar(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+	if err := tm.SetSubTimerName(1, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	// expvar names can't be unpublished, so every run of the test needs its own prefix
+	prefix := fmt.Sprintf("expvar-test-%v", time.Now().UnixNano())
+	if err := tm.PublishExpvar(prefix); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{After: time.Second},
+	)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{".state", `"Running"`},
+		{".elapsed", "3000000000"},
+		{".subtimers", `[{"id":1,"name":"alice","state":"Stopped","time":2000000000}]`},
+	}
+	for _, test := range tests {
+		v := expvar.Get(prefix + test.name)
+		if v == nil {
+			t.Errorf("%v is not published", prefix+test.name)
+			continue
+		}
+		if got := v.String(); got != test.want {
+			t.Errorf("%v is %v, want %v", prefix+test.name, got, test.want)
+		}
+	}
+
+	if err := tm.PublishExpvar(prefix); err == nil {
+		t.Errorf("publishing twice under the same prefix succeeded, want an error")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WithExpvar with a taken prefix didn't panic")
+		}
+	}()
+	timer.New(timer.WithExpvar(prefix))
+}
@@ -0,0 +1,75 @@
+package timer
+
+import "time"
+
+// StateChangeEvents holds all event types which are emitted on state changes of the timer and its subtimers
+var StateChangeEvents = []EventType{
+	EventStarted, EventPaused, EventResumed, EventAutoResumeCanceled, EventStopped, EventReset, EventGameTimePaused, EventGameTimeResumed,
+	EventSubtimerStopped, EventSubtimerStopUndone, EventSubtimerPaused, EventSubtimerResumed, EventSubtimerForfeited, EventSubtimerSkipped,
+	EventSubtimersStopped, EventSubtimersForfeited, EventSubtimersReset,
+}
+
+// Filter selects the events delivered to a consumer
+// the zero value matches all events
+type Filter struct {
+	// Types restricts the events to the given types. All types match if empty
+	Types []EventType
+	// Subtimers restricts the subtimer times included in events to the given ids and drops events about other subtimers.
+	// All subtimers are included if empty
+	Subtimers []int
+	// MinSeverity drops all events with a lower severity
+	MinSeverity Severity
+}
+
+// TicksOnly returns a filter matching only tick events
+func TicksOnly() Filter {
+	return Filter{Types: []EventType{EventTick}}
+}
+
+// StateChangesOnly returns a filter matching only state change events
+func StateChangesOnly() Filter {
+	return Filter{Types: StateChangeEvents}
+}
+
+// Match reports whether e is selected by the filter
+func (f Filter) Match(e Event) bool {
+	if e.Severity < f.MinSeverity {
+		return false
+	}
+	if e.Subtimer != nil && len(f.Subtimers) > 0 && !containsInt(f.Subtimers, *e.Subtimer) {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, typ := range f.Types {
+		if typ == e.Type {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply returns e with all data not selected by the filter removed
+func (f Filter) Apply(e Event) Event {
+	if len(f.Subtimers) == 0 {
+		return e
+	}
+	if e.Live != nil && !containsInt(f.Subtimers, e.Live.ID) {
+		e.Live = nil
+	}
+	if e.Subtimers == nil {
+		return e
+	}
+
+	subtimers := make(map[int]time.Duration, len(f.Subtimers))
+	for _, id := range f.Subtimers {
+		if d, ok := e.Subtimers[id]; ok {
+			subtimers[id] = d
+		}
+	}
+	e.Subtimers = subtimers
+
+	return e
+}
@@ -0,0 +1,73 @@
+package timer_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestFilterMatch(t *testing.T) {
+	one, two := 1, 2
+	tests := []struct {
+		name   string
+		filter timer.Filter
+		event  timer.Event
+		want   bool
+	}{
+		{"zero value", timer.Filter{}, timer.Event{Type: timer.EventAnomaly}, true},
+		{"ticks only", timer.TicksOnly(), timer.Event{Type: timer.EventTick}, true},
+		{"ticks only drops state changes", timer.TicksOnly(), timer.Event{Type: timer.EventStarted}, false},
+		{"state changes", timer.StateChangesOnly(), timer.Event{Type: timer.EventSubtimerStopped}, true},
+		{"state changes drop ticks", timer.StateChangesOnly(), timer.Event{Type: timer.EventTick}, false},
+		{"severity", timer.Filter{MinSeverity: timer.SeverityWarning}, timer.Event{Type: timer.EventAnomaly, Severity: timer.SeverityError}, true},
+		{"low severity", timer.Filter{MinSeverity: timer.SeverityWarning}, timer.Event{Type: timer.EventTick}, false},
+		{"selected subtimer", timer.Filter{Subtimers: []int{1}}, timer.Event{Type: timer.EventSubtimerStopped, Subtimer: &one}, true},
+		{"other subtimer", timer.Filter{Subtimers: []int{1}}, timer.Event{Type: timer.EventSubtimerStopped, Subtimer: &two}, false},
+		{"whole timer", timer.Filter{Subtimers: []int{1}}, timer.Event{Type: timer.EventStopped}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.filter.Match(test.event); got != test.want {
+				t.Errorf("matched %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	e := timer.Event{Type: timer.EventTick, Subtimers: map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 3 * time.Second}}
+
+	if got := (timer.Filter{}).Apply(e); !reflect.DeepEqual(got, e) {
+		t.Errorf("zero filter changed the event to %+v", got)
+	}
+	got := timer.Filter{Subtimers: []int{1, 3, 4}}.Apply(e)
+	if want := map[int]time.Duration{1: time.Second, 3: 3 * time.Second}; !reflect.DeepEqual(got.Subtimers, want) {
+		t.Errorf("filtered subtimers are %v, want %v", got.Subtimers, want)
+	}
+	if len(e.Subtimers) != 3 {
+		t.Errorf("applying the filter modified the original event")
+	}
+}
+
+func TestSubscribeWithSubtimerFilter(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2))
+	defer tm.Close()
+	sub := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventSubtimerStopped}, Subtimers: []int{2}}))
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(2)},
+	)
+	e := timertest.AssertEmits(t, sub.C, 0)
+	if e.Type != timer.EventSubtimerStopped || e.Subtimer == nil || *e.Subtimer != 2 {
+		t.Errorf("got %v event for subtimer %v, want a stop of subtimer 2", e.Type, e.Subtimer)
+	}
+	timertest.AssertNoEmit(t, sub.C, 10*time.Millisecond)
+}
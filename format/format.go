@@ -0,0 +1,95 @@
+// Package format renders durations measured by timer-core as clock strings like 1:23:45.67
+// custom layouts are described by patterns like "hh:mm:ss.SS", see Pattern
+//
+// Hours are only shown if the duration is at least one hour. Fractions are truncated, not rounded, so a
+// formatted time never shows a second which hasn't passed yet. Negative durations are prefixed with a minus sign.
+package format
+
+import "math"
+
+import "strconv"
+
+import "strings"
+
+import "time"
+
+// FormatHMS formats d as h:mm:ss, or m:ss for durations below one hour
+func FormatHMS(d time.Duration) string {
+	return FormatWithMillis(d, 0)
+}
+
+// FormatWithMillis formats d as h:mm:ss followed by digits fractional digits, e.g. 1:23:45.67 for 2 digits
+// digits is clamped between 0 and 9
+func FormatWithMillis(d time.Duration, digits int) string {
+	if digits < 0 {
+		digits = 0
+	}
+	if digits > 9 {
+		digits = 9
+	}
+
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	h := d / time.Hour
+	m := d % time.Hour / time.Minute
+	s := d % time.Minute / time.Second
+	if h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10))
+		b.WriteByte(':')
+		pad(&b, int64(m), 2)
+	} else {
+		b.WriteString(strconv.FormatInt(int64(m), 10))
+	}
+	b.WriteByte(':')
+	pad(&b, int64(s), 2)
+	if digits > 0 {
+		b.WriteByte('.')
+		pad(&b, int64(d%time.Second)/pow10(9-digits), digits)
+	}
+
+	return b.String()
+}
+
+// FormatFrames formats d as h:mm:ss followed by the frame within the second at fps frames per second, e.g. 1:23:45:12 like a timecode
+// frames are padded to the digits of the highest frame number
+func FormatFrames(d time.Duration, fps float64) string {
+	if fps <= 0 {
+		return FormatHMS(d)
+	}
+
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	// a small tolerance keeps times converted from frames on their frame despite float errors
+	frame := int64(math.Floor((abs%time.Second).Seconds()*fps + 1e-4))
+	width := len(strconv.FormatInt(int64(math.Ceil(fps))-1, 10))
+
+	var b strings.Builder
+	b.WriteString(FormatHMS(d))
+	b.WriteByte(':')
+	pad(&b, frame, width)
+
+	return b.String()
+}
+
+// pad writes v with leading zeros to width digits
+func pad(b *strings.Builder, v int64, width int) {
+	s := strconv.FormatInt(v, 10)
+	for i := len(s); i < width; i++ {
+		b.WriteByte('0')
+	}
+	b.WriteString(s)
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+
+	return p
+}
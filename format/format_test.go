@@ -0,0 +1,77 @@
+package format_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core/format"
+
+func TestFormatWithMillis(t *testing.T) {
+	tests := []struct {
+		d      time.Duration
+		digits int
+		want   string
+	}{
+		{0, 0, "0:00"},
+		{59*time.Second + 999*time.Millisecond, 0, "0:59"},
+		{83*time.Second + 456*time.Millisecond, 2, "1:23.45"},
+		{time.Hour + 2*time.Minute + 3*time.Second + 40*time.Millisecond, 3, "1:02:03.040"},
+		{100*time.Hour + time.Nanosecond, 9, "100:00:00.000000001"},
+		{-(90*time.Second + 500*time.Millisecond), 1, "-1:30.5"},
+		{time.Second, -1, "0:01"},
+		{time.Second, 12, "0:01.000000000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if s := format.FormatWithMillis(test.d, test.digits); s != test.want {
+				t.Errorf("FormatWithMillis(%v, %v) is %q, want %q", test.d, test.digits, s, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatHMS(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{5 * time.Second, "0:05"},
+		// fractions are truncated, so no second is shown before it has passed
+		{59*time.Minute + 59*time.Second + 999*time.Millisecond, "59:59"},
+		{time.Hour, "1:00:00"},
+		{-time.Minute, "-1:00"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if s := format.FormatHMS(test.d); s != test.want {
+				t.Errorf("FormatHMS(%v) is %q, want %q", test.d, s, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatFrames(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		fps  float64
+		want string
+	}{
+		{time.Minute + 500*time.Millisecond, 60, "1:00:30"},
+		{time.Hour + 5*time.Second + 40*time.Millisecond, 25, "1:00:05:01"},
+		// frames are padded to the digits of the highest frame
+		{5 * time.Second / 60, 120, "0:00:010"},
+		// 59 frames at 59.94fps truncated to nanoseconds stay on their frame despite float errors
+		{984317650 * time.Nanosecond, 59.94, "0:00:59"},
+		{2 * time.Second, 0, "0:02"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if s := format.FormatFrames(test.d, test.fps); s != test.want {
+				t.Errorf("FormatFrames(%v, %v) is %q, want %q", test.d, test.fps, s, test.want)
+			}
+		})
+	}
+}
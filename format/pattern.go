@@ -0,0 +1,200 @@
+package format
+
+import "fmt"
+
+import "strings"
+
+import "time"
+
+// Pattern is a compiled format pattern
+//
+// A pattern is made of the following tokens, all other characters are copied literally:
+//
+//	h, hh   hours, hh pads to two digits
+//	m, mm   minutes, mm pads to two digits
+//	s, ss   seconds, ss pads to two digits
+//	S...    fractional seconds, one digit per S up to 9
+//	\x      the literal character x
+//
+// The largest unit in the pattern carries the rest of the duration, e.g. "m:ss" formats 1h2m3s as 62:03.
+// Like the other functions of this package fractions are truncated and negative durations are prefixed with a minus sign
+type Pattern struct {
+	tokens  []token
+	largest unit
+}
+
+type unit int
+
+const (
+	literal unit = iota
+	fraction
+	seconds
+	minutes
+	hours
+)
+
+type token struct {
+	unit  unit
+	width int
+	text  string
+}
+
+// Compile parses pattern
+func Compile(pattern string) (*Pattern, error) {
+	p := &Pattern{}
+	var lit strings.Builder
+	flush := func() {
+		if lit.Len() > 0 {
+			p.tokens = append(p.tokens, token{unit: literal, text: lit.String()})
+			lit.Reset()
+		}
+	}
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		var u unit
+		switch c {
+		case '\\':
+			if i+1 >= len(pattern) {
+				return nil, fmt.Errorf("Pattern %q ends with an escape", pattern)
+			}
+			lit.WriteByte(pattern[i+1])
+			i += 2
+			continue
+		case 'h':
+			u = hours
+		case 'm':
+			u = minutes
+		case 's':
+			u = seconds
+		case 'S':
+			u = fraction
+		default:
+			lit.WriteByte(c)
+			i++
+			continue
+		}
+
+		width := 1
+		for i+width < len(pattern) && pattern[i+width] == c {
+			width++
+		}
+		if u != fraction && width > 2 {
+			return nil, fmt.Errorf("Pattern %q repeats %c more than twice", pattern, c)
+		}
+		if u == fraction && width > 9 {
+			return nil, fmt.Errorf("Pattern %q has more than 9 fractional digits", pattern)
+		}
+		if u > p.largest {
+			p.largest = u
+		}
+		flush()
+		p.tokens = append(p.tokens, token{unit: u, width: width})
+		i += width
+	}
+	flush()
+
+	return p, nil
+}
+
+// MustCompile is like Compile but panics if pattern can't be parsed
+func MustCompile(pattern string) *Pattern {
+	p, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// Format formats d according to the pattern
+func (p *Pattern) Format(d time.Duration) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+
+	for _, t := range p.tokens {
+		var v int64
+		switch t.unit {
+		case literal:
+			b.WriteString(t.text)
+			continue
+		case fraction:
+			pad(&b, int64(d%time.Second)/pow10(9-t.width), t.width)
+			continue
+		case hours:
+			v = int64(d / time.Hour)
+		case minutes:
+			v = int64(d / time.Minute)
+			if p.largest > minutes {
+				v %= 60
+			}
+		case seconds:
+			v = int64(d / time.Second)
+			if p.largest > seconds {
+				v %= 60
+			}
+		}
+		pad(&b, v, t.width)
+	}
+
+	return b.String()
+}
+
+// String returns the pattern in its source form
+func (p *Pattern) String() string {
+	var b strings.Builder
+	for _, t := range p.tokens {
+		var c byte
+		switch t.unit {
+		case literal:
+			for i := 0; i < len(t.text); i++ {
+				if strings.IndexByte(`hmsS\`, t.text[i]) >= 0 {
+					b.WriteByte('\\')
+				}
+				b.WriteByte(t.text[i])
+			}
+			continue
+		case fraction:
+			c = 'S'
+		case seconds:
+			c = 's'
+		case minutes:
+			c = 'm'
+		case hours:
+			c = 'h'
+		}
+		b.WriteString(strings.Repeat(string(c), t.width))
+	}
+
+	return b.String()
+}
+
+// MarshalText encodes the pattern in its source form, so patterns can be part of configuration files
+func (p *Pattern) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText compiles the pattern text
+func (p *Pattern) UnmarshalText(text []byte) error {
+	compiled, err := Compile(string(text))
+	if err != nil {
+		return err
+	}
+	*p = *compiled
+
+	return nil
+}
+
+// Format formats d according to pattern
+// patterns used repeatedly should be compiled once with Compile
+func Format(d time.Duration, pattern string) (string, error) {
+	p, err := Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	return p.Format(d), nil
+}
@@ -0,0 +1,74 @@
+package format_test
+
+import "encoding/json"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core/format"
+
+func TestPatternFormat(t *testing.T) {
+	d := time.Hour + 2*time.Minute + 3*time.Second + 456789*time.Microsecond
+
+	tests := []struct {
+		pattern string
+		d       time.Duration
+		want    string
+	}{
+		{"hh:mm:ss.SS", d, "01:02:03.45"},
+		{"h:mm:ss", d, "1:02:03"},
+		// the largest unit carries the rest of the duration
+		{"m:ss", d, "62:03"},
+		{"s.SSS", d, "3723.456"},
+		{"mm\\m ss\\s", d, "62m 03s"},
+		{"ss.S", -1500 * time.Millisecond, "-01.5"},
+		{"SSSSSSSSS", time.Nanosecond, "000000001"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			s, err := format.Format(test.d, test.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != test.want {
+				t.Errorf("Format(%v, %q) is %q, want %q", test.d, test.pattern, s, test.want)
+			}
+		})
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	for _, pattern := range []string{"hhh:mm", "ss.SSSSSSSSSS", "mm:ss\\"} {
+		t.Run(pattern, func(t *testing.T) {
+			if _, err := format.Compile(pattern); err == nil {
+				t.Errorf("compiling %q succeeded, want an error", pattern)
+			}
+		})
+	}
+}
+
+func TestPatternText(t *testing.T) {
+	for _, pattern := range []string{"hh:mm:ss.SS", "m\\m s\\s", "\\\\ss"} {
+		t.Run(pattern, func(t *testing.T) {
+			p := format.MustCompile(pattern)
+			if s := p.String(); s != pattern {
+				t.Errorf("String is %q, want %q", s, pattern)
+			}
+
+			data, err := json.Marshal(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded format.Pattern
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			d := 12*time.Minute + 34*time.Second
+			if got, want := decoded.Format(d), p.Format(d); got != want {
+				t.Errorf("decoded pattern formats %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package timer
+
+import "fmt"
+
+import "math"
+
+import "time"
+
+// FrameRounding describes how times are rounded to whole frames
+type FrameRounding int
+
+const (
+	// FrameFloor rounds down to the frame the time falls into
+	FrameFloor FrameRounding = iota
+	// FrameNearest rounds to the nearest frame boundary
+	FrameNearest
+	// FrameCeil rounds up to the next frame boundary
+	FrameCeil
+)
+
+// WithFrameRate enables frame accurate timing with fps frames per second, e.g. 60 or 59.94
+// Setting 0 or a negative value keeps timing in clock precision
+func WithFrameRate(fps float64, rounding FrameRounding) Option {
+	return func(t *Timer) {
+		if fps > 0 {
+			t.frameRate = fps
+			t.frameRounding = rounding
+		}
+	}
+}
+
+// SetFrameRate enables frame accurate timing with fps frames per second
+// splits, laps and subtimer times recorded afterwards are rounded to whole frames and tick events carry the elapsed frames.
+// Setting 0 disables frame accurate timing. Only possible when in Reset or Stopped state
+func (t *Timer) SetFrameRate(fps float64, rounding FrameRounding) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if t.state != Reset && t.state != Stopped {
+		return t.logFailed("SetFrameRate", &StateError{Op: "SetFrameRate", Current: t.state})
+	}
+	if fps < 0 || math.IsNaN(fps) || math.IsInf(fps, 0) {
+		return t.logFailed("SetFrameRate", fmt.Errorf("Only positive values for fps are allowed"))
+	}
+	if rounding != FrameFloor && rounding != FrameNearest && rounding != FrameCeil {
+		return t.logFailed("SetFrameRate", fmt.Errorf("Unknown frame rounding %v", rounding))
+	}
+	t.frameRate = fps
+	t.frameRounding = rounding
+
+	return nil
+}
+
+// FrameRate returns the frames per second of frame accurate timing. It is 0 if disabled
+func (t *Timer) FrameRate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.frameRate
+}
+
+// Frames returns the current elapsed time of the timer in whole frames
+// it is 0 if frame accurate timing is disabled
+func (t *Timer) Frames() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.frames(t.currentElapsed())
+}
+
+// frames converts d to whole frames using the rounding of the timer
+func (t *Timer) frames(d time.Duration) int64 {
+	if t.frameRate == 0 {
+		return 0
+	}
+	// a small tolerance keeps times converted from frames on their frame despite float errors
+	f := d.Seconds() * t.frameRate
+	switch t.frameRounding {
+	case FrameNearest:
+		return int64(math.Round(f))
+	case FrameCeil:
+		return int64(math.Ceil(f - 1e-4))
+	default:
+		return int64(math.Floor(f + 1e-4))
+	}
+}
+
+// roundFrames rounds d to whole frames. It returns d unchanged if frame accurate timing is disabled
+func (t *Timer) roundFrames(d time.Duration) time.Duration {
+	if t.frameRate == 0 {
+		return d
+	}
+
+	return time.Duration(math.Round(float64(t.frames(d)) * float64(time.Second) / t.frameRate))
+}
@@ -0,0 +1,67 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestFrameRounding(t *testing.T) {
+	// at 60fps 1.01s is 60.6 frames
+	const stop = 1010 * time.Millisecond
+
+	tests := []struct {
+		name     string
+		rounding timer.FrameRounding
+		frames   int64
+	}{
+		{"floor", timer.FrameFloor, 60},
+		{"nearest", timer.FrameNearest, 61},
+		{"ceil", timer.FrameCeil, 61},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock, timer.WithFrameRate(60, test.rounding), timer.WithSubtimers(1))
+			defer tm.Close()
+
+			timertest.Run(t, tm, clock,
+				timertest.Step{Do: timertest.Start},
+				timertest.Step{After: stop},
+			)
+			if f := tm.Frames(); f != test.frames {
+				t.Errorf("frames are %v, want %v", f, test.frames)
+			}
+
+			d, err := tm.StopSubTimer(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := time.Duration(test.frames) * time.Second / 60
+			if diff := d - want; diff < -time.Nanosecond || diff > time.Nanosecond {
+				t.Errorf("subtimer stopped at %v, want %v", d, want)
+			}
+		})
+	}
+}
+
+func TestSetFrameRate(t *testing.T) {
+	tm := timer.New()
+	defer tm.Close()
+
+	if err := tm.SetFrameRate(-1, timer.FrameFloor); err == nil {
+		t.Errorf("setting a negative frame rate succeeded, want an error")
+	}
+	if err := tm.SetFrameRate(30, timer.FrameRounding(7)); err == nil {
+		t.Errorf("setting an unknown rounding succeeded, want an error")
+	}
+	if err := tm.SetFrameRate(59.94, timer.FrameNearest); err != nil {
+		t.Fatal(err)
+	}
+	if fps := tm.FrameRate(); fps != 59.94 {
+		t.Errorf("frame rate is %v, want %v", fps, 59.94)
+	}
+}
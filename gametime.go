@@ -0,0 +1,105 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// GameTime returns the current game time
+// game time runs with the real time of the timer but can be paused on its own, e.g. by an autosplitter during loads
+func (t *Timer) GameTime() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.gameTime(t.currentElapsed())
+}
+
+// PauseGameTime pauses the game time while the real time continues
+// if the game time is already paused a StateError with the game time state Paused is returned
+func (t *Timer) PauseGameTime() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if t.gamePaused {
+		return t.logFailed("PauseGameTime", &StateError{Op: "PauseGameTime", Current: Paused})
+	}
+	t.pauseGame(nil)
+
+	return nil
+}
+
+// ResumeGameTime resumes the game time after PauseGameTime
+// if the game time is not paused a StateError with the game time state Running is returned
+func (t *Timer) ResumeGameTime() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if !t.gamePaused {
+		return t.logFailed("ResumeGameTime", &StateError{Op: "ResumeGameTime", Current: Running})
+	}
+	t.resumeGame()
+
+	return nil
+}
+
+// GameTimePaused reports whether the game time is paused
+func (t *Timer) GameTimePaused() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.gamePaused
+}
+
+// SetGameTime sets the game time to d, e.g. the in-game time read by an autosplitter
+func (t *Timer) SetGameTime(d time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if d < 0 {
+		return t.logFailed("SetGameTime", fmt.Errorf("Only positive values for d are allowed"))
+	}
+	elapsed := t.currentElapsed()
+	t.gameLoss += t.gameTime(elapsed) - d
+	t.emit(Event{Type: EventGameTimeSet, Elapsed: elapsed, GameTime: d})
+
+	return nil
+}
+
+// pauseGame freezes the game time at the current elapsed time. resumeAt is the time of a scheduled automatic resume and nil if there is none
+func (t *Timer) pauseGame(resumeAt *time.Time) {
+	t.gamePaused = true
+	t.gamePausedAt = t.currentElapsed()
+	t.emit(Event{Type: EventGameTimePaused, Elapsed: t.gamePausedAt, GameTime: t.gameTime(t.gamePausedAt), ResumeAt: resumeAt})
+}
+
+// resumeGame lets the game time continue and drops the time it was frozen
+func (t *Timer) resumeGame() {
+	elapsed := t.currentElapsed()
+	t.gameLoss += elapsed - t.gamePausedAt
+	t.gamePaused = false
+	t.emit(Event{Type: EventGameTimeResumed, Elapsed: elapsed, GameTime: t.gameTime(elapsed)})
+}
+
+// gameTime returns the game time at the real time elapsed
+// it is the real time minus all time lost while the game time was paused or set back
+func (t *Timer) gameTime(elapsed time.Duration) time.Duration {
+	if t.gamePaused {
+		elapsed = t.gamePausedAt
+	}
+
+	return elapsed - t.gameLoss
+}
+
+func (t *Timer) resetGameTime() {
+	t.gameLoss = 0
+	t.gamePaused = false
+	t.gamePausedAt = 0
+}
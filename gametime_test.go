@@ -0,0 +1,87 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestGameTime(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+	sub := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventGameTimePaused, timer.EventGameTimeResumed, timer.EventGameTimeSet}}))
+
+	check := func(real, game time.Duration) {
+		t.Helper()
+		if r, g := tm.Elapsed(), tm.GameTime(); r != real || g != game {
+			t.Errorf("real time is %v and game time %v, want %v and %v", r, g, real, game)
+		}
+	}
+
+	// a load removes time from the game time only
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second, Do: (*timer.Timer).PauseGameTime},
+		timertest.Step{After: 3 * time.Second},
+	)
+	if !tm.GameTimePaused() {
+		t.Errorf("game time isn't paused")
+	}
+	check(5*time.Second, 2*time.Second)
+	timertest.Run(t, tm, clock, timertest.Step{Do: (*timer.Timer).ResumeGameTime}, timertest.Step{After: time.Second})
+	check(6*time.Second, 3*time.Second)
+
+	if err := tm.SetGameTime(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	check(7*time.Second, 11*time.Second)
+
+	want := []struct {
+		typ      timer.EventType
+		gameTime time.Duration
+	}{
+		{timer.EventGameTimePaused, 2 * time.Second},
+		{timer.EventGameTimeResumed, 2 * time.Second},
+		{timer.EventGameTimeSet, 10 * time.Second},
+	}
+	for _, w := range want {
+		if e := timertest.AssertEmits(t, sub.C, 0); e.Type != w.typ || e.GameTime != w.gameTime {
+			t.Errorf("got %v with game time %v, want %v with %v", e.Type, e.GameTime, w.typ, w.gameTime)
+		}
+	}
+
+	// the game time follows the real time again in the next run
+	timertest.Run(t, tm, clock, timertest.Step{Do: (*timer.Timer).PauseGameTime}, timertest.Step{Do: timertest.Stop})
+	prepare(t, tm)
+	if tm.GameTimePaused() {
+		t.Errorf("game time is paused after reset")
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start}, timertest.Step{After: time.Second})
+	check(time.Second, time.Second)
+}
+
+func TestGameTimeInvalid(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	var stateErr *timer.StateError
+	if err := tm.ResumeGameTime(); !errors.As(err, &stateErr) || stateErr.Current != timer.Running {
+		t.Errorf("resuming a running game time returned %v, want a state error with Running", err)
+	}
+	if err := tm.PauseGameTime(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.PauseGameTime(); !errors.As(err, &stateErr) || stateErr.Current != timer.Paused {
+		t.Errorf("pausing a paused game time returned %v, want a state error with Paused", err)
+	}
+	if err := tm.SetGameTime(-time.Second); err == nil {
+		t.Errorf("setting a negative game time succeeded, want an error")
+	}
+}
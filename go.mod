@@ -1,3 +1,5 @@
 module github.com/onestay/timer-core
 
 go 1.13
+
+require github.com/gorilla/websocket v1.4.1
@@ -0,0 +1,154 @@
+package timer
+
+import "sync"
+
+import "time"
+
+// HistoryEntry is a single run recorded by a History
+type HistoryEntry struct {
+	// Number is the 1 based number of the run in the history
+	Number    int           `json:"number"`
+	StartTime time.Time     `json:"startTime"`
+	EndTime   time.Time     `json:"endTime"`
+	FinalTime time.Duration `json:"finalTime"`
+	// Completed is set if the timer was stopped and no subtimer was left unfinished
+	Completed bool `json:"completed"`
+	// Subtimers holds the time of every subtimer keyed by id
+	Subtimers map[int]time.Duration `json:"subtimers"`
+	Report    Report                `json:"report"`
+}
+
+// History records the runs of one or more timers across resets
+type History struct {
+	mu      sync.Mutex
+	entries []HistoryEntry
+}
+
+// NewHistory returns a new empty history
+func NewHistory() *History {
+	return &History{}
+}
+
+// Record adds the run described by r to the history and returns its entry
+func (h *History) Record(r Report) HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	e := HistoryEntry{
+		Number:    len(h.entries) + 1,
+		StartTime: r.StartTime,
+		FinalTime: r.FinalTime,
+		Completed: r.Completed(),
+		Subtimers: make(map[int]time.Duration, len(r.Subtimers)),
+		Report:    r,
+	}
+	if !r.StartTime.IsZero() {
+		e.EndTime = r.StartTime.Add(r.WallTime)
+	}
+	for _, s := range r.Subtimers {
+		e.Subtimers[s.ID] = s.Compensated
+	}
+	h.entries = append(h.entries, e)
+
+	return e
+}
+
+// Runs returns all recorded runs in the order they were recorded
+func (h *History) Runs() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]HistoryEntry(nil), h.entries...)
+}
+
+// Completed returns all completed runs in the order they were recorded
+func (h *History) Completed() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var completed []HistoryEntry
+	for _, e := range h.entries {
+		if e.Completed {
+			completed = append(completed, e)
+		}
+	}
+
+	return completed
+}
+
+// Run returns the run with number n
+func (h *History) Run(n int) (HistoryEntry, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n < 1 || n > len(h.entries) {
+		return HistoryEntry{}, false
+	}
+
+	return h.entries[n-1], true
+}
+
+// Best returns the fastest completed run
+func (h *History) Best() (HistoryEntry, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var best HistoryEntry
+	found := false
+	for _, e := range h.entries {
+		if e.Completed && (!found || e.FinalTime < best.FinalTime) {
+			best = e
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// Between returns all runs started in [from, to)
+func (h *History) Between(from, to time.Time) []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var runs []HistoryEntry
+	for _, e := range h.entries {
+		if !e.StartTime.Before(from) && e.StartTime.Before(to) {
+			runs = append(runs, e)
+		}
+	}
+
+	return runs
+}
+
+// Len returns the number of recorded runs
+func (h *History) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.entries)
+}
+
+// WithHistory records every run of the timer in h when the timer is reset
+func WithHistory(h *History) Option {
+	return func(t *Timer) {
+		t.history = h
+	}
+}
+
+// SetHistory sets the history every run of the timer is recorded in when the timer is reset
+// Setting nil stops recording
+func (t *Timer) SetHistory(h *History) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.history = h
+}
+
+// recordHistory records the current run in the history before it is discarded
+// runs which were never started are not recorded
+func (t *Timer) recordHistory() {
+	if t.history == nil || t.firstStart.IsZero() {
+		return
+	}
+	t.history.Record(t.reportLocked())
+}
@@ -0,0 +1,84 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestHistory(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	clock := timertest.NewClock(start)
+	h := timer.NewHistory()
+	tm := newTimer(t, clock, timer.WithSubtimers(1), timer.WithHistory(h))
+	defer tm.Close()
+
+	runs := []struct {
+		begin    time.Duration
+		finish   time.Duration
+		complete bool
+	}{
+		{time.Minute, 3 * time.Second, true},
+		{2*time.Minute + 3*time.Second, 2 * time.Second, false},
+		{3*time.Minute + 5*time.Second, 2 * time.Second, true},
+	}
+	for _, run := range runs {
+		var finish func(tm *timer.Timer) error
+		if run.complete {
+			finish = timertest.StopSubTimer(1)
+		}
+		timertest.Run(t, tm, clock,
+			timertest.Step{After: time.Minute, Do: timertest.Start},
+			timertest.Step{After: run.finish, Do: finish},
+			timertest.Step{Do: timertest.Stop},
+		)
+		prepare(t, tm)
+	}
+	if n := h.Len(); n != 3 {
+		t.Fatalf("history has %v runs, want 3", n)
+	}
+	for i, e := range h.Runs() {
+		run := runs[i]
+		begin := start.Add(run.begin)
+		if e.Number != i+1 || e.FinalTime != run.finish || e.Completed != run.complete {
+			t.Errorf("run %v is %+v, want final time %v and completed %v", i+1, e, run.finish, run.complete)
+		}
+		if !e.StartTime.Equal(begin) || !e.EndTime.Equal(begin.Add(run.finish)) {
+			t.Errorf("run %v took from %v to %v, want %v to %v", i+1, e.StartTime, e.EndTime, begin, begin.Add(run.finish))
+		}
+		if d, ok := e.Subtimers[1]; run.complete && (!ok || d != run.finish) {
+			t.Errorf("run %v has subtimers %v, want 1 at %v", i+1, e.Subtimers, run.finish)
+		}
+	}
+
+	if completed := h.Completed(); len(completed) != 2 || completed[0].Number != 1 || completed[1].Number != 3 {
+		t.Errorf("completed runs are %+v, want runs 1 and 3", completed)
+	}
+	if best, ok := h.Best(); !ok || best.Number != 3 {
+		t.Errorf("best run is %v, %v, want run 3", best.Number, ok)
+	}
+	if e, ok := h.Run(2); !ok || e.Number != 2 {
+		t.Errorf("run 2 is %v, %v", e.Number, ok)
+	}
+	for _, n := range []int{0, 4} {
+		if _, ok := h.Run(n); ok {
+			t.Errorf("found run %v, want none", n)
+		}
+	}
+	second := h.Runs()[1].StartTime
+	if between := h.Between(second, second.Add(time.Hour)); len(between) != 2 || between[0].Number != 2 {
+		t.Errorf("runs between %v and an hour later are %+v, want runs 2 and 3", second, between)
+	}
+	if _, ok := timer.NewHistory().Best(); ok {
+		t.Errorf("empty history has a best run")
+	}
+
+	tm.SetHistory(nil)
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start}, timertest.Step{Do: timertest.Stop})
+	prepare(t, tm)
+	if n := h.Len(); n != 3 {
+		t.Errorf("history has %v runs after removing it from the timer, want 3", n)
+	}
+}
@@ -0,0 +1,61 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// PhaseRest is the kind of the rest phases of interval timers
+const PhaseRest = "rest"
+
+// Interval is a single work phase followed by a rest phase
+// Rest may be 0 to go straight to the next work phase
+type Interval struct {
+	Work time.Duration `json:"work"`
+	Rest time.Duration `json:"rest"`
+}
+
+// IntervalConfig configures an interval training timer
+type IntervalConfig struct {
+	// Intervals are counted down in order in every round
+	Intervals []Interval
+	Rounds    int
+}
+
+// Tabata returns the configuration of a Tabata workout: 8 rounds of 20 seconds work and 10 seconds rest
+func Tabata() IntervalConfig {
+	return IntervalConfig{Intervals: []Interval{{Work: 20 * time.Second, Rest: 10 * time.Second}}, Rounds: 8}
+}
+
+// NewIntervals returns a countdown timer counting down the intervals of cfg for the configured number of rounds
+// every change of the phase emits EventPhase and tick events carry the current phase. Once the last phase expired
+// EventPhasesFinished is emitted and the timer is stopped. Resetting the timer starts over with the first round
+func NewIntervals(cfg IntervalConfig, opts ...Option) (*Timer, error) {
+	if len(cfg.Intervals) == 0 {
+		return nil, fmt.Errorf("At least one interval is required")
+	}
+	if cfg.Rounds <= 0 {
+		return nil, fmt.Errorf("Only positive values for Rounds are allowed")
+	}
+
+	// phases flattens all rounds into a single list, skipping empty rest phases
+	var phases []Phase
+	for round := 1; round <= cfg.Rounds; round++ {
+		for _, i := range cfg.Intervals {
+			if i.Work <= 0 || i.Rest < 0 {
+				return nil, fmt.Errorf("Work has to be positive and Rest must not be negative")
+			}
+			phases = append(phases, Phase{Kind: PhaseWork, Number: len(phases) + 1, Round: round, Duration: i.Work})
+			if i.Rest > 0 {
+				phases = append(phases, Phase{Kind: PhaseRest, Number: len(phases) + 1, Round: round, Duration: i.Rest})
+			}
+		}
+	}
+
+	return newPhaseTimer(func(n int) (Phase, bool) {
+		if n >= len(phases) {
+			return Phase{}, false
+		}
+
+		return phases[n], true
+	}, opts...), nil
+}
@@ -0,0 +1,116 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestNewIntervalsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  timer.IntervalConfig
+	}{
+		{"no intervals", timer.IntervalConfig{Rounds: 1}},
+		{"no rounds", timer.IntervalConfig{Intervals: []timer.Interval{{Work: time.Second}}}},
+		{"no work", timer.IntervalConfig{Intervals: []timer.Interval{{Rest: time.Second}}, Rounds: 1}},
+		{"negative rest", timer.IntervalConfig{Intervals: []timer.Interval{{Work: time.Second, Rest: -time.Second}}, Rounds: 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := timer.NewIntervals(test.cfg); err == nil {
+				t.Errorf("creating the timer succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestIntervalsPhases(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm, err := timer.NewIntervals(timer.IntervalConfig{
+		Intervals: []timer.Interval{{Work: 3 * time.Second, Rest: time.Second}, {Work: 2 * time.Second}},
+		Rounds:    2,
+	}, timer.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prepare(t, tm)
+	defer tm.Close()
+	phases := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventPhase, timer.EventPhasesFinished}}), timer.WithOverflow(timer.Unbounded))
+
+	first, _ := tm.Phase()
+	if want := (timer.Phase{Kind: timer.PhaseWork, Number: 1, Round: 1, Duration: 3 * time.Second}); first != want {
+		t.Errorf("first phase is %+v, want %+v", first, want)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+
+	// the empty rest of the second interval is skipped
+	want := []timer.Phase{
+		{Kind: timer.PhaseRest, Number: 2, Round: 1, Duration: time.Second},
+		{Kind: timer.PhaseWork, Number: 3, Round: 1, Duration: 2 * time.Second},
+		{Kind: timer.PhaseWork, Number: 4, Round: 2, Duration: 3 * time.Second},
+		{Kind: timer.PhaseRest, Number: 5, Round: 2, Duration: time.Second},
+		{Kind: timer.PhaseWork, Number: 6, Round: 2, Duration: 2 * time.Second},
+	}
+	current := first
+	for _, p := range want {
+		clock.Advance(current.Duration)
+		e := timertest.AssertEmitsType(t, phases.C, timer.EventPhase, 0)
+		if e.Phase == nil || *e.Phase != p {
+			t.Fatalf("started phase %+v, want %+v", e.Phase, p)
+		}
+		current = p
+	}
+
+	clock.Advance(current.Duration)
+	timertest.AssertEmitsType(t, phases.C, timer.EventPhasesFinished, 0)
+	waitState(t, tm, timer.Stopped, time.Second)
+
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := tm.Phase(); p != first {
+		t.Errorf("phase after reset is %+v, want %+v", p, first)
+	}
+}
+
+func TestIntervalsTicksCarryPhase(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm, err := timer.NewIntervals(timer.Tabata(), timer.WithClock(clock), timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prepare(t, tm)
+	defer tm.Close()
+	ticks := tm.Subscribe(timer.WithFilter(timer.TicksOnly()), timer.WithOverflow(timer.Unbounded))
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 25 * time.Second},
+	)
+	waitPhase(t, tm, 2, time.Second)
+	clock.Advance(time.Second)
+
+	// ticks sent before the phase change still carry the first phase
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case e := <-ticks.C:
+			if e.Phase == nil {
+				t.Fatalf("tick without a phase")
+			}
+			if e.Phase.Number != 2 {
+				continue
+			}
+			if want := (timer.Phase{Kind: timer.PhaseRest, Number: 2, Round: 1, Duration: 10 * time.Second}); *e.Phase != want {
+				t.Errorf("tick carries phase %+v, want %+v", *e.Phase, want)
+			}
+			return
+		case <-deadline:
+			t.Fatalf("no tick in the rest phase within 1s")
+		}
+	}
+}
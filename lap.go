@@ -0,0 +1,57 @@
+package timer
+
+import "time"
+
+// LapResult describes a single lap of the main timer
+type LapResult struct {
+	// Number is the 1 based number of the lap
+	Number int `json:"number"`
+	// Time is the duration of the lap and Total the elapsed time of the timer at its end
+	Time  time.Duration `json:"time"`
+	Total time.Duration `json:"total"`
+}
+
+// Lap finishes the current lap of the main timer and returns it
+// laps are independent of subtimers. Only possible when in Running or Paused state
+func (t *Timer) Lap() (LapResult, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return LapResult{}, ErrClosed
+	}
+	if t.state != Running && t.state != Paused {
+		return LapResult{}, &StateError{Op: "Lap", Current: t.state}
+	}
+
+	// the lap ends at the moment of the call and not at the last tick
+	elapsed := t.roundFrames(t.currentElapsed())
+	lap := LapResult{Number: len(t.laps) + 1, Time: elapsed, Total: elapsed}
+	if len(t.laps) > 0 {
+		lap.Time -= t.laps[len(t.laps)-1].Total
+	}
+	t.laps = append(t.laps, lap)
+	t.emit(Event{Type: EventLap, Elapsed: elapsed, Lap: &lap})
+
+	return lap, nil
+}
+
+// Split records a split of the main timer. It is the stopwatch style name of Lap
+func (t *Timer) Split() (LapResult, error) {
+	return t.Lap()
+}
+
+// Laps returns all laps of the current run in order
+func (t *Timer) Laps() []LapResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.lapsLocked()
+}
+
+func (t *Timer) lapsLocked() []LapResult {
+	laps := make([]LapResult, len(t.laps))
+	copy(laps, t.laps)
+
+	return laps
+}
@@ -0,0 +1,89 @@
+package timer_test
+
+import "errors"
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestLaps(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+	laps := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventLap}}))
+
+	var stateErr *timer.StateError
+	if _, err := tm.Lap(); !errors.As(err, &stateErr) {
+		t.Errorf("lap before start returned %v, want a state error", err)
+	}
+
+	lap := func(tm *timer.Timer) error {
+		_, err := tm.Lap()
+		return err
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second, Do: lap},
+		timertest.Step{After: 3 * time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{Do: timertest.Pause},
+		timertest.Step{After: 4 * time.Second, Do: lap},
+	)
+	want := []timer.LapResult{
+		{Number: 1, Time: 2 * time.Second, Total: 2 * time.Second},
+		{Number: 2, Time: 3 * time.Second, Total: 5 * time.Second},
+	}
+	if got := tm.Laps(); !reflect.DeepEqual(got, want) {
+		t.Errorf("laps are %+v, want %+v", got, want)
+	}
+	for _, l := range want {
+		e := timertest.AssertEmitsType(t, laps.C, timer.EventLap, 0)
+		if e.Lap == nil || *e.Lap != l || e.Elapsed != l.Total {
+			t.Errorf("lap event has lap %+v at %v, want %+v", e.Lap, e.Elapsed, l)
+		}
+	}
+	if r := tm.Report(); !reflect.DeepEqual(r.Laps, want) {
+		t.Errorf("report has laps %+v, want %+v", r.Laps, want)
+	}
+
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+	if _, err := tm.Lap(); !errors.As(err, &stateErr) {
+		t.Errorf("lap after stop returned %v, want a state error", err)
+	}
+	prepare(t, tm)
+	if got := tm.Laps(); len(got) != 0 {
+		t.Errorf("laps after reset are %+v, want none", got)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	split := func(tm *timer.Timer) error {
+		_, err := tm.Split()
+		return err
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: split},
+		timertest.Step{After: 500 * time.Millisecond, Do: split},
+	)
+	l, err := tm.Lap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// splits and laps share a single history
+	if want := (timer.LapResult{Number: 3, Total: 1500 * time.Millisecond}); l != want {
+		t.Errorf("lap after two splits is %+v, want %+v", l, want)
+	}
+	if laps := tm.Laps(); len(laps) != 3 || laps[1].Time != 500*time.Millisecond {
+		t.Errorf("laps are %+v, want the second one to take 500ms", laps)
+	}
+}
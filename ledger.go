@@ -0,0 +1,131 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// AdjustmentKind describes the reason category of an adjustment
+type AdjustmentKind int
+
+const (
+	// AdjustmentPenalty adds Amount to the total
+	AdjustmentPenalty AdjustmentKind = iota
+	// AdjustmentBonus subtracts Amount from the total
+	AdjustmentBonus
+	// AdjustmentCorrection adds Amount to the total, which may be negative
+	AdjustmentCorrection
+)
+
+// Adjustment is a single entry of the ledger of time adjustments
+type Adjustment struct {
+	Kind AdjustmentKind `json:"kind"`
+	// Subtimer is the id of the adjusted subtimer. It is nil for adjustments of the main timer
+	Subtimer *int          `json:"subtimer,omitempty"`
+	Amount   time.Duration `json:"amount"`
+	Author   string        `json:"author"`
+	Reason   string        `json:"reason"`
+	// Time is the wall clock time the adjustment was recorded at. It is set by the timer
+	Time time.Time `json:"time"`
+}
+
+// delta returns the change of the total caused by the adjustment
+func (a Adjustment) delta() time.Duration {
+	if a.Kind == AdjustmentBonus {
+		return -a.Amount
+	}
+
+	return a.Amount
+}
+
+// AddAdjustment records a penalty, bonus or correction in the ledger and applies it to the total of the main timer or a subtimer
+// adjustments never change recorded times but are included in adjusted and compensated totals. Author and Reason are required.
+// Adjudicated subtimers can't be adjusted
+func (t *Timer) AddAdjustment(a Adjustment) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if a.Author == "" || a.Reason == "" {
+		return fmt.Errorf("Adjustments require an author and a reason")
+	}
+	switch a.Kind {
+	case AdjustmentPenalty, AdjustmentBonus:
+		if a.Amount <= 0 {
+			return fmt.Errorf("Only positive amounts are allowed for penalties and bonuses")
+		}
+	case AdjustmentCorrection:
+		if a.Amount == 0 {
+			return fmt.Errorf("Corrections require a non zero amount")
+		}
+	default:
+		return fmt.Errorf("Unknown adjustment kind %v", a.Kind)
+	}
+	if t.state == Reset {
+		return &StateError{Op: "AddAdjustment", Current: t.state}
+	}
+
+	if a.Subtimer != nil {
+		id := *a.Subtimer
+		s, ok := t.subtimers[id]
+		if !ok {
+			return errSubtimerNotFound(id)
+		}
+		if s.adjudicated {
+			return errSubtimerAdjudicated(id)
+		}
+		s.adjustment += a.delta()
+		a.Subtimer = intPtr(id)
+	}
+
+	a.Time = t.clock.Now()
+	t.ledger = append(t.ledger, a)
+	t.emit(Event{Type: EventAdjusted, Time: a.Time, Elapsed: t.elapsed, Subtimer: a.Subtimer, Adjustment: &a})
+
+	return nil
+}
+
+// Ledger returns all adjustments of the current run in the order they were recorded
+func (t *Timer) Ledger() []Adjustment {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.ledgerLocked()
+}
+
+func (t *Timer) ledgerLocked() []Adjustment {
+	ledger := make([]Adjustment, len(t.ledger))
+	copy(ledger, t.ledger)
+
+	return ledger
+}
+
+// adjusted returns the elapsed time of the main timer including all adjustments of the main timer
+func (t *Timer) adjusted() time.Duration {
+	d := t.elapsed
+	for _, a := range t.ledger {
+		if a.Subtimer == nil {
+			d += a.delta()
+		}
+	}
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
+
+// total returns the recorded time of s corrected by its stream delay and adjustments
+// adjudicated times are official and returned as is
+func (s *subtimer) total() time.Duration {
+	if s.adjudicated {
+		return s.Time
+	}
+	d := s.compensate(s.Time) + s.adjustment
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
@@ -0,0 +1,110 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestAddAdjustmentInvalid(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+	valid := timer.Adjustment{Amount: time.Second, Author: "referee", Reason: "missed a checkpoint"}
+
+	var stateErr *timer.StateError
+	if err := tm.AddAdjustment(valid); !errors.As(err, &stateErr) {
+		t.Errorf("adjusting a reset timer returned %v, want a state error", err)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+
+	two := 2
+	tests := []struct {
+		name   string
+		modify func(a *timer.Adjustment)
+	}{
+		{"no author", func(a *timer.Adjustment) { a.Author = "" }},
+		{"no reason", func(a *timer.Adjustment) { a.Reason = "" }},
+		{"negative penalty", func(a *timer.Adjustment) { a.Amount = -time.Second }},
+		{"empty bonus", func(a *timer.Adjustment) { a.Kind, a.Amount = timer.AdjustmentBonus, 0 }},
+		{"empty correction", func(a *timer.Adjustment) { a.Kind, a.Amount = timer.AdjustmentCorrection, 0 }},
+		{"unknown kind", func(a *timer.Adjustment) { a.Kind = timer.AdjustmentKind(7) }},
+		{"unknown subtimer", func(a *timer.Adjustment) { a.Subtimer = &two }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := valid
+			test.modify(&a)
+			if err := tm.AddAdjustment(a); err == nil {
+				t.Errorf("adding the adjustment succeeded, want an error")
+			}
+		})
+	}
+	if l := tm.Ledger(); len(l) != 0 {
+		t.Errorf("ledger holds %v entries after failed adjustments, want none", len(l))
+	}
+}
+
+func TestLedger(t *testing.T) {
+	now := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	clock := timertest.NewClock(now)
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2))
+	defer tm.Close()
+	adjusted := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventAdjusted}}), timer.WithOverflow(timer.Unbounded))
+
+	one, two := 1, 2
+	adjustments := []timer.Adjustment{
+		{Kind: timer.AdjustmentPenalty, Amount: 5 * time.Second, Author: "referee", Reason: "false start"},
+		{Kind: timer.AdjustmentBonus, Amount: 2 * time.Second, Author: "referee", Reason: "bonus stage"},
+		{Kind: timer.AdjustmentPenalty, Subtimer: &one, Amount: 3 * time.Second, Author: "referee", Reason: "skipped a level"},
+		{Kind: timer.AdjustmentCorrection, Subtimer: &two, Amount: -20 * time.Second, Author: "admin", Reason: "wrong split"},
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 10 * time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{Do: timertest.StopSubTimer(2)},
+		timertest.Step{Do: timertest.Stop},
+	)
+	for _, a := range adjustments {
+		clock.Advance(time.Second)
+		if err := tm.AddAdjustment(a); err != nil {
+			t.Fatal(err)
+		}
+		e := timertest.AssertEmitsType(t, adjusted.C, timer.EventAdjusted, 0)
+		if e.Adjustment == nil || e.Adjustment.Reason != a.Reason {
+			t.Errorf("event holds adjustment %+v, want %q", e.Adjustment, a.Reason)
+		}
+	}
+	// the caller's subtimer id is copied
+	one = 2
+
+	ledger := tm.Ledger()
+	if len(ledger) != len(adjustments) {
+		t.Fatalf("ledger holds %v entries, want %v", len(ledger), len(adjustments))
+	}
+	for i, a := range ledger {
+		if want := now.Add(time.Duration(11+i) * time.Second); !a.Time.Equal(want) {
+			t.Errorf("adjustment %v recorded at %v, want %v", i, a.Time, want)
+		}
+	}
+	if *ledger[2].Subtimer != 1 {
+		t.Errorf("adjustment 2 is for subtimer %v, want 1", *ledger[2].Subtimer)
+	}
+
+	// recorded times never change, totals include the adjustments and never drop below 0
+	r := tm.Report()
+	if r.FinalTime != 10*time.Second || r.Adjusted != 13*time.Second {
+		t.Errorf("final time is %v adjusted to %v, want 10s adjusted to 13s", r.FinalTime, r.Adjusted)
+	}
+	want := map[int]time.Duration{1: 13 * time.Second, 2: 0}
+	for _, s := range r.Subtimers {
+		if s.Time != 10*time.Second || s.Compensated != want[s.ID] {
+			t.Errorf("subtimer %v at %v compensated to %v, want 10s compensated to %v", s.ID, s.Time, s.Compensated, want[s.ID])
+		}
+	}
+}
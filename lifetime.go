@@ -0,0 +1,56 @@
+package timer
+
+import "context"
+
+// NewWithContext initializes and returns a new timer whose lifetime is bound to ctx
+// once ctx is done the timer is stopped, its tickers and loop are terminated and the updates channel is closed
+func NewWithContext(ctx context.Context, opts ...Option) *Timer {
+	t := New(opts...)
+	go func() {
+		select {
+		case <-ctx.Done():
+			t.shutdown()
+		case <-t.quit:
+		}
+	}()
+
+	return t
+}
+
+// Close stops the timer, terminates its tickers and loop and closes the updates channel
+// all subscriptions are closed after delivering the events emitted until then and Close waits until their callbacks and samplers have returned, so it must not be called from a callback.
+// All further operations changing the timer return ErrClosed, queries keep working. Calling Close twice returns ErrClosed
+func (t *Timer) Close() error {
+	if !t.shutdown() {
+		return ErrClosed
+	}
+
+	return nil
+}
+
+// shutdown stops the timer, terminates its loop, closes the updates channel and all subscriptions
+// it reports false if the timer has already been shut down
+func (t *Timer) shutdown() bool {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return false
+	}
+	if t.state == Running || t.state == Paused {
+		t.stopTimerLocked()
+	}
+	t.stopAutoResume()
+	t.stopScheduledStart()
+	t.closed = true
+	t.closeUpdateSubs()
+	t.closeSubscriptions()
+	close(t.quit)
+	t.mu.Unlock()
+
+	// the loop may be sending an update, so the channel is closed once it exited
+	t.loops.Wait()
+	close(t.Updates)
+	t.workers.Wait()
+
+	return true
+}
@@ -0,0 +1,75 @@
+package timer_test
+
+import "context"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestNewWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clock := timertest.NewClock(time.Now())
+	tm := prepare(t, timer.NewWithContext(ctx, timer.WithClock(clock)))
+	sub := tm.Subscribe(timer.WithFilter(timer.StateChangesOnly()))
+	if err := tm.StartTimer(); err != nil {
+		t.Fatal(err)
+	}
+	timertest.AssertEmitsType(t, sub.C, timer.EventStarted, 0)
+	clock.Advance(time.Second)
+
+	cancel()
+	// the timer is stopped before the subscriptions are closed
+	timertest.AssertEmitsType(t, sub.C, timer.EventStopped, 0)
+	select {
+	case _, ok := <-sub.C:
+		if ok {
+			t.Errorf("subscription received an event after the stop, want it closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("subscription not closed after canceling the context")
+	}
+	if s := tm.State(); s != timer.Stopped {
+		t.Errorf("timer is %v, want stopped", s)
+	}
+	if err := tm.StartTimer(); err != timer.ErrClosed {
+		t.Errorf("StartTimer returned %v, want %v", err, timer.ErrClosed)
+	}
+	if err := tm.Close(); err != timer.ErrClosed {
+		t.Errorf("Close returned %v, want %v", err, timer.ErrClosed)
+	}
+}
+
+func TestClose(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := timer.New(timer.WithClock(clock))
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second, Do: timertest.Pause},
+	)
+
+	if err := tm.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-tm.Updates; ok {
+		t.Errorf("received an update after closing, want the channel closed")
+	}
+	// queries keep working
+	clock.Advance(time.Second)
+	if s, d := tm.State(), tm.Elapsed(); s != timer.Stopped || d != 2*time.Second {
+		t.Errorf("closed timer is %v at %v, want stopped at 2s", s, d)
+	}
+	if err := tm.ResetTimer(); err != timer.ErrClosed {
+		t.Errorf("ResetTimer returned %v, want %v", err, timer.ErrClosed)
+	}
+	if err := tm.Close(); err != timer.ErrClosed {
+		t.Errorf("second Close returned %v, want %v", err, timer.ErrClosed)
+	}
+}
@@ -0,0 +1,43 @@
+package timer
+
+import "time"
+
+// LiveDelta is the difference between the currently active subtimer and its comparison time
+type LiveDelta struct {
+	// ID is the id of the active subtimer, the running subtimer with the earliest comparison time
+	ID int `json:"id"`
+	// Delta is the current time of the subtimer minus its comparison time, positive if it is behind
+	Delta time.Duration `json:"delta"`
+}
+
+// LiveDelta returns the live delta of the currently active subtimer
+// ok is false if no comparison is set or no running subtimer is part of it
+func (t *Timer) LiveDelta() (delta LiveDelta, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	d := t.liveDelta()
+	if d == nil {
+		return LiveDelta{}, false
+	}
+
+	return *d, true
+}
+
+// liveDelta returns the live delta of the active subtimer or nil if there is none
+func (t *Timer) liveDelta() *LiveDelta {
+	var active *LiveDelta
+	var target time.Duration
+	for id, s := range t.subtimers {
+		c, ok := t.comparison[id]
+		if !ok || (s.state != Running && s.state != Paused) {
+			continue
+		}
+		if active == nil || c < target || (c == target && id < active.ID) {
+			active = &LiveDelta{ID: id, Delta: t.subtimerElapsed(s) - c}
+			target = c
+		}
+	}
+
+	return active
+}
@@ -0,0 +1,57 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+func TestLiveDelta(t *testing.T) {
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Timer.Close()
+	for _, id := range []int{1, 2, 3} {
+		if err := s.Timer.AddSubTimer(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := s.Timer.LiveDelta(); ok {
+		t.Errorf("found a live delta without a comparison")
+	}
+	// subtimer 3 isn't part of the comparison, so it is never active
+	s.Timer.SetComparison(map[int]time.Duration{1: 3 * time.Second, 2: 5 * time.Second})
+	s.At(0, (*timer.Timer).StartTimer)
+	s.At(3500*time.Millisecond, func(tm *timer.Timer) error {
+		_, err := tm.StopSubTimer(1)
+		return err
+	})
+	events, err := s.Run(6 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[time.Duration]timer.LiveDelta{
+		2 * time.Second: {ID: 1, Delta: -time.Second},
+		3 * time.Second: {ID: 1, Delta: 0},
+		5 * time.Second: {ID: 2, Delta: 0},
+	}
+	checked := 0
+	for _, e := range events {
+		w, ok := want[e.Elapsed]
+		if e.Type != timer.EventTick || !ok {
+			continue
+		}
+		checked++
+		if e.Live == nil || *e.Live != w {
+			t.Errorf("tick at %v has live delta %+v, want %+v", e.Elapsed, e.Live, w)
+		}
+	}
+	if checked != len(want) {
+		t.Errorf("checked %v ticks, want %v", checked, len(want))
+	}
+	if d, ok := s.Timer.LiveDelta(); !ok || d != (timer.LiveDelta{ID: 2, Delta: time.Second}) {
+		t.Errorf("live delta is %+v, %v, want subtimer 2 one second behind", d, ok)
+	}
+}
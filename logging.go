@@ -0,0 +1,57 @@
+package timer
+
+// log levels passed to the log hook. They match the levels of log/slog
+const (
+	logDebug = -4
+	logInfo  = 0
+	logWarn  = 4
+)
+
+// logHook receives the log records of a timer. It is called while the timer is locked and must not call methods of the timer
+type logHook func(level int, msg string, args ...interface{})
+
+// log passes a record to the log hook of the timer if one is set
+func (t *Timer) log(level int, msg string, args ...interface{}) {
+	if t.logHook != nil {
+		t.logHook(level, msg, args...)
+	}
+}
+
+// logFailed logs err as a failed attempt of op and returns it
+func (t *Timer) logFailed(op string, err error, args ...interface{}) error {
+	if err != nil {
+		t.log(logInfo, "Operation failed", append([]interface{}{"op", op, "state", t.state, "error", err}, args...)...)
+	}
+
+	return err
+}
+
+// logEvent logs a non periodic event. State changes of the timer are logged at info level, all other events at debug level
+func (t *Timer) logEvent(e Event) {
+	if t.logHook == nil || e.Type.periodic() {
+		return
+	}
+
+	level := logDebug
+	switch {
+	case e.Severity >= SeverityWarning:
+		level = logWarn
+	case e.Subtimer == nil && containsEventType(StateChangeEvents, e.Type):
+		level = logInfo
+	}
+	args := []interface{}{"type", e.Type, "elapsed", e.Elapsed}
+	if e.Subtimer != nil {
+		args = append(args, "subtimer", *e.Subtimer)
+	}
+	t.log(level, "Timer event", args...)
+}
+
+func containsEventType(types []EventType, typ EventType) bool {
+	for _, e := range types {
+		if e == typ {
+			return true
+		}
+	}
+
+	return false
+}
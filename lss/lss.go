@@ -0,0 +1,361 @@
+// Package lss reads and writes LiveSplit splits files (.lss) and maps their segments to subtimers
+// only real time is supported. Elements not modeled by Run like icons and auto splitter settings are not preserved
+package lss
+
+import "encoding/xml"
+
+import "fmt"
+
+import "io"
+
+import "strconv"
+
+import "strings"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// version is the LiveSplit file format version written by Write
+const version = "1.7.0"
+
+// Run holds the splits of a game and category
+type Run struct {
+	GameName     string
+	CategoryName string
+	// Offset is the time the timer starts at
+	Offset       time.Duration
+	AttemptCount int
+	Attempts     []Attempt
+	Segments     []Segment
+}
+
+// Attempt is a single recorded attempt of the run
+// Time is 0 for attempts which weren't finished
+type Attempt struct {
+	ID      int
+	Started time.Time
+	Ended   time.Time
+	Time    time.Duration
+}
+
+// Segment is a single split of the run
+type Segment struct {
+	Name string
+	// PersonalBest is the split time of the personal best run at the end of the segment. It is 0 if there is none
+	PersonalBest time.Duration
+	// BestSegment is the fastest time the segment itself was ever completed in. It is 0 if there is none
+	BestSegment time.Duration
+	// History holds the segment time of every attempt which completed the segment
+	History []SegmentTime
+}
+
+// SegmentTime is the time a segment took in the attempt with id ID
+type SegmentTime struct {
+	ID   int
+	Time time.Duration
+}
+
+// Read reads a run from a LiveSplit splits file
+func Read(r io.Reader) (*Run, error) {
+	var x xmlRun
+	if err := xml.NewDecoder(r).Decode(&x); err != nil {
+		return nil, fmt.Errorf("Reading splits: %v", err)
+	}
+
+	run := &Run{
+		GameName:     x.GameName,
+		CategoryName: x.CategoryName,
+		AttemptCount: x.AttemptCount,
+	}
+	var err error
+	if run.Offset, err = parseTime(x.Offset); err != nil {
+		return nil, err
+	}
+	for _, a := range x.Attempts {
+		attempt := Attempt{ID: a.ID}
+		if attempt.Started, err = parseDate(a.Started); err != nil {
+			return nil, err
+		}
+		if attempt.Ended, err = parseDate(a.Ended); err != nil {
+			return nil, err
+		}
+		if attempt.Time, err = parseTime(a.RealTime); err != nil {
+			return nil, err
+		}
+		run.Attempts = append(run.Attempts, attempt)
+	}
+	for _, s := range x.Segments {
+		segment := Segment{Name: s.Name}
+		for _, split := range s.SplitTimes {
+			if split.Name != personalBest {
+				continue
+			}
+			if segment.PersonalBest, err = parseTime(split.RealTime); err != nil {
+				return nil, err
+			}
+		}
+		if segment.BestSegment, err = parseTime(s.BestSegment.RealTime); err != nil {
+			return nil, err
+		}
+		for _, h := range s.History {
+			d, err := parseTime(h.RealTime)
+			if err != nil {
+				return nil, err
+			}
+			// attempts which skipped the segment have no time
+			if d > 0 {
+				segment.History = append(segment.History, SegmentTime{ID: h.ID, Time: d})
+			}
+		}
+		run.Segments = append(run.Segments, segment)
+	}
+
+	return run, nil
+}
+
+// Write writes the run as a LiveSplit splits file
+func (run *Run) Write(w io.Writer) error {
+	x := xmlRun{
+		Version:      version,
+		GameName:     run.GameName,
+		CategoryName: run.CategoryName,
+		Offset:       formatTime(run.Offset),
+		AttemptCount: run.AttemptCount,
+		Attempts:     make([]xmlAttempt, 0, len(run.Attempts)),
+		Segments:     make([]xmlSegment, 0, len(run.Segments)),
+	}
+	for _, a := range run.Attempts {
+		x.Attempts = append(x.Attempts, xmlAttempt{
+			ID:       a.ID,
+			Started:  formatDate(a.Started),
+			Ended:    formatDate(a.Ended),
+			RealTime: formatOptional(a.Time),
+		})
+	}
+	for _, s := range run.Segments {
+		segment := xmlSegment{
+			Name:        s.Name,
+			SplitTimes:  []xmlSplitTime{{Name: personalBest, RealTime: formatOptional(s.PersonalBest)}},
+			BestSegment: xmlTime{RealTime: formatOptional(s.BestSegment)},
+		}
+		for _, h := range s.History {
+			segment.History = append(segment.History, xmlTime{ID: h.ID, RealTime: formatTime(h.Time)})
+		}
+		x.Segments = append(x.Segments, segment)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	return enc.Encode(x)
+}
+
+// AddSubTimers adds one subtimer per segment to t, named after the segment
+// the subtimer of segment i has id i+1. Segments sharing a name are numbered to keep the names unique
+func (run *Run) AddSubTimers(t *timer.Timer) error {
+	seen := make(map[string]int)
+	for i, s := range run.Segments {
+		id := i + 1
+		if err := t.AddSubTimer(id); err != nil {
+			return err
+		}
+		name := s.Name
+		if seen[name]++; seen[name] > 1 {
+			name = fmt.Sprintf("%v (%v)", name, seen[name])
+		}
+		if err := t.SetSubTimerName(id, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAttempt records the run described by r as a new attempt
+// the subtimer with id i+1 holds the split time of segment i. Finished segments are added to the segment history,
+// best segments are updated and a finished run faster than the personal best becomes the new personal best
+func (run *Run) AddAttempt(r timer.Report) {
+	run.AttemptCount++
+	id := run.AttemptCount
+	attempt := Attempt{ID: id, Started: r.StartTime}
+	if !r.StartTime.IsZero() {
+		attempt.Ended = r.StartTime.Add(r.WallTime)
+	}
+
+	splits := make([]time.Duration, len(run.Segments))
+	for _, s := range r.Subtimers {
+		if s.ID >= 1 && s.ID <= len(splits) && s.State == timer.Stopped {
+			splits[s.ID-1] = s.Compensated
+		}
+	}
+
+	finished := r.State == timer.Stopped && len(splits) > 0
+	var previous time.Duration
+	for i, split := range splits {
+		if split == 0 {
+			finished = false
+			continue
+		}
+		segment := &run.Segments[i]
+		d := split - previous
+		previous = split
+		segment.History = append(segment.History, SegmentTime{ID: id, Time: d})
+		if segment.BestSegment == 0 || d < segment.BestSegment {
+			segment.BestSegment = d
+		}
+	}
+
+	if finished {
+		attempt.Time = splits[len(splits)-1]
+		pb := run.Segments[len(run.Segments)-1].PersonalBest
+		if pb == 0 || attempt.Time < pb {
+			for i := range run.Segments {
+				run.Segments[i].PersonalBest = splits[i]
+			}
+		}
+	}
+	run.Attempts = append(run.Attempts, attempt)
+}
+
+const (
+	personalBest = "Personal Best"
+	dateLayout   = "01/02/2006 15:04:05"
+)
+
+type xmlRun struct {
+	XMLName      xml.Name     `xml:"Run"`
+	Version      string       `xml:"version,attr"`
+	GameName     string       `xml:"GameName"`
+	CategoryName string       `xml:"CategoryName"`
+	Offset       string       `xml:"Offset"`
+	AttemptCount int          `xml:"AttemptCount"`
+	Attempts     []xmlAttempt `xml:"AttemptHistory>Attempt"`
+	Segments     []xmlSegment `xml:"Segments>Segment"`
+}
+
+type xmlAttempt struct {
+	ID       int    `xml:"id,attr"`
+	Started  string `xml:"started,attr,omitempty"`
+	Ended    string `xml:"ended,attr,omitempty"`
+	RealTime string `xml:"RealTime,omitempty"`
+}
+
+type xmlSegment struct {
+	Name        string         `xml:"Name"`
+	SplitTimes  []xmlSplitTime `xml:"SplitTimes>SplitTime"`
+	BestSegment xmlTime        `xml:"BestSegmentTime"`
+	History     []xmlTime      `xml:"SegmentHistory>Time"`
+}
+
+type xmlSplitTime struct {
+	Name     string `xml:"name,attr"`
+	RealTime string `xml:"RealTime,omitempty"`
+}
+
+type xmlTime struct {
+	ID       int    `xml:"id,attr,omitempty"`
+	RealTime string `xml:"RealTime,omitempty"`
+}
+
+// parseTime parses a LiveSplit time span like 1.02:03:04.5670000 where the days and the fraction are optional
+// an empty string is parsed as 0
+func parseTime(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	var days int64
+	if i := strings.Index(s, "."); i >= 0 && i < strings.Index(s, ":") {
+		var err error
+		if days, err = strconv.ParseInt(s[:i], 10, 64); err != nil {
+			return 0, fmt.Errorf("Invalid time %q", s)
+		}
+		s = s[i+1:]
+	}
+
+	var fraction time.Duration
+	if i := strings.Index(s, "."); i >= 0 {
+		digits := s[i+1:]
+		if len(digits) > 9 {
+			digits = digits[:9]
+		}
+		n, err := strconv.ParseInt(digits+strings.Repeat("0", 9-len(digits)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid time %q", s)
+		}
+		fraction = time.Duration(n)
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("Invalid time %q", s)
+	}
+	d := time.Duration(days) * 24 * time.Hour
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.ParseInt(parts[i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid time %q", s)
+		}
+		d += time.Duration(n) * unit
+	}
+	d += fraction
+	if negative {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// formatTime formats d as a LiveSplit time span with 7 fractional digits
+func formatTime(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	s := fmt.Sprintf("%02d:%02d:%02d.%07d", d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second, d%time.Second/100)
+	if days > 0 {
+		s = fmt.Sprintf("%d.%v", days, s)
+	}
+
+	return sign + s
+}
+
+// formatOptional formats d as a time span and 0 as an empty string
+func formatOptional(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+
+	return formatTime(d)
+}
+
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid date %q", s)
+	}
+
+	return t, nil
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(dateLayout)
+}
@@ -0,0 +1,224 @@
+package lss_test
+
+import "bytes"
+
+import "reflect"
+
+import "strings"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/lss"
+
+import "github.com/onestay/timer-core/timertest"
+
+const splits = `<?xml version="1.0" encoding="UTF-8"?>
+<Run version="1.7.0">
+  <GameName>Super Mario 64</GameName>
+  <CategoryName>16 Star</CategoryName>
+  <Offset>-00:00:01.5000000</Offset>
+  <AttemptCount>2</AttemptCount>
+  <AttemptHistory>
+    <Attempt id="1" started="01/02/2021 15:04:05" ended="01/02/2021 15:20:05">
+      <RealTime>00:16:00.0000000</RealTime>
+    </Attempt>
+    <Attempt id="2" started="01/03/2021 15:04:05" ended="01/03/2021 15:10:05" />
+  </AttemptHistory>
+  <Segments>
+    <Segment>
+      <Name>Bob-omb Battlefield</Name>
+      <SplitTimes>
+        <SplitTime name="Personal Best">
+          <RealTime>00:05:00.1234567</RealTime>
+        </SplitTime>
+      </SplitTimes>
+      <BestSegmentTime>
+        <RealTime>00:04:30.0000000</RealTime>
+      </BestSegmentTime>
+      <SegmentHistory>
+        <Time id="1">
+          <RealTime>00:05:00.1234567</RealTime>
+        </Time>
+        <Time id="2">
+          <RealTime>00:04:30.0000000</RealTime>
+        </Time>
+      </SegmentHistory>
+    </Segment>
+    <Segment>
+      <Name>Bowser</Name>
+      <SplitTimes>
+        <SplitTime name="Personal Best">
+          <RealTime>1.00:16:00.0000000</RealTime>
+        </SplitTime>
+      </SplitTimes>
+      <BestSegmentTime />
+      <SegmentHistory>
+        <Time id="2" />
+      </SegmentHistory>
+    </Segment>
+  </Segments>
+</Run>`
+
+func TestRead(t *testing.T) {
+	run, err := lss.Read(strings.NewReader(splits))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &lss.Run{
+		GameName:     "Super Mario 64",
+		CategoryName: "16 Star",
+		Offset:       -1500 * time.Millisecond,
+		AttemptCount: 2,
+		Attempts: []lss.Attempt{
+			{ID: 1, Started: time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC), Ended: time.Date(2021, 1, 2, 15, 20, 5, 0, time.UTC), Time: 16 * time.Minute},
+			{ID: 2, Started: time.Date(2021, 1, 3, 15, 4, 5, 0, time.UTC), Ended: time.Date(2021, 1, 3, 15, 10, 5, 0, time.UTC)},
+		},
+		Segments: []lss.Segment{
+			{
+				Name:         "Bob-omb Battlefield",
+				PersonalBest: 5*time.Minute + 123456700,
+				BestSegment:  4*time.Minute + 30*time.Second,
+				History:      []lss.SegmentTime{{ID: 1, Time: 5*time.Minute + 123456700}, {ID: 2, Time: 4*time.Minute + 30*time.Second}},
+			},
+			// the attempt which skipped the segment is not part of its history
+			{Name: "Bowser", PersonalBest: 24*time.Hour + 16*time.Minute},
+		},
+	}
+	if !reflect.DeepEqual(run, want) {
+		t.Errorf("read %+v, want %+v", run, want)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"no xml", "splits"},
+		{"invalid time", `<Run><Offset>1:2</Offset></Run>`},
+		{"invalid date", `<Run><AttemptHistory><Attempt id="1" started="yesterday" /></AttemptHistory></Run>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := lss.Read(strings.NewReader(test.data)); err == nil {
+				t.Errorf("reading succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	run, err := lss.Read(strings.NewReader(splits))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := run.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := lss.Read(&buf)
+	if err != nil {
+		t.Fatalf("reading written splits: %v", err)
+	}
+	if !reflect.DeepEqual(got, run) {
+		t.Errorf("read %+v after writing, want %+v", got, run)
+	}
+}
+
+func TestAddSubTimers(t *testing.T) {
+	run := &lss.Run{Segments: []lss.Segment{{Name: "Level"}, {Name: "Boss"}, {Name: "Level"}}}
+	tm := timer.New()
+	defer tm.Close()
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run.AddSubTimers(tm); err != nil {
+		t.Fatal(err)
+	}
+	for id, name := range map[int]string{1: "Level", 2: "Boss", 3: "Level (2)"} {
+		info, err := tm.SubTimer(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Name != name {
+			t.Errorf("subtimer %v is named %q, want %q", id, info.Name, name)
+		}
+	}
+}
+
+func TestAddAttempt(t *testing.T) {
+	tests := []struct {
+		name string
+		// splits are the times at which the subtimers are stopped, 0 leaves the subtimer running
+		splits []time.Duration
+		pb     []time.Duration
+		best   []time.Duration
+	}{
+		{
+			name:   "new personal best",
+			splits: []time.Duration{4 * time.Second, 9 * time.Second},
+			pb:     []time.Duration{4 * time.Second, 9 * time.Second},
+			best:   []time.Duration{4 * time.Second, 5 * time.Second},
+		},
+		{
+			name:   "slower run",
+			splits: []time.Duration{6 * time.Second, 11 * time.Second},
+			pb:     []time.Duration{5 * time.Second, 10 * time.Second},
+			best:   []time.Duration{5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:   "unfinished run",
+			splits: []time.Duration{3 * time.Second, 0},
+			pb:     []time.Duration{5 * time.Second, 10 * time.Second},
+			best:   []time.Duration{3 * time.Second, 6 * time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			run := &lss.Run{Segments: []lss.Segment{
+				{Name: "Level", PersonalBest: 5 * time.Second, BestSegment: 5 * time.Second},
+				{Name: "Boss", PersonalBest: 10 * time.Second, BestSegment: 6 * time.Second},
+			}}
+			clock := timertest.NewClock(time.Now())
+			tm := timertest.New(t, clock)
+			defer tm.Close()
+			if err := run.AddSubTimers(tm); err != nil {
+				t.Fatal(err)
+			}
+
+			steps := []timertest.Step{{Do: timertest.Start}}
+			var last time.Duration
+			for i, split := range test.splits {
+				if split == 0 {
+					continue
+				}
+				steps = append(steps, timertest.Step{After: split - last, Do: timertest.StopSubTimer(i + 1)})
+				last = split
+			}
+			steps = append(steps, timertest.Step{Do: timertest.Stop})
+			timertest.Run(t, tm, clock, steps...)
+
+			run.AddAttempt(tm.Report())
+			if run.AttemptCount != 1 || len(run.Attempts) != 1 {
+				t.Fatalf("run has %v attempts, want 1", len(run.Attempts))
+			}
+			for i, s := range run.Segments {
+				if s.PersonalBest != test.pb[i] {
+					t.Errorf("personal best of segment %v is %v, want %v", i, s.PersonalBest, test.pb[i])
+				}
+				if s.BestSegment != test.best[i] {
+					t.Errorf("best segment of segment %v is %v, want %v", i, s.BestSegment, test.best[i])
+				}
+			}
+		})
+	}
+}
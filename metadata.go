@@ -0,0 +1,82 @@
+package timer
+
+import "time"
+
+// SubtimerInfo holds the metadata and current time of a single subtimer
+type SubtimerInfo struct {
+	ID          int               `json:"id"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	State       State             `json:"state"`
+	Time        time.Duration     `json:"time"`
+}
+
+// SubTimer returns the metadata and current time of the subtimer with id
+func (t *Timer) SubTimer(id int) (SubtimerInfo, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return SubtimerInfo{}, errSubtimerNotFound(id)
+	}
+
+	return SubtimerInfo{
+		ID:          id,
+		Name:        s.name,
+		Description: s.description,
+		Tags:        copyTags(s.tags),
+		State:       s.state,
+		Time:        t.subtimerElapsed(s),
+	}, nil
+}
+
+// SetSubTimerMetadata sets the description and tags of a subtimer
+// tags are copied, setting nil removes all tags
+func (t *Timer) SetSubTimerMetadata(id int, description string, tags map[string]string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	s.description = description
+	s.tags = copyTags(tags)
+
+	return nil
+}
+
+// SetSubTimerTag sets a single tag of a subtimer. Setting an empty value removes the tag
+func (t *Timer) SetSubTimerTag(id int, key, value string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if value == "" {
+		delete(s.tags, key)
+		return nil
+	}
+	if s.tags == nil {
+		s.tags = make(map[string]string)
+	}
+	s.tags[key] = value
+
+	return nil
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	c := make(map[string]string, len(tags))
+	for k, v := range tags {
+		c[k] = v
+	}
+
+	return c
+}
@@ -0,0 +1,72 @@
+package timer_test
+
+import "errors"
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestSubTimerMetadata(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+
+	tags := map[string]string{"country": "SE", "category": "any%"}
+	if err := tm.SetSubTimerMetadata(1, "defending champion", tags); err != nil {
+		t.Fatal(err)
+	}
+	// the tags are copied
+	tags["country"] = "NO"
+	if err := tm.SetSubTimerTag(1, "category", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetSubTimerTag(1, "pronouns", "they/them"); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second, Do: timertest.StopSubTimer(1)},
+	)
+
+	info, err := tm.SubTimer(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := timer.SubtimerInfo{
+		ID:          1,
+		Description: "defending champion",
+		Tags:        map[string]string{"country": "SE", "pronouns": "they/them"},
+		State:       timer.Stopped,
+		Time:        2 * time.Second,
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("subtimer is %+v, want %+v", info, want)
+	}
+	info.Tags["country"] = "DK"
+	if info, _ := tm.SubTimer(1); info.Tags["country"] != "SE" {
+		t.Errorf("modifying the returned tags changed the subtimer")
+	}
+
+	if err := tm.SetSubTimerMetadata(1, "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := tm.SubTimer(1); info.Description != "" || info.Tags != nil {
+		t.Errorf("subtimer has description %q and tags %v after clearing them", info.Description, info.Tags)
+	}
+
+	for name, err := range map[string]error{
+		"SubTimer":            func() error { _, err := tm.SubTimer(2); return err }(),
+		"SetSubTimerMetadata": tm.SetSubTimerMetadata(2, "", nil),
+		"SetSubTimerTag":      tm.SetSubTimerTag(2, "key", "value"),
+	} {
+		if !errors.Is(err, timer.ErrSubtimerNotFound) {
+			t.Errorf("%v of an unknown subtimer returned %v, want %v", name, err, timer.ErrSubtimerNotFound)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package timer
+
+import "fmt"
+
+// AddNamedSubTimer adds a subtimer identified by a human readable name and returns the id assigned to it
+// the id is one higher than the highest id in use. name has to be unique
+func (t *Timer) AddNamedSubTimer(name string) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if name == "" {
+		return 0, fmt.Errorf("Subtimer name must not be empty")
+	}
+	if _, ok := t.subtimerByName(name); ok {
+		return 0, &SubtimerError{Name: name, Err: ErrSubtimerExists}
+	}
+
+	id := 1
+	for existing := range t.subtimers {
+		if existing >= id {
+			id = existing + 1
+		}
+	}
+	s, err := t.addSubTimerLocked(id, 0)
+	if err != nil {
+		return 0, err
+	}
+	s.name = name
+
+	return id, nil
+}
+
+// SetSubTimerName sets the human readable name of an existing subtimer
+// Setting an empty name removes it
+func (t *Timer) SetSubTimerName(id int, name string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if other, ok := t.subtimerByName(name); ok && other != id && name != "" {
+		return &SubtimerError{ID: other, Name: name, Err: ErrSubtimerExists}
+	}
+	s.name = name
+
+	return nil
+}
+
+// SubTimerID returns the id of the subtimer with name
+func (t *Timer) SubTimerID(name string) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id, ok := t.subtimerByName(name)
+	if !ok || name == "" {
+		return 0, &SubtimerError{Name: name, Err: ErrSubtimerNotFound}
+	}
+
+	return id, nil
+}
+
+// SubTimerNames returns the names of all named subtimers ordered by id
+func (t *Timer) SubTimerNames() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var names []string
+	for _, id := range t.subtimerIDs() {
+		if name := t.subtimers[id].name; name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// subtimerByName returns the id of the subtimer with name
+func (t *Timer) subtimerByName(name string) (int, bool) {
+	for id, s := range t.subtimers {
+		if s.name == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,69 @@
+package timer_test
+
+import "errors"
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestNamedSubtimers(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(3))
+	defer tm.Close()
+
+	alice, err := tm.AddNamedSubTimer("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := tm.AddNamedSubTimer("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alice != 4 || bob != 5 {
+		t.Errorf("got ids %v and %v, want 4 and 5", alice, bob)
+	}
+	if _, err := tm.AddNamedSubTimer("alice"); !errors.Is(err, timer.ErrSubtimerExists) {
+		t.Errorf("adding a duplicate name returned %v, want %v", err, timer.ErrSubtimerExists)
+	}
+	if _, err := tm.AddNamedSubTimer(""); err == nil {
+		t.Errorf("adding an empty name succeeded, want an error")
+	}
+	if err := tm.SetSubTimerName(3, "bob"); !errors.Is(err, timer.ErrSubtimerExists) {
+		t.Errorf("renaming to a taken name returned %v, want %v", err, timer.ErrSubtimerExists)
+	}
+	if err := tm.SetSubTimerName(3, "carol"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetSubTimerName(5, ""); err != nil {
+		t.Fatal(err)
+	}
+	if names := tm.SubTimerNames(); !reflect.DeepEqual(names, []string{"carol", "alice"}) {
+		t.Errorf("names are %v, want carol and alice", names)
+	}
+
+	tests := []struct {
+		name string
+		id   int
+		err  error
+	}{
+		{"alice", 4, nil},
+		{"carol", 3, nil},
+		{"bob", 0, timer.ErrSubtimerNotFound},
+		{"", 0, timer.ErrSubtimerNotFound},
+	}
+	for _, test := range tests {
+		id, err := tm.SubTimerID(test.name)
+		if id != test.id || !errors.Is(err, test.err) {
+			t.Errorf("id of %q is %v, %v, want %v, %v", test.name, id, err, test.id, test.err)
+		}
+	}
+	if err := tm.SetSubTimerName(9, "dave"); !errors.Is(err, timer.ErrSubtimerNotFound) {
+		t.Errorf("naming an unknown subtimer returned %v, want %v", err, timer.ErrSubtimerNotFound)
+	}
+}
@@ -0,0 +1,119 @@
+package timer
+
+import "sort"
+
+import "time"
+
+// threshold is a pending notification of NotifyAt
+type threshold struct {
+	at   time.Duration
+	fire func()
+}
+
+// NotifyAt returns a channel which is closed once the elapsed time reaches d
+// it fires exactly at d even between ticks and immediately if d has already been reached.
+// Thresholds not reached in a run stay pending for the next run. The channel is never closed if the timer is closed first
+func (t *Timer) NotifyAt(d time.Duration) <-chan struct{} {
+	c := make(chan struct{})
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.addThreshold(d, func() {
+		close(c)
+	})
+
+	return c
+}
+
+// NotifyAtEach returns a channel receiving each of ds once the elapsed time reaches it, like NotifyAt
+// the channel is closed after all thresholds have been reached
+func (t *Timer) NotifyAtEach(ds ...time.Duration) <-chan time.Duration {
+	c := make(chan time.Duration, len(ds))
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(ds) == 0 {
+		close(c)
+		return c
+	}
+	pending := len(ds)
+	for _, d := range ds {
+		d := d
+		t.addThreshold(d, func() {
+			c <- d
+			pending--
+			if pending == 0 {
+				close(c)
+			}
+		})
+	}
+
+	return c
+}
+
+func (t *Timer) addThreshold(d time.Duration, fire func()) {
+	i := sort.Search(len(t.thresholds), func(i int) bool {
+		return t.thresholds[i].at > d
+	})
+	t.thresholds = append(t.thresholds, threshold{})
+	copy(t.thresholds[i+1:], t.thresholds[i:])
+	t.thresholds[i] = threshold{at: d, fire: fire}
+
+	t.fireThresholds(t.currentElapsed())
+	t.armThresholds()
+}
+
+// fireThresholds fires all pending thresholds reached by elapsed
+func (t *Timer) fireThresholds(elapsed time.Duration) {
+	n := 0
+	for n < len(t.thresholds) && t.thresholds[n].at <= elapsed {
+		t.thresholds[n].fire()
+		n++
+	}
+	t.thresholds = t.thresholds[n:]
+}
+
+// armThresholds schedules the alarm firing the next threshold of a running timer
+func (t *Timer) armThresholds() {
+	t.disarmThresholds()
+	if len(t.thresholds) == 0 || t.state != Running {
+		return
+	}
+
+	delay := t.thresholds[0].at - t.currentElapsed()
+	if delay < 0 {
+		delay = 0
+	}
+	t.thresholdAlarm = t.clock.AfterFunc(delay, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		if t.state != Running {
+			return
+		}
+		t.fireThresholds(t.currentElapsed())
+		t.armThresholds()
+	})
+}
+
+func (t *Timer) disarmThresholds() {
+	if t.thresholdAlarm == nil {
+		return
+	}
+	t.thresholdAlarm.Stop()
+	t.thresholdAlarm = nil
+}
+
+// armAlarms schedules all alarms depending on the elapsed time
+// it has to be called whenever the timer starts running or its elapsed time is shifted
+func (t *Timer) armAlarms() {
+	t.armTarget()
+	t.armCountdown()
+	t.armThresholds()
+}
+
+func (t *Timer) disarmAlarms() {
+	t.disarmTarget()
+	t.disarmCountdown()
+	t.disarmThresholds()
+}
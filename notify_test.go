@@ -0,0 +1,81 @@
+package timer_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestNotifyAt(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	fired := func(c <-chan struct{}, within time.Duration) bool {
+		select {
+		case <-c:
+			return true
+		case <-time.After(within):
+			return false
+		}
+	}
+	at := tm.NotifyAt(2 * time.Second)
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.Pause},
+		timertest.Step{After: 5 * time.Second},
+	)
+	if fired(at, 10*time.Millisecond) {
+		t.Fatalf("fired before the threshold was reached")
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Resume}, timertest.Step{After: time.Second})
+	if !fired(at, time.Second) {
+		t.Fatalf("didn't fire at the threshold")
+	}
+	if !fired(tm.NotifyAt(time.Second), time.Second) {
+		t.Errorf("a threshold which has already been reached didn't fire right away")
+	}
+
+	// thresholds not reached stay pending for the next run
+	later := tm.NotifyAt(3 * time.Second)
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+	prepare(t, tm)
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start}, timertest.Step{After: 2 * time.Second})
+	if fired(later, 10*time.Millisecond) {
+		t.Fatalf("fired before the threshold was reached in the next run")
+	}
+	clock.Advance(time.Second)
+	if !fired(later, time.Second) {
+		t.Errorf("didn't fire in the next run")
+	}
+}
+
+func TestNotifyAtEach(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	if _, ok := <-tm.NotifyAtEach(); ok {
+		t.Errorf("received a threshold without any, want the channel closed")
+	}
+	c := tm.NotifyAtEach(3*time.Second, time.Second, 2*time.Second)
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	var got []time.Duration
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		select {
+		case d := <-c:
+			got = append(got, d)
+		case <-time.After(time.Second):
+			t.Fatalf("no threshold after %v", time.Duration(i+1)*time.Second)
+		}
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}; !reflect.DeepEqual(got, want) {
+		t.Errorf("received thresholds %v, want %v", got, want)
+	}
+	if _, ok := <-c; ok {
+		t.Errorf("received more thresholds, want the channel closed")
+	}
+}
@@ -0,0 +1,37 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// SetSubTimerOffset sets the known stream delay of the runner of a subtimer
+// compensated times subtract the delay from the official time and are used when comparing subtimers against each other.
+// Official times are never changed by the offset
+func (t *Timer) SetSubTimerOffset(id int, delay time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if delay < 0 {
+		return fmt.Errorf("Only positive values for delay are allowed")
+	}
+	s.offset = delay
+
+	return nil
+}
+
+// compensate returns d corrected by the stream delay of s
+// adjudicated times are official and never corrected
+func (s *subtimer) compensate(d time.Duration) time.Duration {
+	if s.adjudicated {
+		return d
+	}
+	if d <= s.offset {
+		return 0
+	}
+
+	return d - s.offset
+}
@@ -0,0 +1,55 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestSubTimerOffset(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2, 3))
+	defer tm.Close()
+
+	if err := tm.SetSubTimerOffset(2, -time.Second); err == nil {
+		t.Errorf("setting a negative offset succeeded, want an error")
+	}
+	if err := tm.SetSubTimerOffset(4, time.Second); err == nil {
+		t.Errorf("setting the offset of an unknown subtimer succeeded, want an error")
+	}
+	// the stream of runner 2 lags 2s behind, runner 3's offset exceeds their time
+	if err := tm.SetSubTimerOffset(2, 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetSubTimerOffset(3, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 5 * time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(2)},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(3)},
+	)
+
+	want := []struct {
+		id                int
+		time, compensated time.Duration
+	}{
+		{3, 7 * time.Second, 0},
+		{2, 6 * time.Second, 4 * time.Second},
+		{1, 5 * time.Second, 5 * time.Second},
+	}
+	entries := tm.RaceResult().Entries
+	if len(entries) != len(want) {
+		t.Fatalf("result has %v entries, want %v", len(entries), len(want))
+	}
+	for i, e := range entries {
+		w := want[i]
+		if e.ID != w.id || e.Rank != i+1 || e.Time != w.time || e.Compensated != w.compensated {
+			t.Errorf("entry %v is subtimer %v ranked %v at %v (%v compensated), want subtimer %v ranked %v at %v (%v compensated)",
+				i, e.ID, e.Rank, e.Time, e.Compensated, w.id, i+1, w.time, w.compensated)
+		}
+	}
+}
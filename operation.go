@@ -0,0 +1,133 @@
+package timer
+
+import "time"
+
+// Operation is a typed operation which can be applied to a timer with ApplyOp
+// it allows driving a timer with generated operation sequences, e.g. in property based or fuzz tests
+type Operation interface {
+	apply(t *Timer) error
+}
+
+// StartOp starts the timer
+type StartOp struct{}
+
+// PauseOp pauses the timer
+type PauseOp struct{}
+
+// PauseForOp pauses the timer and resumes it after D
+type PauseForOp struct{ D time.Duration }
+
+// ResumeOp resumes the timer
+type ResumeOp struct{}
+
+// StopOp stops the timer
+type StopOp struct{}
+
+// ResetOp resets the timer
+type ResetOp struct{}
+
+// LapOp finishes the current lap of the main timer
+type LapOp struct{}
+
+// AddSubOp adds the subtimer ID
+type AddSubOp struct{ ID int }
+
+// StopSubOp stops the subtimer ID
+type StopSubOp struct{ ID int }
+
+// SplitOp records the next shared split of the subtimer ID
+type SplitOp struct{ ID int }
+
+// PauseSubOp pauses the subtimer ID
+type PauseSubOp struct{ ID int }
+
+// ResumeSubOp resumes the subtimer ID
+type ResumeSubOp struct{ ID int }
+
+// HandoffOp hands the relay subtimer ID over to Member
+type HandoffOp struct {
+	ID     int
+	Member string
+}
+
+// StopAllSubsOp stops all running subtimers
+type StopAllSubsOp struct{}
+
+// ForfeitAllSubsOp forfeits all running subtimers
+type ForfeitAllSubsOp struct{}
+
+// ResetAllSubsOp resets all subtimers
+type ResetAllSubsOp struct{}
+
+// AdjustOp records Adjustment in the ledger
+type AdjustOp struct{ Adjustment Adjustment }
+
+// AdjustElapsedOp shifts the elapsed time of the timer by D
+type AdjustElapsedOp struct{ D time.Duration }
+
+// SetElapsedOp sets the elapsed time of the timer to D
+type SetElapsedOp struct{ D time.Duration }
+
+// AdjudicateOp sets the official time of the subtimer ID
+type AdjudicateOp struct {
+	ID   int
+	Time time.Duration
+	Note string
+}
+
+// ApplyOp applies op to the timer and returns the error of the underlying method
+func (t *Timer) ApplyOp(op Operation) error {
+	return op.apply(t)
+}
+
+func (StartOp) apply(t *Timer) error  { return t.StartTimer() }
+func (PauseOp) apply(t *Timer) error  { return t.PauseTimer() }
+func (ResumeOp) apply(t *Timer) error { return t.ResumeTimer() }
+func (StopOp) apply(t *Timer) error   { return t.StopTimer() }
+func (ResetOp) apply(t *Timer) error  { return t.ResetTimer() }
+
+func (o PauseForOp) apply(t *Timer) error  { return t.PauseFor(o.D) }
+func (o AddSubOp) apply(t *Timer) error    { return t.AddSubTimer(o.ID) }
+func (o PauseSubOp) apply(t *Timer) error  { return t.PauseSubTimer(o.ID) }
+func (o ResumeSubOp) apply(t *Timer) error { return t.ResumeSubTimer(o.ID) }
+func (o HandoffOp) apply(t *Timer) error   { return t.Handoff(o.ID, o.Member) }
+
+func (o AdjustElapsedOp) apply(t *Timer) error { return t.Adjust(o.D) }
+func (o SetElapsedOp) apply(t *Timer) error    { return t.SetElapsed(o.D) }
+
+func (o StopSubOp) apply(t *Timer) error {
+	_, err := t.StopSubTimer(o.ID)
+	return err
+}
+
+func (LapOp) apply(t *Timer) error {
+	_, err := t.Lap()
+	return err
+}
+
+func (o SplitOp) apply(t *Timer) error {
+	_, err := t.SplitSubTimer(o.ID)
+	return err
+}
+
+func (StopAllSubsOp) apply(t *Timer) error {
+	_, err := t.StopAllSubTimers()
+	return err
+}
+
+func (ForfeitAllSubsOp) apply(t *Timer) error {
+	_, err := t.ForfeitAllRunning()
+	return err
+}
+
+func (ResetAllSubsOp) apply(t *Timer) error {
+	return t.ResetAllSubTimers()
+}
+
+func (o AdjudicateOp) apply(t *Timer) error {
+	return t.Adjudicate(o.ID, o.Time, o.Note)
+}
+
+func (o AdjustOp) apply(t *Timer) error {
+	return t.AddAdjustment(o.Adjustment)
+}
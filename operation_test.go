@@ -0,0 +1,79 @@
+package timer_test
+
+import "math/rand"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// randomOp returns a random operation on the timer or one of the subtimers 1 to 3
+func randomOp(r *rand.Rand) timer.Operation {
+	id := r.Intn(3) + 1
+	ops := []timer.Operation{
+		timer.StartOp{},
+		timer.PauseOp{},
+		timer.PauseForOp{D: time.Duration(r.Intn(3)) * time.Second},
+		timer.ResumeOp{},
+		timer.StopOp{},
+		timer.ResetOp{},
+		timer.LapOp{},
+		timer.AddSubOp{ID: id},
+		timer.StopSubOp{ID: id},
+		timer.SplitOp{ID: id},
+		timer.PauseSubOp{ID: id},
+		timer.ResumeSubOp{ID: id},
+		timer.HandoffOp{ID: id, Member: "runner"},
+		timer.StopAllSubsOp{},
+		timer.ForfeitAllSubsOp{},
+		timer.ResetAllSubsOp{},
+		timer.AdjustElapsedOp{D: time.Duration(r.Intn(5)-2) * time.Second},
+		timer.SetElapsedOp{D: time.Duration(r.Intn(5)) * time.Second},
+		timer.AdjudicateOp{ID: id, Time: time.Duration(r.Intn(5)) * time.Second},
+	}
+
+	return ops[r.Intn(len(ops))]
+}
+
+func TestApplyOpSequences(t *testing.T) {
+	// states holds the state a successful operation leaves the timer in
+	states := map[timer.Operation]timer.State{
+		timer.StartOp{}:  timer.Running,
+		timer.PauseOp{}:  timer.Paused,
+		timer.ResumeOp{}: timer.Running,
+		timer.StopOp{}:   timer.Stopped,
+		timer.ResetOp{}:  timer.Reset,
+	}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		s, err := timer.NewSimulation(time.Now(), 100*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Timer.SetSegments("Level", "Boss"); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 200; i++ {
+			op := randomOp(r)
+			s.At(time.Duration(i)*time.Second, func(tm *timer.Timer) error {
+				before := tm.State()
+				err := tm.ApplyOp(op)
+				after := tm.State()
+				if want, ok := states[op]; ok && err == nil && after != want {
+					t.Errorf("seed %v: %T left the timer %v, want %v", seed, op, after, want)
+				}
+				if err != nil && after != before {
+					t.Errorf("seed %v: failed %T changed the state from %v to %v: %v", seed, op, before, after, err)
+				}
+				return nil
+			})
+		}
+		if _, err := s.Run(200 * time.Second); err != nil {
+			t.Fatal(err)
+		}
+		s.Timer.Close()
+	}
+}
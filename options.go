@@ -0,0 +1,96 @@
+package timer
+
+import "time"
+
+// Option configures a timer at creation time
+type Option func(t *Timer)
+
+// WithUpdateInterval sets the update interval in milliseconds
+// Setting 0 or a negative value keeps the default
+func WithUpdateInterval(interval int) Option {
+	return WithUpdateIntervalDuration(time.Duration(interval) * time.Millisecond)
+}
+
+// WithUpdateIntervalDuration sets the update interval, it may be below one millisecond
+// Setting 0 or a negative value keeps the default
+func WithUpdateIntervalDuration(interval time.Duration) Option {
+	return func(t *Timer) {
+		if interval > 0 {
+			t.updateInterval = interval
+		}
+	}
+}
+
+// WithTickerInterval sets the interval of the internal ticker in milliseconds
+// Setting 0 or a negative value keeps the default
+func WithTickerInterval(interval int) Option {
+	return WithTickerIntervalDuration(time.Duration(interval) * time.Millisecond)
+}
+
+// WithTickerIntervalDuration sets the interval of the internal ticker, it may be below one millisecond
+// Setting 0 or a negative value keeps the default
+func WithTickerIntervalDuration(interval time.Duration) Option {
+	return func(t *Timer) {
+		if interval > 0 {
+			t.tickerInterval = interval
+		}
+	}
+}
+
+// WithResumeAfterStop allows resuming the timer after it has been stopped
+// if continueCounting is set the time between stopping and resuming is counted as well
+func WithResumeAfterStop(continueCounting bool) Option {
+	return func(t *Timer) {
+		t.allowResumeAfterStop = true
+		t.continueCountingWhenStopped = continueCounting
+	}
+}
+
+// WithStopOnSubtimersStop stops the timer once all subtimers are stopped
+func WithStopOnSubtimersStop() Option {
+	return func(t *Timer) {
+		t.stopOnSubtimersStop = true
+	}
+}
+
+// WithSubtimers adds subtimers with ids to every run
+// they are created every time the timer is reset. Duplicate ids are ignored
+func WithSubtimers(ids ...int) Option {
+	return func(t *Timer) {
+		for _, id := range ids {
+			if !containsInt(t.defaultSubtimers, id) {
+				t.defaultSubtimers = append(t.defaultSubtimers, id)
+			}
+		}
+	}
+}
+
+// WithPausePolicy sets which clocks freeze when the timer is paused
+func WithPausePolicy(p PausePolicy) Option {
+	return func(t *Timer) {
+		if p.valid() {
+			t.pausePolicy = p
+		}
+	}
+}
+
+// WithClock sets the clock used by the timer, e.g. a fake clock from the timertest package
+// Setting nil keeps the real clock
+func WithClock(c Clock) Option {
+	return func(t *Timer) {
+		if c != nil {
+			t.clock = c
+			t.epoch = c.Now()
+		}
+	}
+}
+
+func containsInt(s []int, v int) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
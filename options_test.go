@@ -0,0 +1,82 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestOptions(t *testing.T) {
+	defaults := timer.New().Report().Config
+	tests := []struct {
+		name string
+		opts []timer.Option
+		want func(c *timer.ReportConfig)
+	}{
+		{"none", nil, func(c *timer.ReportConfig) {}},
+		{"intervals", []timer.Option{timer.WithUpdateInterval(50), timer.WithTickerIntervalDuration(500 * time.Microsecond)}, func(c *timer.ReportConfig) {
+			c.UpdateInterval, c.UpdateIntervalDuration = 50, 50*time.Millisecond
+			c.TickerInterval, c.TickerIntervalDuration = 0, 500*time.Microsecond
+		}},
+		{"invalid intervals keep the defaults", []timer.Option{timer.WithUpdateInterval(-1), timer.WithTickerIntervalDuration(0)}, func(c *timer.ReportConfig) {}},
+		{"resume after stop", []timer.Option{timer.WithResumeAfterStop(true)}, func(c *timer.ReportConfig) {
+			c.AllowResumeAfterStop, c.ContinueCountingWhenStopped = true, true
+		}},
+		{"stop on subtimers stop", []timer.Option{timer.WithStopOnSubtimersStop()}, func(c *timer.ReportConfig) {
+			c.StopOnSubtimersStop = true
+		}},
+		{"pause policy", []timer.Option{timer.WithPausePolicy(timer.PauseMainOnly)}, func(c *timer.ReportConfig) {
+			c.PausePolicy = timer.PauseMainOnly
+		}},
+		{"invalid pause policy", []timer.Option{timer.WithPausePolicy(timer.PausePolicy(42))}, func(c *timer.ReportConfig) {}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := timer.New(test.opts...)
+			defer tm.Close()
+
+			want := defaults
+			test.want(&want)
+			if got := tm.Report().Config; got != want {
+				t.Errorf("config is %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithSubtimers(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(2, 1), timer.WithSubtimers(1, 3))
+	defer tm.Close()
+
+	ids := func() []int {
+		var ids []int
+		for _, s := range tm.Report().Subtimers {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+	if got := ids(); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("subtimers are %v, want 1, 2 and 3", got)
+	}
+
+	// only they are recreated on reset
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: func(tm *timer.Timer) error { return tm.AddSubTimer(4) }},
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(2)},
+		timertest.Step{Do: timertest.Stop},
+	)
+	prepare(t, tm)
+	if got := ids(); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("subtimers after reset are %v, want 1, 2 and 3", got)
+	}
+	for _, s := range tm.Report().Subtimers {
+		if s.State != timer.Reset || s.Time != 0 {
+			t.Errorf("subtimer %v is %v at %v after reset, want a fresh subtimer", s.ID, s.State, s.Time)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// PauseSubTimer pauses a single subtimer while the timer keeps running
+// only works when subtimer and timer are running
+func (t *Timer) PauseSubTimer(id int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	t.refreshElapsed()
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if t.state != Running || s.state != Running {
+		return t.subtimerStateError("PauseSubTimer", s)
+	}
+
+	s.state = Paused
+	s.pausedAt = t.elapsed
+	t.emit(Event{Type: EventSubtimerPaused, Elapsed: t.elapsed, Subtimer: intPtr(id)})
+
+	return nil
+}
+
+// ResumeSubTimer resumes a paused subtimer
+func (t *Timer) ResumeSubTimer(id int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	t.refreshElapsed()
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if s.state != Paused {
+		return &StateError{Op: "ResumeSubTimer", Current: s.state}
+	}
+
+	s.endPause(t.elapsed)
+	s.state = Running
+	t.emit(Event{Type: EventSubtimerResumed, Elapsed: t.elapsed, Subtimer: intPtr(id)})
+
+	return nil
+}
+
+// SetSubTimerPauseBudget sets the maximum time a subtimer may be paused in total
+// exceeding the budget emits an EventPauseBudgetExceeded event and forfeits the subtimer if forfeit is set. Setting 0 removes the budget
+func (t *Timer) SetSubTimerPauseBudget(id int, budget time.Duration, forfeit bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if budget < 0 {
+		return fmt.Errorf("Only positive values for budget are allowed")
+	}
+	s.pauseBudget = budget
+	s.forfeitOnBudget = forfeit
+
+	return nil
+}
+
+// pausedFor returns the total time s has been paused until elapsed
+func (s *subtimer) pausedFor(elapsed time.Duration) time.Duration {
+	if s.state == Paused {
+		return s.paused + elapsed - s.pausedAt
+	}
+
+	return s.paused
+}
+
+// endPause finishes the ongoing pause of s at elapsed
+func (s *subtimer) endPause(elapsed time.Duration) {
+	s.paused += elapsed - s.pausedAt
+	s.pausedAt = 0
+}
+
+// checkPauseBudgets emits a violation for every paused subtimer which exceeded its pause budget
+func (t *Timer) checkPauseBudgets() {
+	for _, id := range t.subtimerIDs() {
+		s := t.subtimers[id]
+		if s.state != Paused || s.pauseBudget == 0 || s.budgetExceeded {
+			continue
+		}
+		paused := s.pausedFor(t.elapsed)
+		if paused <= s.pauseBudget {
+			continue
+		}
+
+		s.budgetExceeded = true
+		t.emit(Event{Type: EventPauseBudgetExceeded, Severity: SeverityWarning, Elapsed: t.elapsed, Subtimer: intPtr(id)})
+		if s.forfeitOnBudget {
+			t.forfeit(id, s)
+		}
+	}
+}
+
+// forfeit marks s as forfeited and applies the completion policy
+func (t *Timer) forfeit(id int, s *subtimer) {
+	t.forfeitSubTimer(s)
+	t.emit(Event{Type: EventSubtimerForfeited, Elapsed: t.elapsed, Subtimer: intPtr(id)})
+
+	if t.stopOnSubtimersStop && t.checkSubTimerFinish() {
+		if t.stopTimerLocked() == nil {
+			t.autoStopped = true
+		}
+	}
+}
+
+// forfeitSubTimer freezes the time of s and marks it as forfeited
+func (t *Timer) forfeitSubTimer(s *subtimer) {
+	s.Time = t.subtimerElapsed(s)
+	if s.state == Paused {
+		s.endPause(t.elapsed)
+	}
+	s.state = Forfeited
+	s.finishLeg(s.Time)
+}
@@ -0,0 +1,103 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+func TestPauseSubTimer(t *testing.T) {
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Timer.Close()
+	for _, id := range []int{1, 2} {
+		if err := s.Timer.AddSubTimer(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.At(0, (*timer.Timer).StartTimer)
+	s.At(time.Second, func(tm *timer.Timer) error { return tm.PauseSubTimer(1) })
+	s.At(3*time.Second, func(tm *timer.Timer) error { return tm.ResumeSubTimer(1) })
+	s.At(5*time.Second, func(tm *timer.Timer) error {
+		_, err := tm.StopSubTimer(1)
+		return err
+	})
+	if _, err := s.Run(5 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	r := s.Timer.Report()
+	if sub := r.Subtimers[0]; sub.Time != 3*time.Second || sub.Paused != 2*time.Second {
+		t.Errorf("subtimer 1 finished at %v after pausing %v, want 3s after pausing 2s", sub.Time, sub.Paused)
+	}
+	var stateErr *timer.StateError
+	if err := s.Timer.ResumeSubTimer(2); !errors.As(err, &stateErr) {
+		t.Errorf("resuming a running subtimer returned %v, want a state error", err)
+	}
+	if err := s.Timer.PauseSubTimer(1); !errors.As(err, &stateErr) {
+		t.Errorf("pausing a stopped subtimer returned %v, want a state error", err)
+	}
+}
+
+func TestSubTimerPauseBudget(t *testing.T) {
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Timer.Close()
+	for _, id := range []int{1, 2, 3} {
+		if err := s.Timer.AddSubTimer(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Timer.SetSubTimerPauseBudget(1, -time.Second, false); err == nil {
+		t.Errorf("setting a negative budget succeeded, want an error")
+	}
+	// subtimer 1 exceeds its budget and is forfeited, 2 only exceeds it and 3 stays within
+	budgets := map[int]bool{1: true, 2: false, 3: false}
+	for id, forfeit := range budgets {
+		if err := s.Timer.SetSubTimerPauseBudget(id, 2*time.Second, forfeit); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.At(0, (*timer.Timer).StartTimer)
+	for id := range budgets {
+		id := id
+		s.At(time.Second, func(tm *timer.Timer) error { return tm.PauseSubTimer(id) })
+	}
+	s.At(3*time.Second, func(tm *timer.Timer) error { return tm.ResumeSubTimer(3) })
+	events, err := s.Run(6 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exceeded := make(map[int]time.Duration)
+	for _, e := range events {
+		if e.Type == timer.EventPauseBudgetExceeded {
+			if _, ok := exceeded[*e.Subtimer]; ok {
+				t.Errorf("budget of subtimer %v exceeded twice", *e.Subtimer)
+			}
+			exceeded[*e.Subtimer] = e.Elapsed
+		}
+	}
+	if len(exceeded) != 2 || exceeded[1] != 4*time.Second || exceeded[2] != 4*time.Second {
+		t.Errorf("budgets exceeded at %v, want subtimers 1 and 2 at 4s", exceeded)
+	}
+
+	states := map[int]timer.State{1: timer.Forfeited, 2: timer.Paused, 3: timer.Running}
+	for id, want := range states {
+		info, err := s.Timer.SubTimer(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.State != want {
+			t.Errorf("subtimer %v is %v, want %v", id, info.State, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// PausedUpdates describes what the update stream does while the timer is paused
+type PausedUpdates int
+
+const (
+	// PausedSilent sends no updates while paused
+	PausedSilent PausedUpdates = iota
+	// PausedRepeat keeps sending the frozen elapsed time at a reduced rate
+	PausedRepeat
+	// PausedMarker sends the frozen elapsed time once after pausing
+	PausedMarker
+)
+
+const defaultPausedUpdateRate = time.Second
+
+// SetPausedUpdates sets what the update stream does while the timer is paused
+// updates sent while paused are tick events with Frozen set. rate is only used by PausedRepeat, setting 0 sets it back to the default of 1s
+func (t *Timer) SetPausedUpdates(mode PausedUpdates, rate time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if mode != PausedSilent && mode != PausedRepeat && mode != PausedMarker {
+		return fmt.Errorf("Unknown paused update mode %v", mode)
+	}
+	if rate < 0 {
+		return fmt.Errorf("Only positive values for rate are allowed")
+	}
+	if rate == 0 {
+		rate = defaultPausedUpdateRate
+	}
+	t.pausedUpdates = mode
+	t.pausedUpdateRate = rate
+	if t.state == Paused {
+		if mode == PausedSilent {
+			t.stopLoop()
+		} else {
+			t.startLoop()
+		}
+	}
+
+	return nil
+}
+
+// advance moves the timer to now, ticking it if running
+// it returns the value to send on the updates channel and whether it should be sent
+func (t *Timer) advance(now time.Time) (time.Duration, bool) {
+	switch t.state {
+	case Running:
+		return t.tick(now)
+	case Paused:
+		return t.pausedTick(now)
+	default:
+		return 0, false
+	}
+}
+
+// pausedTick emits the frozen elapsed time if due according to the paused update mode
+func (t *Timer) pausedTick(now time.Time) (time.Duration, bool) {
+	switch t.pausedUpdates {
+	case PausedRepeat:
+		if !t.lastFrozen.IsZero() && now.Sub(t.lastFrozen) < t.pausedUpdateRate {
+			return 0, false
+		}
+	case PausedMarker:
+		if !t.lastFrozen.IsZero() {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	t.lastFrozen = now
+	t.emit(Event{Type: EventTick, Time: now, Elapsed: t.elapsed, Remaining: t.remaining(), Frozen: true})
+
+	return t.update(), !t.manual
+}
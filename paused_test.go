@@ -0,0 +1,66 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+func TestPausedUpdates(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   timer.PausedUpdates
+		rate   time.Duration
+		frozen int
+	}{
+		{"silent", timer.PausedSilent, 0, 0},
+		{"repeat", timer.PausedRepeat, 2 * time.Second, 3},
+		{"repeat at the default rate", timer.PausedRepeat, 0, 6},
+		{"marker", timer.PausedMarker, 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := timer.NewSimulation(time.Now(), 500*time.Millisecond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Timer.SetPausedUpdates(test.mode, test.rate); err != nil {
+				t.Fatal(err)
+			}
+			s.At(0, (*timer.Timer).StartTimer)
+			s.At(time.Second, (*timer.Timer).PauseTimer)
+			events, err := s.Run(6 * time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			frozen := 0
+			for _, e := range events {
+				if e.Type != timer.EventTick || !e.Frozen {
+					continue
+				}
+				frozen++
+				if e.Elapsed != time.Second {
+					t.Errorf("frozen update has elapsed %v, want 1s", e.Elapsed)
+				}
+			}
+			if frozen != test.frozen {
+				t.Errorf("got %v frozen updates, want %v", frozen, test.frozen)
+			}
+		})
+	}
+}
+
+func TestSetPausedUpdatesInvalid(t *testing.T) {
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Timer.SetPausedUpdates(timer.PausedUpdates(42), 0); err == nil {
+		t.Errorf("setting an unknown mode succeeded, want an error")
+	}
+	if err := s.Timer.SetPausedUpdates(timer.PausedRepeat, -time.Second); err == nil {
+		t.Errorf("setting a negative rate succeeded, want an error")
+	}
+}
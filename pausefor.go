@@ -0,0 +1,68 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// PauseFor pauses the timer and automatically resumes it after d
+// only possible when in Running state. The automatic resume can be canceled with CancelAutoResume
+func (t *Timer) PauseFor(d time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("PauseFor", t.pauseForLocked(d))
+}
+
+func (t *Timer) pauseForLocked(d time.Duration) error {
+	if t.closed {
+		return ErrClosed
+	}
+	if d <= 0 {
+		return fmt.Errorf("Only positive values for d are allowed")
+	}
+	if t.gameOnlyPaused() {
+		return &StateError{Op: "PauseFor", Current: Paused}
+	}
+	if !t.checkValidState(pauseOp) {
+		return &StateError{Op: "PauseFor", Current: t.state}
+	}
+
+	resumeAt := t.clock.Now().Add(d)
+	t.pause(&resumeAt)
+	pause := len(t.pauses)
+	t.autoResume = t.clock.AfterFunc(d, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		// only resume the pause this function was scheduled for. Pauses of PauseGameOnly are not recorded in t.pauses
+		if t.state == Paused && len(t.pauses) == pause || t.gameOnlyPaused() {
+			t.resumeTimerLocked()
+		}
+	})
+
+	return nil
+}
+
+// CancelAutoResume cancels the automatic resume of a pause started by PauseFor
+// the timer stays paused until ResumeTimer is called. It returns false if no automatic resume was pending
+func (t *Timer) CancelAutoResume() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.stopAutoResume() {
+		return false
+	}
+	t.emit(Event{Type: EventAutoResumeCanceled, Elapsed: t.elapsed})
+
+	return true
+}
+
+func (t *Timer) stopAutoResume() bool {
+	if t.autoResume == nil {
+		return false
+	}
+	stopped := t.autoResume.Stop()
+	t.autoResume = nil
+
+	return stopped
+}
@@ -0,0 +1,94 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestPauseFor(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+	sub := tm.Subscribe(timer.WithFilter(timer.StateChangesOnly()), timer.WithOverflow(timer.Unbounded))
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: func(tm *timer.Timer) error {
+			return tm.PauseFor(2 * time.Second)
+		}},
+	)
+	timertest.AssertEmitsType(t, sub.C, timer.EventStarted, 0)
+	e := timertest.AssertEmitsType(t, sub.C, timer.EventPaused, 0)
+	if want := clock.Now().Add(2 * time.Second); e.ResumeAt == nil || !e.ResumeAt.Equal(want) {
+		t.Errorf("pause event resumes at %v, want %v", e.ResumeAt, want)
+	}
+
+	clock.Advance(2 * time.Second)
+	waitState(t, tm, timer.Running, time.Second)
+	timertest.AssertEmitsType(t, sub.C, timer.EventResumed, 0)
+	if d := tm.Elapsed(); d != time.Second {
+		t.Errorf("elapsed is %v after the automatic resume, want 1s", d)
+	}
+}
+
+func TestCancelAutoResume(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	if tm.CancelAutoResume() {
+		t.Errorf("canceling without a pending resume reported true")
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{Do: func(tm *timer.Timer) error {
+			return tm.PauseFor(time.Second)
+		}},
+	)
+	if !tm.CancelAutoResume() {
+		t.Errorf("canceling the pending resume reported false")
+	}
+	clock.Advance(2 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	if s := tm.State(); s != timer.Paused {
+		t.Errorf("state is %v after canceling the automatic resume, want %v", s, timer.Paused)
+	}
+}
+
+func TestPauseForIgnoresLaterPauses(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	// the manual resume and pause replace the pause the automatic resume was scheduled for
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{Do: func(tm *timer.Timer) error {
+			return tm.PauseFor(2 * time.Second)
+		}},
+		timertest.Step{After: time.Second, Do: timertest.Resume},
+		timertest.Step{Do: timertest.Pause},
+		timertest.Step{After: 2 * time.Second},
+	)
+	time.Sleep(10 * time.Millisecond)
+	if s := tm.State(); s != timer.Paused {
+		t.Errorf("state is %v, want %v", s, timer.Paused)
+	}
+}
+
+func TestPauseForInvalid(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	if err := tm.PauseFor(time.Second); err == nil {
+		t.Errorf("pausing a reset timer succeeded, want an error")
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	if err := tm.PauseFor(0); err == nil {
+		t.Errorf("pausing for 0 succeeded, want an error")
+	}
+}
@@ -0,0 +1,66 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// PausePolicy describes which clocks freeze when the timer is paused
+type PausePolicy int
+
+const (
+	// PauseAll freezes the main timer and all subtimers
+	PauseAll PausePolicy = iota
+	// PauseMainOnly freezes the main timer while running subtimers keep counting
+	PauseMainOnly
+	// PauseGameOnly freezes only the game time while the main timer and subtimers keep counting real time
+	// the timer stays in Running state, ResumeTimer lets the game time continue
+	PauseGameOnly
+)
+
+// SetPausePolicy sets which clocks freeze when the timer is paused
+// Only works when timer is not paused
+func (t *Timer) SetPausePolicy(p PausePolicy) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state == Paused || t.gameOnlyPaused() {
+		return &StateError{Op: "SetPausePolicy", Current: Paused}
+	}
+	if !p.valid() {
+		return fmt.Errorf("Unknown pause policy %v", p)
+	}
+	t.pausePolicy = p
+
+	return nil
+}
+
+func (p PausePolicy) valid() bool {
+	return p == PauseAll || p == PauseMainOnly || p == PauseGameOnly
+}
+
+// gameOnlyPaused reports whether the timer has been paused with PauseGameOnly, so only its game time is frozen
+func (t *Timer) gameOnlyPaused() bool {
+	return t.pausePolicy == PauseGameOnly && t.gamePaused
+}
+
+// pauseCredit returns the time running subtimers counted during the ongoing pause of the timer
+func (t *Timer) pauseCredit() time.Duration {
+	if t.state != Paused || t.pausePolicy != PauseMainOnly {
+		return 0
+	}
+
+	return t.clock.Now().Sub(t.pauseTime)
+}
+
+// creditPause lets running subtimers keep the time they counted during a pause of paused
+// it has to be called when the timer is resumed, before the start time is shifted
+func (t *Timer) creditPause(paused time.Duration) {
+	if t.pausePolicy != PauseMainOnly {
+		return
+	}
+	for _, s := range t.subtimers {
+		if s.state == Running {
+			s.start -= paused
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestPausePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   timer.PausePolicy
+		paused   timer.State
+		elapsed  time.Duration
+		subtimer time.Duration
+		gameTime time.Duration
+	}{
+		{"all", timer.PauseAll, timer.Paused, 3 * time.Second, 3 * time.Second, 3 * time.Second},
+		{"main only", timer.PauseMainOnly, timer.Paused, 3 * time.Second, 6 * time.Second, 3 * time.Second},
+		{"game only", timer.PauseGameOnly, timer.Running, 6 * time.Second, 6 * time.Second, 3 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock, timer.WithSubtimers(1), timer.WithPausePolicy(test.policy))
+			defer tm.Close()
+
+			timertest.Run(t, tm, clock,
+				timertest.Step{Do: timertest.Start},
+				timertest.Step{After: 2 * time.Second, Do: timertest.Pause},
+			)
+			if s := tm.State(); s != test.paused {
+				t.Errorf("timer is %v while paused, want %v", s, test.paused)
+			}
+			timertest.Run(t, tm, clock, timertest.Step{After: 3 * time.Second, Do: timertest.Resume})
+			clock.Advance(time.Second)
+
+			if d := tm.Elapsed(); d != test.elapsed {
+				t.Errorf("elapsed %v, want %v", d, test.elapsed)
+			}
+			if d := tm.GameTime(); d != test.gameTime {
+				t.Errorf("game time %v, want %v", d, test.gameTime)
+			}
+			d, err := tm.StopSubTimer(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d != test.subtimer {
+				t.Errorf("subtimer stopped at %v, want %v", d, test.subtimer)
+			}
+		})
+	}
+}
+
+func TestSetPausePolicy(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	if err := tm.SetPausePolicy(timer.PausePolicy(42)); err == nil {
+		t.Errorf("setting an unknown pause policy succeeded, want an error")
+	}
+	if err := tm.SetPausePolicy(timer.PauseGameOnly); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.Pause},
+	)
+	var stateErr *timer.StateError
+	if err := tm.SetPausePolicy(timer.PauseAll); !errors.As(err, &stateErr) {
+		t.Errorf("changing the policy while the game time is paused returned %v, want a state error", err)
+	}
+	if err := tm.PauseTimer(); !errors.As(err, &stateErr) {
+		t.Errorf("pausing twice returned %v, want a state error", err)
+	}
+
+	timertest.Run(t, tm, clock, timertest.Step{After: time.Second, Do: timertest.Resume})
+	if err := tm.SetPausePolicy(timer.PauseAll); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Pause})
+	if err := tm.SetPausePolicy(timer.PauseMainOnly); !errors.As(err, &stateErr) {
+		t.Errorf("changing the policy while paused returned %v, want a state error", err)
+	}
+	if r := tm.Report(); r.Config.PausePolicy != timer.PauseAll {
+		t.Errorf("report has pause policy %v, want PauseAll", r.Config.PausePolicy)
+	}
+}
@@ -0,0 +1,95 @@
+package timer
+
+import "time"
+
+// Annotated invokes operations on a timer with a user payload attached
+// the payload is carried by all events emitted during the operation and therefore ends up in reports
+type Annotated struct {
+	t       *Timer
+	payload interface{}
+}
+
+// WithPayload returns a handle for invoking operations with payload attached
+// e.g. t.WithPayload("operator A").StopTimer()
+func (t *Timer) WithPayload(payload interface{}) Annotated {
+	return Annotated{t: t, payload: payload}
+}
+
+// do calls f with the payload attached and logs its failure like the operation op of the timer
+func (a Annotated) do(op string, f func() error) error {
+	a.t.mu.Lock()
+	defer a.t.mu.Unlock()
+
+	a.t.payload = a.payload
+	defer func() {
+		a.t.payload = nil
+	}()
+
+	return a.t.logFailed(op, f(), "payload", a.payload)
+}
+
+// StartTimer calls StartTimer on the timer with the payload attached
+func (a Annotated) StartTimer() error {
+	return a.do("StartTimer", a.t.startTimerLocked)
+}
+
+// StopTimer calls StopTimer on the timer with the payload attached
+func (a Annotated) StopTimer() error {
+	return a.do("StopTimer", a.t.stopTimerLocked)
+}
+
+// ResetTimer calls ResetTimer on the timer with the payload attached
+func (a Annotated) ResetTimer() error {
+	return a.do("ResetTimer", a.t.resetTimerLocked)
+}
+
+// PauseTimer calls PauseTimer on the timer with the payload attached
+func (a Annotated) PauseTimer() error {
+	return a.do("PauseTimer", a.t.pauseTimerLocked)
+}
+
+// PauseFor calls PauseFor on the timer with the payload attached
+func (a Annotated) PauseFor(d time.Duration) error {
+	return a.do("PauseFor", func() error {
+		return a.t.pauseForLocked(d)
+	})
+}
+
+// ResumeTimer calls ResumeTimer on the timer with the payload attached
+func (a Annotated) ResumeTimer() error {
+	return a.do("ResumeTimer", a.t.resumeTimerLocked)
+}
+
+// ConfirmReset calls ConfirmReset on the timer with the payload attached
+func (a Annotated) ConfirmReset() error {
+	return a.do("ConfirmReset", a.t.confirmResetLocked)
+}
+
+// ConfirmStop calls ConfirmStop on the timer with the payload attached
+func (a Annotated) ConfirmStop() error {
+	return a.do("ConfirmStop", a.t.confirmStopLocked)
+}
+
+// StopSubTimer calls StopSubTimer on the timer with the payload attached
+func (a Annotated) StopSubTimer(id int) (time.Duration, error) {
+	var d time.Duration
+	err := a.do("StopSubTimer", func() error {
+		var err error
+		d, err = a.t.stopSubTimerLocked(id)
+		return err
+	})
+
+	return d, err
+}
+
+// Trigger calls Trigger on the timer with the payload attached
+func (a Annotated) Trigger(source string) (time.Duration, error) {
+	var d time.Duration
+	err := a.do("Trigger", func() error {
+		var err error
+		d, err = a.t.triggerLocked(source)
+		return err
+	})
+
+	return d, err
+}
@@ -0,0 +1,45 @@
+package timer
+
+import "testing"
+
+func TestAnnotatedLogsFailedOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		op   string
+		do   func(a Annotated) error
+	}{
+		{"pause while stopped", "PauseTimer", Annotated.PauseTimer},
+		{"stop missing subtimer", "StopSubTimer", func(a Annotated) error {
+			_, err := a.StopSubTimer(1)
+			return err
+		}},
+		{"trigger unbound source", "Trigger", func(a Annotated) error {
+			_, err := a.Trigger("key:space")
+			return err
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := New()
+			defer tm.Close()
+			var logged map[interface{}]interface{}
+			tm.logHook = func(level int, msg string, args ...interface{}) {
+				logged = make(map[interface{}]interface{})
+				for i := 0; i+1 < len(args); i += 2 {
+					logged[args[i]] = args[i+1]
+				}
+			}
+
+			if err := test.do(tm.WithPayload("operator A")); err == nil {
+				t.Fatalf("%v succeeded, want an error", test.op)
+			}
+			if logged["op"] != test.op {
+				t.Errorf("logged op %v, want %v", logged["op"], test.op)
+			}
+			if logged["payload"] != "operator A" {
+				t.Errorf("logged payload %v, want %v", logged["payload"], "operator A")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package timer
+
+import "time"
+
+// Phase is a single countdown of a timer counting down a sequence of phases like NewPomodoro timers
+type Phase struct {
+	// Kind names the phase, e.g. PhaseWork
+	Kind string `json:"kind"`
+	// Number is the 1 based number of the phase in the sequence and Round the 1 based round it belongs to
+	Number   int           `json:"number"`
+	Round    int           `json:"round"`
+	Duration time.Duration `json:"duration"`
+}
+
+// phaseSequence returns the phase with the 0 based index n. ok is false once the sequence is finished
+type phaseSequence func(n int) (p Phase, ok bool)
+
+// newPhaseTimer returns a countdown timer counting down all phases of seq one after another
+func newPhaseTimer(seq phaseSequence, opts ...Option) *Timer {
+	first, _ := seq(0)
+	t := NewCountdown(first.Duration, opts...)
+	t.phases = seq
+	t.phase = first
+
+	return t
+}
+
+// Phase returns the current phase of a timer counting down a sequence of phases
+// ok is false for all other timers
+func (t *Timer) Phase() (p Phase, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.phase, t.phases != nil
+}
+
+// nextPhase starts the phase following the expired one and reports whether there was one
+// the next phase starts exactly when the previous one ended, so no time is lost between phases
+func (t *Timer) nextPhase() bool {
+	if t.phases == nil {
+		return false
+	}
+	next, ok := t.phases(t.phase.Number)
+	if !ok {
+		return false
+	}
+
+	t.startTime = t.startTime.Add(t.countdown)
+	t.countdown = next.Duration
+	t.phase = next
+	t.elapsed = t.currentElapsed()
+	t.emit(Event{Type: EventPhase, Elapsed: t.elapsed, Remaining: t.remaining(), Phase: &next})
+	t.armCountdown()
+
+	return true
+}
+
+// currentPhase returns a copy of the current phase for tick events or nil if the timer doesn't count down phases
+func (t *Timer) currentPhase() *Phase {
+	if t.phases == nil {
+		return nil
+	}
+	p := t.phase
+
+	return &p
+}
+
+// resetPhases starts the sequence of phases from the beginning
+func (t *Timer) resetPhases() {
+	if t.phases == nil {
+		return
+	}
+	t.phase, _ = t.phases(0)
+	t.countdown = t.phase.Duration
+}
@@ -0,0 +1,59 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// Kinds of Pomodoro phases
+const (
+	PhaseWork       = "work"
+	PhaseShortBreak = "short break"
+	PhaseLongBreak  = "long break"
+)
+
+// PomodoroConfig configures a Pomodoro timer
+type PomodoroConfig struct {
+	Work       time.Duration
+	ShortBreak time.Duration
+	LongBreak  time.Duration
+	// LongBreakEvery is the number of work phases after which a long break follows instead of a short one. Setting 0 uses 4
+	LongBreakEvery int
+	// Sessions is the number of work phases after which the timer stops. Setting 0 never stops
+	Sessions int
+}
+
+// NewPomodoro returns a countdown timer alternating between work phases and breaks
+// every change of the phase emits EventPhase. The updates channel receives the remaining time of the current phase.
+// Resetting the timer starts over with the first work phase
+func NewPomodoro(cfg PomodoroConfig, opts ...Option) (*Timer, error) {
+	if cfg.Work <= 0 || cfg.ShortBreak <= 0 || cfg.LongBreak <= 0 {
+		return nil, fmt.Errorf("Only positive durations are allowed")
+	}
+	if cfg.LongBreakEvery < 0 || cfg.Sessions < 0 {
+		return nil, fmt.Errorf("Only positive values for LongBreakEvery and Sessions are allowed")
+	}
+	if cfg.LongBreakEvery == 0 {
+		cfg.LongBreakEvery = 4
+	}
+
+	return newPhaseTimer(func(n int) (Phase, bool) {
+		// phases alternate between work and break, a round is one work phase and the break after it
+		round := n/2 + 1
+		if cfg.Sessions > 0 && round > cfg.Sessions {
+			return Phase{}, false
+		}
+		p := Phase{Kind: PhaseWork, Number: n + 1, Round: round, Duration: cfg.Work}
+		if n%2 == 1 {
+			if cfg.Sessions > 0 && round == cfg.Sessions {
+				// no break after the last session
+				return Phase{}, false
+			}
+			p.Kind, p.Duration = PhaseShortBreak, cfg.ShortBreak
+			if round%cfg.LongBreakEvery == 0 {
+				p.Kind, p.Duration = PhaseLongBreak, cfg.LongBreak
+			}
+		}
+
+		return p, true
+	}, opts...), nil
+}
@@ -0,0 +1,118 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestNewPomodoroInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  timer.PomodoroConfig
+	}{
+		{"no work", timer.PomodoroConfig{ShortBreak: time.Second, LongBreak: time.Second}},
+		{"negative break", timer.PomodoroConfig{Work: time.Second, ShortBreak: -time.Second, LongBreak: time.Second}},
+		{"negative long break interval", timer.PomodoroConfig{Work: time.Second, ShortBreak: time.Second, LongBreak: time.Second, LongBreakEvery: -1}},
+		{"negative sessions", timer.PomodoroConfig{Work: time.Second, ShortBreak: time.Second, LongBreak: time.Second, Sessions: -1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := timer.NewPomodoro(test.cfg); err == nil {
+				t.Errorf("creating the timer succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestPomodoroPhases(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm, err := timer.NewPomodoro(timer.PomodoroConfig{
+		Work:           4 * time.Second,
+		ShortBreak:     time.Second,
+		LongBreak:      2 * time.Second,
+		LongBreakEvery: 2,
+		Sessions:       3,
+	}, timer.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prepare(t, tm)
+	defer tm.Close()
+	phases := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventPhase, timer.EventPhasesFinished}}), timer.WithOverflow(timer.Unbounded))
+
+	first, ok := tm.Phase()
+	if !ok {
+		t.Fatalf("timer doesn't count down phases")
+	}
+	if want := (timer.Phase{Kind: timer.PhaseWork, Number: 1, Round: 1, Duration: 4 * time.Second}); first != want {
+		t.Errorf("first phase is %+v, want %+v", first, want)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+
+	// no break follows the last session
+	want := []timer.Phase{
+		{Kind: timer.PhaseShortBreak, Number: 2, Round: 1, Duration: time.Second},
+		{Kind: timer.PhaseWork, Number: 3, Round: 2, Duration: 4 * time.Second},
+		{Kind: timer.PhaseLongBreak, Number: 4, Round: 2, Duration: 2 * time.Second},
+		{Kind: timer.PhaseWork, Number: 5, Round: 3, Duration: 4 * time.Second},
+	}
+	current := first
+	for _, p := range want {
+		clock.Advance(current.Duration)
+		e := timertest.AssertEmitsType(t, phases.C, timer.EventPhase, 0)
+		if e.Phase == nil || *e.Phase != p {
+			t.Fatalf("started phase %+v, want %+v", e.Phase, p)
+		}
+		current = p
+	}
+
+	clock.Advance(current.Duration)
+	timertest.AssertEmitsType(t, phases.C, timer.EventPhasesFinished, 0)
+	waitState(t, tm, timer.Stopped, time.Second)
+	// elapsed and remaining refer to the current phase
+	if d := tm.Elapsed(); d != current.Duration {
+		t.Errorf("elapsed is %v, want %v", d, current.Duration)
+	}
+	if d := tm.Remaining(); d != 0 {
+		t.Errorf("remaining is %v, want 0", d)
+	}
+
+	// resetting starts over with the first work phase
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := tm.Phase(); p != first {
+		t.Errorf("phase after reset is %+v, want %+v", p, first)
+	}
+}
+
+func TestPomodoroWithoutSessionsKeepsGoing(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm, err := timer.NewPomodoro(timer.PomodoroConfig{Work: time.Second, ShortBreak: time.Second, LongBreak: time.Second}, timer.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prepare(t, tm)
+	defer tm.Close()
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+
+	// the default long break interval puts a long break after the fourth work phase
+	for n := 2; n <= 9; n++ {
+		clock.Advance(time.Second)
+		waitPhase(t, tm, n, time.Second)
+		if p, _ := tm.Phase(); n == 8 && p.Kind != timer.PhaseLongBreak {
+			t.Errorf("phase 8 is a %v, want a %v", p.Kind, timer.PhaseLongBreak)
+		}
+	}
+	p, _ := tm.Phase()
+	if p.Kind != timer.PhaseWork || p.Round != 5 {
+		t.Errorf("phase 9 is %+v, want the work phase of round 5", p)
+	}
+	if s := tm.State(); s != timer.Running {
+		t.Errorf("state is %v, want %v", s, timer.Running)
+	}
+}
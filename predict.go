@@ -0,0 +1,143 @@
+package timer
+
+import "time"
+
+// Predictor calculates the predicted final time of a run from its current progress
+// Predict is called while the timer is locked and must not call methods of the timer
+type Predictor interface {
+	Predict(p Progress) time.Duration
+}
+
+// PredictorFunc allows the use of ordinary functions as Predictor
+type PredictorFunc func(p Progress) time.Duration
+
+// Predict calls f(p)
+func (f PredictorFunc) Predict(p Progress) time.Duration {
+	return f(p)
+}
+
+// Progress describes the current run in relation to the comparison
+type Progress struct {
+	Elapsed time.Duration
+	// Splits holds the recorded times of all stopped subtimers
+	Splits map[int]time.Duration
+	// Comparison holds the comparison times keyed by subtimer id
+	Comparison map[int]time.Duration
+}
+
+// Final returns the final time of the comparison, which is its largest time
+func (p Progress) Final() time.Duration {
+	var final time.Duration
+	for _, c := range p.Comparison {
+		if c > final {
+			final = c
+		}
+	}
+
+	return final
+}
+
+// LastSplit returns the latest recorded split which also exists in the comparison
+// ok is false if no such split has been recorded yet
+func (p Progress) LastSplit() (split, comparison time.Duration, ok bool) {
+	for id, s := range p.Splits {
+		c, exists := p.Comparison[id]
+		if !exists || s < split {
+			continue
+		}
+		split, comparison, ok = s, c, true
+	}
+
+	return split, comparison, ok
+}
+
+// SegmentPredictor predicts the final time by carrying the delta at the last split over to the final time of the comparison
+var SegmentPredictor Predictor = PredictorFunc(func(p Progress) time.Duration {
+	prediction := p.Final()
+	if split, comparison, ok := p.LastSplit(); ok {
+		prediction += split - comparison
+	}
+
+	return maxDuration(prediction, p.Elapsed)
+})
+
+// LinearPredictor predicts the final time by scaling the final time of the comparison with the pace of the last split
+var LinearPredictor Predictor = PredictorFunc(func(p Progress) time.Duration {
+	prediction := p.Final()
+	if split, comparison, ok := p.LastSplit(); ok && comparison > 0 {
+		prediction = time.Duration(float64(prediction) * float64(split) / float64(comparison))
+	}
+
+	return maxDuration(prediction, p.Elapsed)
+})
+
+// SetComparison sets the times the current run is compared against, keyed by subtimer id
+// passing nil removes the comparison
+func (t *Timer) SetComparison(times map[int]time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if times == nil {
+		t.comparison = nil
+		return
+	}
+
+	t.comparison = make(map[int]time.Duration, len(times))
+	for id, d := range times {
+		t.comparison[id] = d
+	}
+}
+
+// SetPredictor sets the model used for predicting the final time
+// Setting nil sets it back to the default SegmentPredictor
+func (t *Timer) SetPredictor(p Predictor) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.predictor = p
+}
+
+// PredictedFinish returns the predicted final time of the current run
+// it returns 0 if no comparison is set
+func (t *Timer) PredictedFinish() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prediction, _ := t.prediction()
+
+	return prediction
+}
+
+// prediction returns the predicted final time and its difference to the comparison final time
+func (t *Timer) prediction() (prediction, delta time.Duration) {
+	if len(t.comparison) == 0 {
+		return 0, 0
+	}
+
+	p := Progress{
+		Elapsed:    t.elapsed,
+		Splits:     make(map[int]time.Duration),
+		Comparison: t.comparison,
+	}
+	for id, s := range t.subtimers {
+		if s.state == Stopped {
+			p.Splits[id] = s.Time
+		}
+	}
+
+	predictor := t.predictor
+	if predictor == nil {
+		predictor = SegmentPredictor
+	}
+	prediction = predictor.Predict(p)
+
+	return prediction, prediction - p.Final()
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,89 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestPredictors(t *testing.T) {
+	comparison := map[int]time.Duration{1: 10 * time.Second, 2: 20 * time.Second, 3: 40 * time.Second}
+	tests := []struct {
+		name      string
+		predictor timer.Predictor
+		progress  timer.Progress
+		want      time.Duration
+	}{
+		{
+			name:      "segment without splits",
+			predictor: timer.SegmentPredictor,
+			progress:  timer.Progress{Elapsed: 5 * time.Second, Comparison: comparison},
+			want:      40 * time.Second,
+		},
+		{
+			name:      "segment ahead",
+			predictor: timer.SegmentPredictor,
+			progress:  timer.Progress{Elapsed: 19 * time.Second, Splits: map[int]time.Duration{1: 9 * time.Second, 2: 18 * time.Second}, Comparison: comparison},
+			want:      38 * time.Second,
+		},
+		{
+			name:      "segment ignores splits missing in the comparison",
+			predictor: timer.SegmentPredictor,
+			progress:  timer.Progress{Elapsed: 19 * time.Second, Splits: map[int]time.Duration{1: 12 * time.Second, 4: 18 * time.Second}, Comparison: comparison},
+			want:      42 * time.Second,
+		},
+		{
+			name:      "linear behind",
+			predictor: timer.LinearPredictor,
+			progress:  timer.Progress{Elapsed: 25 * time.Second, Splits: map[int]time.Duration{1: 15 * time.Second}, Comparison: comparison},
+			want:      60 * time.Second,
+		},
+		{
+			name:      "never below elapsed",
+			predictor: timer.LinearPredictor,
+			progress:  timer.Progress{Elapsed: 50 * time.Second, Splits: map[int]time.Duration{2: 20 * time.Second}, Comparison: comparison},
+			want:      50 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.predictor.Predict(test.progress); got != test.want {
+				t.Errorf("predicted %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPredictedFinish(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2))
+	defer tm.Close()
+
+	if d := tm.PredictedFinish(); d != 0 {
+		t.Errorf("predicted %v without a comparison, want 0", d)
+	}
+	tm.SetComparison(map[int]time.Duration{1: 10 * time.Second, 2: 30 * time.Second})
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 8 * time.Second, Do: timertest.StopSubTimer(1)},
+	)
+	if d := tm.PredictedFinish(); d != 28*time.Second {
+		t.Errorf("predicted %v, want 28s", d)
+	}
+
+	tm.SetPredictor(timer.LinearPredictor)
+	if d := tm.PredictedFinish(); d != 24*time.Second {
+		t.Errorf("predicted %v with the linear predictor, want 24s", d)
+	}
+	tm.SetPredictor(nil)
+	if d := tm.PredictedFinish(); d != 28*time.Second {
+		t.Errorf("predicted %v after removing the predictor, want 28s", d)
+	}
+	tm.SetComparison(nil)
+	if d := tm.PredictedFinish(); d != 0 {
+		t.Errorf("predicted %v after removing the comparison, want 0", d)
+	}
+}
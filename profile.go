@@ -0,0 +1,66 @@
+package timer
+
+import "context"
+
+import "fmt"
+
+import "runtime/pprof"
+
+import "time"
+
+const (
+	roleLoop         = "loop"
+	roleSubscription = "subscription"
+	roleSampler      = "sampler"
+	roleCountdown    = "countdown"
+	roleCallback     = "callback"
+)
+
+// TickObserver is called after every tick with the elapsed time of the timer and how long processing the tick took
+type TickObserver func(elapsed, took time.Duration)
+
+// SetLabel sets the value of the "timer" pprof label attached to all goroutines of the timer
+// goroutines are additionally labeled with their "role", so profiles of large deployments can be attributed to specific timers.
+// Only affects goroutines started after the call
+func (t *Timer) SetLabel(label string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.label = label
+}
+
+// SetTickObserver sets a function which is called after every tick, e.g. for measuring the cost of the tick path
+// o is called while the timer is locked and must not call methods of the timer. Setting nil removes the observer
+func (t *Timer) SetTickObserver(o TickObserver) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tickObserver = o
+}
+
+// goLabeled runs f in a new goroutine labeled with the timer label and role. t.mu has to be held
+func (t *Timer) goLabeled(role string, f func()) {
+	label := t.label
+	if label == "" {
+		label = fmt.Sprintf("%p", t)
+	}
+
+	go pprof.Do(context.Background(), pprof.Labels("timer", label, "role", role), func(context.Context) {
+		f()
+	})
+}
+
+// goWorker runs f like goLabeled and tracks it in t.workers so shutdown can wait for it. t.mu has to be held
+// once the timer is closed f is not tracked anymore, it has to return on its own
+func (t *Timer) goWorker(role string, f func()) {
+	if t.closed {
+		t.goLabeled(role, f)
+		return
+	}
+
+	t.workers.Add(1)
+	t.goLabeled(role, func() {
+		defer t.workers.Done()
+		f()
+	})
+}
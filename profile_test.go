@@ -0,0 +1,71 @@
+package timer_test
+
+import "bytes"
+
+import "runtime/pprof"
+
+import "strings"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestTickObserver(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+	defer tm.Close()
+	observed := make(chan time.Duration, 10)
+	tm.SetTickObserver(func(elapsed, took time.Duration) {
+		observed <- elapsed
+	})
+
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	for want := time.Second; want <= 2*time.Second; want += time.Second {
+		clock.Advance(time.Second)
+		select {
+		case d := <-observed:
+			if d != want {
+				t.Errorf("observed tick at %v, want %v", d, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no tick observed within 1s")
+		}
+	}
+
+	tm.SetTickObserver(nil)
+	clock.Advance(time.Second)
+	select {
+	case d := <-observed:
+		t.Errorf("removed observer observed tick at %v", d)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestSetLabel(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+	tm.SetLabel("race-42")
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+
+	// the loop goroutine may not have been scheduled yet
+	want := `"role":"loop", "timer":"race-42"`
+	deadline := time.Now().Add(time.Second)
+	for {
+		var buf bytes.Buffer
+		if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(buf.String(), want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutine profile doesn't contain the labels %v", want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,52 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// pump holds the state of a timer created by NewPump
+type pump struct {
+	clock  *simClock
+	events []Event
+}
+
+// NewPump returns a new timer in pump mode whose clock starts at now
+// the timer spawns no goroutines of its own. Instead the host calls Pump from its own loop, which advances the timer and returns all due events.
+// Subscriptions and samplers still run their own goroutines and should not be used in pump mode
+func NewPump(now time.Time) *Timer {
+	t := New()
+	p := &pump{clock: &simClock{now: now}}
+	t.manual = true
+	t.pump = p
+	t.SetClock(p.clock)
+	t.events.record = func(e Event) {
+		p.events = append(p.events, e)
+	}
+
+	return t
+}
+
+// Pump advances a timer created by NewPump to now and returns all events emitted since the last call
+// due automatic resumes are performed and the timer is ticked. now must not be before the previous call
+func (t *Timer) Pump(now time.Time) ([]Event, error) {
+	if t.pump == nil {
+		return nil, fmt.Errorf("Pump can only be called on timers created by NewPump")
+	}
+	c := t.pump.clock
+	previous := c.Now()
+	if now.Before(previous) {
+		return nil, fmt.Errorf("Pump called with time %v before previous time %v", now, previous)
+	}
+
+	c.advance(now.Sub(previous))
+	c.fireAlarms()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.advance(now)
+	events := t.pump.events
+	t.pump.events = nil
+
+	return events, nil
+}
@@ -0,0 +1,77 @@
+package timer_test
+
+import "reflect"
+
+import "runtime"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+func TestPump(t *testing.T) {
+	now := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	tm := timer.NewPump(now)
+	defer tm.Close()
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	goroutines := runtime.NumGoroutine()
+	if err := tm.StartTimer(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.PauseFor(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if n := runtime.NumGoroutine(); n > goroutines {
+		t.Errorf("running %v goroutines after starting, want at most %v", n, goroutines)
+	}
+
+	// the automatic resume is performed by the pump
+	var events []timer.Event
+	for d := 100 * time.Millisecond; d <= 1500*time.Millisecond; d += 100 * time.Millisecond {
+		pumped, err := tm.Pump(now.Add(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, pumped...)
+	}
+	var types []timer.EventType
+	var last time.Duration
+	for _, e := range events {
+		if e.Type == timer.EventTick {
+			last = e.Elapsed
+			continue
+		}
+		types = append(types, e.Type)
+	}
+	want := []timer.EventType{timer.EventReset, timer.EventStarted, timer.EventPaused, timer.EventResumed}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("pumped events %v, want %v", types, want)
+	}
+	if last != 500*time.Millisecond {
+		t.Errorf("last tick has elapsed %v, want 500ms", last)
+	}
+
+	// events are only returned once
+	events, err := tm.Pump(now.Add(1600 * time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Type != timer.EventTick || events[0].Elapsed != 600*time.Millisecond {
+		t.Errorf("pumped %+v, want a single tick at 600ms", events)
+	}
+	if _, err := tm.Pump(now); err == nil {
+		t.Errorf("pumping backwards in time succeeded, want an error")
+	}
+}
+
+func TestPumpRequiresPumpTimer(t *testing.T) {
+	tm := timer.New()
+	defer tm.Close()
+
+	if _, err := tm.Pump(time.Now()); err == nil {
+		t.Errorf("pumping a regular timer succeeded, want an error")
+	}
+}
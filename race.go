@@ -0,0 +1,237 @@
+package timer
+
+import "fmt"
+
+import "sort"
+
+import "sync"
+
+import "time"
+
+// Race coordinates independent timers of multiple runners
+// all timers are started at the same instant after a shared countdown and every runner finishes on their own timer
+type Race struct {
+	mu        sync.Mutex
+	timers    map[int]*Timer
+	forfeited map[int]bool
+	clock     Clock
+	start     Alarm
+	// startErr is the error of the last scheduled start
+	startErr error
+}
+
+// NewRace returns a new race with one timer per runner id
+// opts are applied to every timer. All timers have to share the same clock. Unless opts say otherwise
+// the updates channels of the timers only hold the latest update, so runners nobody watches don't stall
+func NewRace(runners []int, opts ...Option) (*Race, error) {
+	if len(runners) == 0 {
+		return nil, fmt.Errorf("A race needs at least one runner")
+	}
+
+	r := &Race{timers: make(map[int]*Timer, len(runners)), forfeited: make(map[int]bool)}
+	for _, id := range runners {
+		if _, ok := r.timers[id]; ok {
+			return nil, fmt.Errorf("Runner with id %v already exists", id)
+		}
+		t := New(append([]Option{WithUpdates(1, UpdateLatest)}, opts...)...)
+		if err := t.ResetTimer(); err != nil {
+			return nil, err
+		}
+		r.timers[id] = t
+		r.clock = t.clock
+	}
+
+	return r, nil
+}
+
+// Timer returns the timer of the runner with id
+func (r *Race) Timer(id int) (*Timer, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.timers[id]
+
+	return t, ok
+}
+
+// Start starts the timers of all runners at the same instant once countdown has passed
+// with a countdown of 0 the timers are started immediately. Only possible when all timers are in Reset state.
+// Errors of a start after a countdown are returned by StartErr
+func (r *Race) Start(countdown time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if countdown < 0 {
+		return fmt.Errorf("Only positive values for countdown are allowed")
+	}
+	if r.start != nil {
+		return fmt.Errorf("Race start is already scheduled")
+	}
+	for id, t := range r.timers {
+		t.mu.Lock()
+		valid := t.checkValidState(startOp)
+		t.mu.Unlock()
+		if !valid {
+			return fmt.Errorf("Timer of runner %v is not in reset state", id)
+		}
+	}
+
+	r.startErr = nil
+	at := r.clock.Now().Add(countdown)
+	if countdown == 0 {
+		return r.startAll(at)
+	}
+	r.start = r.clock.AfterFunc(countdown, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.start = nil
+		r.startErr = r.startAll(at)
+	})
+
+	return nil
+}
+
+// StartErr returns the error of the last start which was scheduled after a countdown
+// it is nil while the start is pending or if all timers were started
+func (r *Race) StartErr() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.startErr
+}
+
+// startAll starts all timers as if they had been started at the instant at
+// timers which can't be started don't keep the others from starting. It must be called with r.mu held
+func (r *Race) startAll(at time.Time) error {
+	var lastErr error
+	for _, id := range r.runnerIDs() {
+		t := r.timers[id]
+		t.mu.Lock()
+		if err := t.startTimerAtLocked(at); err != nil {
+			lastErr = fmt.Errorf("Starting timer of runner %v: %v", id, err)
+		}
+		t.mu.Unlock()
+	}
+
+	return lastErr
+}
+
+// CancelStart cancels a scheduled start of the race
+// it returns false if no start was pending
+func (r *Race) CancelStart() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start == nil {
+		return false
+	}
+	stopped := r.start.Stop()
+	r.start = nil
+
+	return stopped
+}
+
+// Finish stops the timer of the runner with id and returns the final time
+func (r *Race) Finish(id int) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.timers[id]
+	if !ok {
+		return 0, fmt.Errorf("Runner with id %v does not exist", id)
+	}
+	if err := t.StopTimer(); err != nil {
+		return 0, err
+	}
+
+	return t.Elapsed(), nil
+}
+
+// Forfeit stops the timer of the runner with id and marks the runner as not finished
+func (r *Race) Forfeit(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.timers[id]
+	if !ok {
+		return fmt.Errorf("Runner with id %v does not exist", id)
+	}
+	if err := t.StopTimer(); err != nil {
+		return err
+	}
+	r.forfeited[id] = true
+
+	return nil
+}
+
+// Reset resets the timers of all runners for the next race
+// only possible when no timer is running
+func (r *Race) Reset() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start != nil {
+		return fmt.Errorf("Race start is scheduled")
+	}
+	for _, id := range r.runnerIDs() {
+		t := r.timers[id]
+		if err := t.ResetTimer(); err != nil {
+			return fmt.Errorf("Resetting timer of runner %v: %v", id, err)
+		}
+	}
+	r.forfeited = make(map[int]bool)
+
+	return nil
+}
+
+// Results ranks all runners by their final times including the adjustments of their timers
+// runners who haven't finished yet or forfeited are ranked last
+func (r *Race) Results() RaceResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := RaceResult{Entries: make([]RaceEntry, 0, len(r.timers))}
+	for id, t := range r.timers {
+		report := t.Report()
+		e := RaceEntry{
+			ID:          id,
+			State:       report.State,
+			Time:        report.FinalTime,
+			Compensated: report.Adjusted,
+			Adjustment:  report.Adjusted - report.FinalTime,
+		}
+		if r.forfeited[id] {
+			e.State = Forfeited
+		}
+		res.Entries = append(res.Entries, e)
+	}
+	rankEntries(res.Entries)
+
+	return res
+}
+
+// Close closes the timers of all runners
+func (r *Race) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start != nil {
+		r.start.Stop()
+		r.start = nil
+	}
+	for _, t := range r.timers {
+		t.Close()
+	}
+}
+
+// runnerIDs returns the ids of all runners in ascending order
+func (r *Race) runnerIDs() []int {
+	ids := make([]int, 0, len(r.timers))
+	for id := range r.timers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	return ids
+}
@@ -0,0 +1,142 @@
+package timer_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestRaceScheduledStartError(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	r, err := timer.NewRace([]int{1, 2}, timer.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err := r.Start(3 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	// runner 1 is started by hand during the countdown, so the scheduled start fails for it
+	t1, _ := r.Timer(1)
+	if err := t1.StartTimer(); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(3 * time.Second)
+
+	deadline := time.Now().Add(time.Second)
+	for r.StartErr() == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("no start error within 1s")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t2, _ := r.Timer(2)
+	if s := t2.State(); s != timer.Running {
+		t.Errorf("runner 2 is %v, want %v", s, timer.Running)
+	}
+
+	// scheduling the next start clears the error of the previous one
+	for _, id := range []int{1, 2} {
+		if _, err := r.Finish(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Start(3 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.StartErr(); err != nil {
+		t.Errorf("start error is %v while the start is pending, want nil", err)
+	}
+}
+
+func TestRaceStartAfterCountdown(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	r, err := timer.NewRace([]int{1, 2, 3}, timer.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err := r.Start(3 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Start(time.Second); err == nil {
+		t.Errorf("scheduling a second start succeeded, want an error")
+	}
+	// the timers start at the end of the countdown even if the alarm runs late
+	clock.Advance(5 * time.Second)
+	for _, id := range []int{1, 2, 3} {
+		tm, _ := r.Timer(id)
+		waitState(t, tm, timer.Running, time.Second)
+	}
+
+	// the timers were started 2s ago when the countdown ran out
+	finishes := []struct {
+		id    int
+		after time.Duration
+		want  time.Duration
+	}{
+		{2, time.Second, 3 * time.Second},
+		{1, 2 * time.Second, 5 * time.Second},
+	}
+	for _, f := range finishes {
+		clock.Advance(f.after)
+		got, err := r.Finish(f.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != f.want {
+			t.Errorf("runner %v finished at %v, want %v", f.id, got, f.want)
+		}
+	}
+	if err := r.Forfeit(3); err != nil {
+		t.Fatal(err)
+	}
+
+	res := r.Results()
+	ranks := make(map[int]int)
+	for _, e := range res.Entries {
+		ranks[e.ID] = e.Rank
+		if e.ID == 3 && e.State != timer.Forfeited {
+			t.Errorf("runner 3 is %v, want %v", e.State, timer.Forfeited)
+		}
+	}
+	if want := map[int]int{1: 2, 2: 1, 3: 0}; !reflect.DeepEqual(ranks, want) {
+		t.Errorf("ranks are %v, want %v", ranks, want)
+	}
+}
+
+func TestRaceCancelStart(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	r, err := timer.NewRace([]int{1, 2}, timer.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if r.CancelStart() {
+		t.Errorf("canceling without a scheduled start reported true")
+	}
+	if err := r.Start(3 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if !r.CancelStart() {
+		t.Errorf("canceling the scheduled start reported false")
+	}
+	clock.Advance(5 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	for _, id := range []int{1, 2} {
+		tm, _ := r.Timer(id)
+		if s := tm.State(); s != timer.Reset {
+			t.Errorf("runner %v is %v after canceling the start, want %v", id, s, timer.Reset)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package timer
+
+import "time"
+
+// Leg is the part of a relay subtimer run by a single member
+type Leg struct {
+	Member string `json:"member"`
+	// Start is the time of the subtimer at which the member took over
+	Start time.Duration `json:"start"`
+	// Time is the time the member held the subtimer. It is 0 while the leg is ongoing
+	Time time.Duration `json:"time"`
+}
+
+// Handoff hands a relay subtimer over to member, finishing the leg of the current holder
+// the subtimer keeps running. Handing off a subtimer which hasn't been started yet sets its first holder
+func (t *Timer) Handoff(id int, member string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	t.refreshElapsed()
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if s.state != Reset && s.state != Running {
+		return &StateError{Op: "Handoff", Current: s.state}
+	}
+
+	if s.state == Reset {
+		s.legs = []Leg{{Member: member}}
+		return nil
+	}
+
+	elapsed := t.subtimerElapsed(s)
+	s.finishLeg(elapsed)
+	s.legs = append(s.legs, Leg{Member: member, Start: elapsed})
+	t.emit(Event{Type: EventHandoff, Elapsed: t.elapsed, Subtimer: intPtr(id), Leg: &s.legs[len(s.legs)-1]})
+
+	return nil
+}
+
+// Legs returns the legs of a relay subtimer in the order they were run
+func (t *Timer) Legs(id int) ([]Leg, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return nil, errSubtimerNotFound(id)
+	}
+
+	return s.copyLegs(), nil
+}
+
+// finishLeg finishes the ongoing leg of s at elapsed
+func (s *subtimer) finishLeg(elapsed time.Duration) {
+	if len(s.legs) == 0 {
+		return
+	}
+	leg := &s.legs[len(s.legs)-1]
+	if leg.Time == 0 {
+		leg.Time = elapsed - leg.Start
+	}
+}
+
+func (s *subtimer) copyLegs() []Leg {
+	if len(s.legs) == 0 {
+		return nil
+	}
+	legs := make([]Leg, len(s.legs))
+	copy(legs, s.legs)
+
+	return legs
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,62 @@
+package timer_test
+
+import "errors"
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestHandoff(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+	handoffs := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventHandoff}}))
+
+	handoff := func(member string) func(tm *timer.Timer) error {
+		return func(tm *timer.Timer) error {
+			return tm.Handoff(1, member)
+		}
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: handoff("alice")},
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 3 * time.Second, Do: handoff("bob")},
+	)
+	e := timertest.AssertEmitsType(t, handoffs.C, timer.EventHandoff, 0)
+	if want := (timer.Leg{Member: "bob", Start: 3 * time.Second}); e.Leg == nil || *e.Leg != want || e.Subtimer == nil || *e.Subtimer != 1 {
+		t.Errorf("handoff event has leg %+v of subtimer %v, want %+v of subtimer 1", e.Leg, e.Subtimer, want)
+	}
+
+	timertest.Run(t, tm, clock, timertest.Step{After: 2 * time.Second, Do: timertest.StopSubTimer(1)})
+	want := []timer.Leg{
+		{Member: "alice", Time: 3 * time.Second},
+		{Member: "bob", Start: 3 * time.Second, Time: 2 * time.Second},
+	}
+	legs, err := tm.Legs(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(legs, want) {
+		t.Errorf("legs are %+v, want %+v", legs, want)
+	}
+	if r := tm.Report(); !reflect.DeepEqual(r.Subtimers[0].Legs, want) {
+		t.Errorf("report has legs %+v, want %+v", r.Subtimers[0].Legs, want)
+	}
+
+	var stateErr *timer.StateError
+	if err := tm.Handoff(1, "carol"); !errors.As(err, &stateErr) {
+		t.Errorf("handing off a stopped subtimer returned %v, want a state error", err)
+	}
+	if err := tm.Handoff(2, "carol"); err == nil {
+		t.Errorf("handing off an unknown subtimer succeeded, want an error")
+	}
+	if _, err := tm.Legs(2); err == nil {
+		t.Errorf("getting the legs of an unknown subtimer succeeded, want an error")
+	}
+}
@@ -0,0 +1,191 @@
+package timer
+
+import "encoding/json"
+
+import "sort"
+
+import "time"
+
+// Pause describes a single pause of the timer
+type Pause struct {
+	// Start is the wall clock time the timer was paused at
+	Start time.Time `json:"start"`
+	// Elapsed is the elapsed time of the timer when it was paused
+	Elapsed time.Duration `json:"elapsed"`
+	// Duration is how long the timer was paused. It is 0 while the pause is ongoing
+	Duration time.Duration `json:"duration"`
+}
+
+// SubtimerResult holds the result of a single subtimer
+type SubtimerResult struct {
+	ID    int           `json:"id"`
+	State State         `json:"state"`
+	Time  time.Duration `json:"time"`
+	// Offset is the stream delay of the runner and Adjustment the sum of all ledger adjustments
+	// Compensated is the time corrected by both
+	Offset      time.Duration `json:"offset,omitempty"`
+	Adjustment  time.Duration `json:"adjustment,omitempty"`
+	Compensated time.Duration `json:"compensated"`
+	// Paused is the total time the subtimer was paused on its own
+	Paused time.Duration `json:"paused,omitempty"`
+	// Legs holds the legs of relay subtimers
+	Legs []Leg `json:"legs,omitempty"`
+	// Splits holds the times at the shared segments
+	Splits []time.Duration `json:"splits,omitempty"`
+	// Name is the human readable name of the subtimer if it has one, Description and Tags its display data
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	// Seed and Bracket hold the tournament metadata of the subtimer
+	Seed    int    `json:"seed,omitempty"`
+	Bracket string `json:"bracket,omitempty"`
+	// Gold is set if the subtimer finished with a new best segment
+	Gold bool `json:"gold,omitempty"`
+	// Provisional is set for recorded times which haven't been adjudicated yet
+	Provisional bool   `json:"provisional"`
+	Note        string `json:"note,omitempty"`
+}
+
+// ReportConfig holds the configuration of the timer which produced a report
+type ReportConfig struct {
+	// UpdateInterval and TickerInterval are in milliseconds. Intervals below one millisecond are only kept by the Duration variants
+	UpdateInterval              int           `json:"updateInterval"`
+	TickerInterval              int           `json:"tickerInterval"`
+	UpdateIntervalDuration      time.Duration `json:"updateIntervalDuration,omitempty"`
+	TickerIntervalDuration      time.Duration `json:"tickerIntervalDuration,omitempty"`
+	AllowResumeAfterStop        bool          `json:"allowResumeAfterStop"`
+	ContinueCountingWhenStopped bool          `json:"continueCountingWhenStopped"`
+	StopOnSubtimersStop         bool          `json:"stopOnSubtimersStop"`
+	PausePolicy                 PausePolicy   `json:"pausePolicy"`
+	StartOffset                 time.Duration `json:"startOffset,omitempty"`
+	TargetDuration              time.Duration `json:"targetDuration,omitempty"`
+	FrameRate                   float64       `json:"frameRate,omitempty"`
+	FrameRounding               FrameRounding `json:"frameRounding,omitempty"`
+}
+
+func (t *Timer) reportConfig() ReportConfig {
+	return ReportConfig{
+		UpdateInterval:              int(t.updateInterval / time.Millisecond),
+		TickerInterval:              int(t.tickerInterval / time.Millisecond),
+		UpdateIntervalDuration:      t.updateInterval,
+		TickerIntervalDuration:      t.tickerInterval,
+		AllowResumeAfterStop:        t.allowResumeAfterStop,
+		ContinueCountingWhenStopped: t.continueCountingWhenStopped,
+		StopOnSubtimersStop:         t.stopOnSubtimersStop,
+		PausePolicy:                 t.pausePolicy,
+		StartOffset:                 t.startOffset,
+		TargetDuration:              t.target,
+		FrameRate:                   t.frameRate,
+		FrameRounding:               t.frameRounding,
+	}
+}
+
+// Report is the complete record of a single run
+type Report struct {
+	Config    ReportConfig  `json:"config"`
+	State     State         `json:"state"`
+	StartTime time.Time     `json:"startTime"`
+	FinalTime time.Duration `json:"finalTime"`
+	// GameTime is the game time of the run, which may exclude time paused by PauseGameTime
+	GameTime time.Duration `json:"gameTime"`
+	// ActiveTime is the time the timer was running excluding pauses and stops and WallTime the time since the first start including them
+	ActiveTime time.Duration `json:"activeTime"`
+	WallTime   time.Duration `json:"wallTime"`
+	// Adjusted is FinalTime including all adjustments of the main timer
+	Adjusted time.Duration `json:"adjusted"`
+	Pauses   []Pause       `json:"pauses"`
+	Laps     []LapResult   `json:"laps,omitempty"`
+	// Subtimers holds the results of all subtimers ordered by id
+	Subtimers []SubtimerResult `json:"subtimers"`
+	// Splits holds the results of all stopped subtimers ordered by time
+	Splits []SubtimerResult `json:"splits"`
+	// Ledger holds all adjustments of the run
+	Ledger []Adjustment `json:"ledger"`
+	// Events holds all events of the run except ticks
+	Events []Event `json:"events"`
+}
+
+// Report returns the record of the current run
+func (t *Timer) Report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.reportLocked()
+}
+
+func (t *Timer) reportLocked() Report {
+	r := Report{
+		Config:     t.reportConfig(),
+		State:      t.state,
+		StartTime:  t.startTime,
+		FinalTime:  t.elapsed,
+		GameTime:   t.gameTime(t.elapsed),
+		ActiveTime: t.activeTimeLocked(),
+		WallTime:   t.wallTimeLocked(),
+		Adjusted:   t.adjusted(),
+		Ledger:     t.ledgerLocked(),
+		Pauses:     make([]Pause, len(t.pauses)),
+		Laps:       t.lapsLocked(),
+		Subtimers:  make([]SubtimerResult, 0, len(t.subtimers)),
+		Splits:     make([]SubtimerResult, 0),
+		Events:     t.eventLog(),
+	}
+	copy(r.Pauses, t.pauses)
+
+	for id, s := range t.subtimers {
+		result := SubtimerResult{
+			ID:          id,
+			State:       s.state,
+			Time:        s.Time,
+			Offset:      s.offset,
+			Adjustment:  s.adjustment,
+			Compensated: s.total(),
+			Paused:      s.pausedFor(t.elapsed),
+			Legs:        s.copyLegs(),
+			Splits:      s.copySplits(),
+			Name:        s.name,
+			Description: s.description,
+			Tags:        copyTags(s.tags),
+			Seed:        s.seed,
+			Bracket:     s.bracket,
+			Gold:        s.gold,
+			Provisional: s.provisional(),
+			Note:        s.note,
+		}
+		r.Subtimers = append(r.Subtimers, result)
+		if s.state == Stopped {
+			r.Splits = append(r.Splits, result)
+		}
+	}
+	sort.Slice(r.Subtimers, func(i, j int) bool {
+		return r.Subtimers[i].ID < r.Subtimers[j].ID
+	})
+	sort.Slice(r.Splits, func(i, j int) bool {
+		if r.Splits[i].Time == r.Splits[j].Time {
+			return r.Splits[i].ID < r.Splits[j].ID
+		}
+		return r.Splits[i].Time < r.Splits[j].Time
+	})
+
+	return r
+}
+
+// ExportReport returns the record of the current run as a JSON document
+func (t *Timer) ExportReport() ([]byte, error) {
+	return json.Marshal(t.Report())
+}
+
+// Completed reports whether the run was finished
+// a run is finished if the timer was stopped and no subtimer is still waiting to finish
+func (r Report) Completed() bool {
+	if r.State != Stopped {
+		return false
+	}
+	for _, s := range r.Subtimers {
+		if s.State != Stopped && s.State != Forfeited && s.State != Skipped {
+			return false
+		}
+	}
+
+	return true
+}
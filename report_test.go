@@ -0,0 +1,82 @@
+package timer_test
+
+import "encoding/json"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestReport(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	clock := timertest.NewClock(start)
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2, 3), timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+	defer tm.Close()
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second, Do: timertest.Pause},
+		timertest.Step{After: 3 * time.Second, Do: timertest.Resume},
+		timertest.Step{After: 2 * time.Second, Do: timertest.StopSubTimer(3)},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+	)
+
+	r := tm.Report()
+	if r.Completed() {
+		t.Errorf("report of a running timer is completed")
+	}
+	if r.ActiveTime != 5*time.Second || r.WallTime != 8*time.Second {
+		t.Errorf("active time is %v and wall time %v, want 5s and 8s", r.ActiveTime, r.WallTime)
+	}
+	want := []timer.Pause{{Start: start.Add(2 * time.Second), Elapsed: 2 * time.Second, Duration: 3 * time.Second}}
+	if len(r.Pauses) != 1 || r.Pauses[0] != want[0] {
+		t.Errorf("pauses are %+v, want %+v", r.Pauses, want)
+	}
+	// subtimers are ordered by id and splits by time
+	if len(r.Subtimers) != 3 || r.Subtimers[0].ID != 1 || r.Subtimers[1].ID != 2 || r.Subtimers[2].ID != 3 {
+		t.Errorf("subtimers are %+v, want ids 1, 2 and 3", r.Subtimers)
+	}
+	if len(r.Splits) != 2 || r.Splits[0].ID != 3 || r.Splits[0].Time != 4*time.Second || r.Splits[1].ID != 1 || r.Splits[1].Time != 5*time.Second {
+		t.Errorf("splits are %+v, want 3 at 4s and 1 at 5s", r.Splits)
+	}
+	for _, e := range r.Events {
+		if e.Type == timer.EventTick {
+			t.Errorf("report contains a tick event")
+		}
+	}
+
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.StopSubTimer(2)}, timertest.Step{Do: timertest.Stop})
+	r = tm.Report()
+	if !r.Completed() {
+		t.Errorf("report of a finished run is not completed")
+	}
+	if r.FinalTime != 5*time.Second {
+		t.Errorf("final time is %v, want 5s", r.FinalTime)
+	}
+}
+
+func TestExportReport(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{Do: timertest.Stop},
+	)
+
+	data, err := tm.ExportReport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r timer.Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("decoding report: %v", err)
+	}
+	if r.State != timer.Stopped || r.FinalTime != time.Second || len(r.Splits) != 1 || r.Splits[0].Time != time.Second {
+		t.Errorf("decoded report %+v, want a stopped run of 1s with one split", r)
+	}
+}
@@ -0,0 +1,82 @@
+package timer
+
+import "time"
+
+// raiseResolution raises the resolution of the system timer. It is replaced in tests
+var raiseResolution = beginHighResolution
+
+// JitterStats holds the measured deviation of the tick intervals from the configured ticker interval
+type JitterStats struct {
+	Samples int           `json:"samples"`
+	Mean    time.Duration `json:"mean"`
+	Max     time.Duration `json:"max"`
+}
+
+// jitter accumulates the deviation of tick intervals
+type jitter struct {
+	samples int
+	total   time.Duration
+	max     time.Duration
+}
+
+func (j *jitter) add(interval, expected time.Duration) {
+	d := interval - expected
+	if d < 0 {
+		d = -d
+	}
+	j.samples++
+	j.total += d
+	if d > j.max {
+		j.max = d
+	}
+}
+
+// SetHighResolution requests a higher resolution of the system timer while the timer is running
+// on Windows the default resolution of 15.6ms makes 10ms ticks inaccurate. On other platforms this has no effect.
+// Only works when timer is stopped
+func (t *Timer) SetHighResolution(enabled bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != Stopped {
+		return &StateError{Op: "SetHighResolution", Current: t.state}
+	}
+	t.highResolution = enabled
+
+	return nil
+}
+
+// Jitter returns the measured jitter of the ticks of the current run
+func (t *Timer) Jitter() JitterStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := JitterStats{Samples: t.jitter.samples, Max: t.jitter.max}
+	if s.Samples > 0 {
+		s.Mean = t.jitter.total / time.Duration(s.Samples)
+	}
+
+	return s
+}
+
+// acquireResolution raises the system timer resolution if enabled
+func (t *Timer) acquireResolution() error {
+	if !t.highResolution || t.resolutionHeld {
+		return nil
+	}
+	if err := raiseResolution(); err != nil {
+		return err
+	}
+	t.resolutionHeld = true
+
+	return nil
+}
+
+// releaseResolution restores the system timer resolution if it was raised
+func (t *Timer) releaseResolution() {
+	if !t.resolutionHeld {
+		return
+	}
+	endHighResolution()
+	t.resolutionHeld = false
+}
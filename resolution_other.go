@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package timer
+
+// other platforms already provide high resolution timers
+func beginHighResolution() error {
+	return nil
+}
+
+func endHighResolution() {}
@@ -0,0 +1,67 @@
+package timer
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+func TestResumeFailsWhenResolutionCantBeRaised(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		resume func(t *Timer) error
+	}{
+		{"ResumeTimer", []Option{WithResumeAfterStop(false)}, (*Timer).ResumeTimer},
+		{"UndoStopSubTimer", []Option{WithSubtimers(1), WithStopOnSubtimersStop()}, func(t *Timer) error {
+			return t.UndoStopSubTimer(1)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := New(test.opts...)
+			defer tm.Close()
+			go func() {
+				for range tm.Updates {
+				}
+			}()
+			if err := tm.SetHighResolution(true); err != nil {
+				t.Fatal(err)
+			}
+			if err := tm.SetBests(time.Hour, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := tm.ResetTimer(); err != nil {
+				t.Fatal(err)
+			}
+			if err := tm.StartTimer(); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(10 * time.Millisecond)
+			if _, err := tm.StopSubTimer(1); err != nil {
+				if err := tm.StopTimer(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if s := tm.State(); s != Stopped {
+				t.Fatalf("state is %v, want Stopped", s)
+			}
+			pb, _ := tm.PersonalBest()
+
+			failed := errors.New("no resolution")
+			raiseResolution = func() error { return failed }
+			defer func() { raiseResolution = beginHighResolution }()
+
+			if err := test.resume(tm); err == nil {
+				t.Fatalf("resume succeeded, want an error")
+			}
+			if s := tm.State(); s != Stopped {
+				t.Errorf("state is %v after the failed resume, want Stopped", s)
+			}
+			if d, _ := tm.PersonalBest(); d != pb {
+				t.Errorf("personal best is %v after the failed resume, want %v", d, pb)
+			}
+		})
+	}
+}
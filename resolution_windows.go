@@ -0,0 +1,33 @@
+//go:build windows
+// +build windows
+
+package timer
+
+import "fmt"
+
+import "syscall"
+
+// highResolutionPeriod is the requested timer resolution in milliseconds
+const highResolutionPeriod = 1
+
+var (
+	winmm           = syscall.NewLazyDLL("winmm.dll")
+	timeBeginPeriod = winmm.NewProc("timeBeginPeriod")
+	timeEndPeriod   = winmm.NewProc("timeEndPeriod")
+)
+
+func beginHighResolution() error {
+	if err := timeBeginPeriod.Find(); err != nil {
+		return err
+	}
+	// timeBeginPeriod returns TIMERR_NOERROR (0) on success
+	if r, _, _ := timeBeginPeriod.Call(highResolutionPeriod); r != 0 {
+		return fmt.Errorf("timeBeginPeriod failed with code %v", r)
+	}
+
+	return nil
+}
+
+func endHighResolution() {
+	timeEndPeriod.Call(highResolutionPeriod)
+}
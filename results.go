@@ -0,0 +1,96 @@
+package timer
+
+import "sort"
+
+import "time"
+
+// RaceEntry is the result of a single subtimer in a race
+type RaceEntry struct {
+	ID int `json:"id"`
+	// Rank is the 1 based placement of the subtimer. It is 0 for subtimers which didn't finish
+	Rank  int           `json:"rank"`
+	State State         `json:"state"`
+	Time  time.Duration `json:"time"`
+	// Offset is the stream delay of the runner and Adjustment the sum of all ledger adjustments
+	// Compensated is the time corrected by both
+	Offset      time.Duration `json:"offset,omitempty"`
+	Adjustment  time.Duration `json:"adjustment,omitempty"`
+	Compensated time.Duration `json:"compensated"`
+	// Legs holds the legs of relay subtimers
+	Legs []Leg `json:"legs,omitempty"`
+	// Splits holds the times at the shared segments
+	Splits []time.Duration `json:"splits,omitempty"`
+	// Name is the human readable name of the subtimer if it has one
+	Name string `json:"name,omitempty"`
+	// Seed and Bracket hold the tournament metadata of the subtimer
+	Seed    int    `json:"seed,omitempty"`
+	Bracket string `json:"bracket,omitempty"`
+	// Provisional is set for recorded times which haven't been adjudicated yet
+	Provisional bool   `json:"provisional"`
+	Note        string `json:"note,omitempty"`
+}
+
+// RaceResult holds the ranked results of all subtimers of a race
+type RaceResult struct {
+	// Entries are ordered by rank, subtimers which didn't finish are last
+	Entries []RaceEntry `json:"entries"`
+}
+
+// RaceResult ranks the subtimers of the current run by their compensated times
+// subtimers with equal times share a rank and are ordered by seed
+func (t *Timer) RaceResult() RaceResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r := RaceResult{Entries: make([]RaceEntry, 0, len(t.subtimers))}
+	for id, s := range t.subtimers {
+		r.Entries = append(r.Entries, RaceEntry{
+			ID:          id,
+			State:       s.state,
+			Time:        s.Time,
+			Offset:      s.offset,
+			Adjustment:  s.adjustment,
+			Compensated: s.total(),
+			Legs:        s.copyLegs(),
+			Splits:      s.copySplits(),
+			Name:        s.name,
+			Seed:        s.seed,
+			Bracket:     s.bracket,
+			Provisional: s.provisional(),
+			Note:        s.note,
+		})
+	}
+
+	rankEntries(r.Entries)
+
+	return r
+}
+
+// rankEntries orders entries by their compensated times and assigns their ranks
+// entries with equal times share a rank and are ordered by seed
+func rankEntries(entries []RaceEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.State == Stopped) != (b.State == Stopped) {
+			return a.State == Stopped
+		}
+		if a.State == Stopped && a.Compensated != b.Compensated {
+			return a.Compensated < b.Compensated
+		}
+		if a.Seed != b.Seed {
+			return seedBefore(a.Seed, b.Seed)
+		}
+		return a.ID < b.ID
+	})
+
+	for i := range entries {
+		e := &entries[i]
+		if e.State != Stopped {
+			continue
+		}
+		e.Rank = i + 1
+		if i > 0 && entries[i-1].State == Stopped && entries[i-1].Compensated == e.Compensated {
+			e.Rank = entries[i-1].Rank
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package timer
+
+import "fmt"
+
+import "io"
+
+import "sync"
+
+import "time"
+
+// Sample is a single point of a sampled time series
+type Sample struct {
+	Elapsed time.Duration
+	// Delta is the predicted difference to the comparison final time. It is 0 if no comparison is set
+	Delta time.Duration
+}
+
+// Sampler records the elapsed time of a timer at a fixed rate
+// samples are taken in timer time, so pauses don't produce gaps or duplicate samples
+type Sampler struct {
+	rate    time.Duration
+	sub     *Subscription
+	w       io.Writer
+	mu      sync.Mutex
+	samples []Sample
+	err     error
+	done    chan struct{}
+}
+
+// NewSampler starts sampling the timer every rate of elapsed time into a slice
+func (t *Timer) NewSampler(rate time.Duration) (*Sampler, error) {
+	return t.newSampler(rate, nil)
+}
+
+// NewSamplerWriter starts sampling the timer every rate of elapsed time
+// each sample is written to w as a line of "elapsed,delta" in milliseconds instead of being kept in memory
+func (t *Timer) NewSamplerWriter(rate time.Duration, w io.Writer) (*Sampler, error) {
+	if w == nil {
+		return nil, fmt.Errorf("Writer for sampler must not be nil")
+	}
+
+	return t.newSampler(rate, w)
+}
+
+func (t *Timer) newSampler(rate time.Duration, w io.Writer) (*Sampler, error) {
+	if rate <= 0 {
+		return nil, fmt.Errorf("Only positive values for rate are allowed")
+	}
+
+	s := &Sampler{
+		rate: rate,
+		sub:  t.Subscribe(WithFilter(TicksOnly())),
+		w:    w,
+		done: make(chan struct{}),
+	}
+	t.mu.Lock()
+	t.goWorker(roleSampler, s.run)
+	t.mu.Unlock()
+
+	return s, nil
+}
+
+// Stop stops sampling and returns the recorded samples
+// the returned error is the first error which occurred while writing samples
+func (s *Sampler) Stop() ([]Sample, error) {
+	s.sub.Close()
+	<-s.done
+
+	return s.Samples(), s.err
+}
+
+// Samples returns a copy of the samples recorded so far
+// it is always empty for samplers writing to an io.Writer
+func (s *Sampler) Samples() []Sample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	samples := make([]Sample, len(s.samples))
+	copy(samples, s.samples)
+
+	return samples
+}
+
+func (s *Sampler) run() {
+	defer close(s.done)
+	next := time.Duration(0)
+	for e := range s.sub.C {
+		if e.Type != EventTick || e.Elapsed < next {
+			continue
+		}
+
+		s.record(Sample{Elapsed: e.Elapsed, Delta: e.Delta})
+		next = e.Elapsed - e.Elapsed%s.rate + s.rate
+	}
+}
+
+func (s *Sampler) record(sample Sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w == nil {
+		s.samples = append(s.samples, sample)
+		return
+	}
+	if s.err != nil {
+		return
+	}
+	_, s.err = fmt.Fprintf(s.w, "%d,%d\n", sample.Elapsed.Milliseconds(), sample.Delta.Milliseconds())
+}
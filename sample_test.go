@@ -0,0 +1,67 @@
+package timer_test
+
+import "bytes"
+
+import "strings"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+func TestSamplerWithoutUpdatesReader(t *testing.T) {
+	const rate = 20 * time.Millisecond
+
+	tests := []struct {
+		name   string
+		writer bool
+	}{
+		{"in memory", false},
+		{"writer", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := timer.New()
+			defer tm.Close()
+			if err := tm.ResetTimer(); err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			var s *timer.Sampler
+			var err error
+			if test.writer {
+				s, err = tm.NewSamplerWriter(rate, &buf)
+			} else {
+				s, err = tm.NewSampler(rate)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tm.StartTimer(); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(300 * time.Millisecond)
+			samples, err := s.Stop()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			n := len(samples)
+			if test.writer {
+				n = strings.Count(buf.String(), "\n")
+			}
+			// 300ms at a rate of 20ms are 15 samples, a stalled timer only yields one
+			if n < 5 {
+				t.Errorf("recorded %v samples in 300ms, want at least 5", n)
+			}
+			for i := 1; i < len(samples); i++ {
+				if samples[i].Elapsed-samples[i-1].Elapsed < rate/2 {
+					t.Errorf("samples %v and %v are closer than the rate: %v, %v", i-1, i, samples[i-1].Elapsed, samples[i].Elapsed)
+				}
+			}
+		})
+	}
+}
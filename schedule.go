@@ -0,0 +1,75 @@
+package timer
+
+import "time"
+
+// StartTimerAt arms the timer to start automatically at the instant ts
+// until then EventCountdown is emitted on every update with the time left until the start. If ts has already passed
+// the timer is started immediately and counts the time since ts. Only possible when timer is in Reset state
+func (t *Timer) StartTimerAt(ts time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if !t.checkValidState(startOp) {
+		return t.logFailed("StartTimerAt", &StateError{Op: "StartTimerAt", Current: t.state})
+	}
+	now := t.clock.Now()
+
+	return t.scheduleStart("StartTimerAt", ts, ts.Round(0).Sub(now.Round(0)))
+}
+
+// ScheduledStart returns the instant of a pending scheduled start
+// ok is false if no start is pending
+func (t *Timer) ScheduledStart() (at time.Time, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.scheduledStart == nil {
+		return time.Time{}, false
+	}
+
+	return t.startCountdown.target, true
+}
+
+// CancelScheduledStart cancels a start scheduled by StartTimerAt or StartTimerAtSynced
+// it returns false if no start was pending
+func (t *Timer) CancelScheduledStart() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stopScheduledStart()
+}
+
+// scheduleStart starts the timer at the instant at of the clock of the timer which is delay from now
+// a previously scheduled start is replaced
+func (t *Timer) scheduleStart(op string, at time.Time, delay time.Duration) error {
+	if delay <= 0 {
+		return t.startTimerAtLocked(at)
+	}
+
+	t.stopScheduledStart()
+	t.startCountdown = t.countdownLocked(at, true)
+	t.scheduledStart = t.clock.AfterFunc(delay, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.stopScheduledStart()
+		t.logFailed(op, t.startTimerAtLocked(at))
+	})
+
+	return nil
+}
+
+func (t *Timer) stopScheduledStart() bool {
+	if t.scheduledStart == nil {
+		return false
+	}
+	stopped := t.scheduledStart.Stop()
+	t.scheduledStart = nil
+	t.startCountdown.Stop()
+	t.startCountdown = nil
+
+	return stopped
+}
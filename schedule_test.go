@@ -0,0 +1,86 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestStartTimerAt(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	clock := timertest.NewClock(start)
+	tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+	defer tm.Close()
+	sub := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventCountdown, timer.EventStarted}}), timer.WithOverflow(timer.Unbounded))
+
+	at := start.Add(3 * time.Second)
+	if err := tm.StartTimerAt(at); err != nil {
+		t.Fatal(err)
+	}
+	if ts, ok := tm.ScheduledStart(); !ok || !ts.Equal(at) {
+		t.Errorf("scheduled start is %v, %v, want %v", ts, ok, at)
+	}
+	clock.Advance(time.Second)
+	if e := timertest.AssertEmitsType(t, sub.C, timer.EventCountdown, 0); e.Remaining != 2*time.Second {
+		t.Errorf("countdown has %v remaining, want 2s", e.Remaining)
+	}
+	if s := tm.State(); s != timer.Reset {
+		t.Errorf("timer is %v before the scheduled start, want reset", s)
+	}
+
+	clock.Advance(2 * time.Second)
+	waitState(t, tm, timer.Running, time.Second)
+	if e := timertest.AssertEmitsType(t, sub.C, timer.EventStarted, 0); !e.Time.Equal(at) {
+		t.Errorf("started at %v, want %v", e.Time, at)
+	}
+	if _, ok := tm.ScheduledStart(); ok {
+		t.Errorf("start still scheduled after the timer started")
+	}
+	clock.Advance(2 * time.Second)
+	if d := tm.Elapsed(); d != 2*time.Second {
+		t.Errorf("elapsed is %v, want 2s", d)
+	}
+}
+
+func TestStartTimerAtPassedInstant(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	// the time since the instant is counted
+	if err := tm.StartTimerAt(clock.Now().Add(-2 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if s, d := tm.State(), tm.Elapsed(); s != timer.Running || d != 2*time.Second {
+		t.Errorf("timer is %v at %v, want running at 2s", s, d)
+	}
+	var stateErr *timer.StateError
+	if err := tm.StartTimerAt(clock.Now().Add(time.Second)); !errors.As(err, &stateErr) {
+		t.Errorf("scheduling a start of a running timer returned %v, want a state error", err)
+	}
+}
+
+func TestCancelScheduledStart(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	if tm.CancelScheduledStart() {
+		t.Errorf("canceled a start which wasn't scheduled")
+	}
+	if err := tm.StartTimerAt(clock.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if !tm.CancelScheduledStart() {
+		t.Errorf("canceling the scheduled start returned false")
+	}
+	clock.Advance(2 * time.Second)
+	timertest.AssertNoEmit(t, tm.Subscribe(timer.WithFilter(timer.StateChangesOnly())).C, 10*time.Millisecond)
+	if s := tm.State(); s != timer.Reset {
+		t.Errorf("timer is %v after canceling the start, want reset", s)
+	}
+}
@@ -0,0 +1,32 @@
+package timer
+
+import "fmt"
+
+// SetSubTimerSeed sets the seed number and bracket position of a subtimer
+// seeds break ties in results and leaderboards, lower seeds are ranked first. A seed of 0 means unseeded
+func (t *Timer) SetSubTimerSeed(id int, seed int, bracket string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if seed < 0 {
+		return fmt.Errorf("Only positive values for seed are allowed")
+	}
+	s.seed = seed
+	s.bracket = bracket
+
+	return nil
+}
+
+// seedBefore reports whether seed a is ordered before seed b
+// unseeded entries are ordered after all seeded ones
+func seedBefore(a, b int) bool {
+	if a == 0 || b == 0 {
+		return a != 0 && b == 0
+	}
+
+	return a < b
+}
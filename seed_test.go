@@ -0,0 +1,59 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestSubTimerSeed(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2, 3, 4))
+	defer tm.Close()
+
+	if err := tm.SetSubTimerSeed(1, -1, ""); err == nil {
+		t.Errorf("setting a negative seed succeeded, want an error")
+	}
+	if err := tm.SetSubTimerSeed(5, 1, ""); err == nil {
+		t.Errorf("seeding an unknown subtimer succeeded, want an error")
+	}
+	// subtimer 1 stays unseeded
+	seeds := map[int]int{2: 3, 3: 1, 4: 2}
+	for id, seed := range seeds {
+		if err := tm.SetSubTimerSeed(id, seed, "upper"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 1, 2 and 3 tie, 4 doesn't finish
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{Do: timertest.StopSubTimer(2)},
+		timertest.Step{Do: timertest.StopSubTimer(3)},
+	)
+
+	want := []struct {
+		id, rank, seed int
+	}{
+		{3, 1, 1},
+		{2, 1, 3},
+		{1, 1, 0},
+		{4, 0, 2},
+	}
+	entries := tm.RaceResult().Entries
+	if len(entries) != len(want) {
+		t.Fatalf("result has %v entries, want %v", len(entries), len(want))
+	}
+	for i, e := range entries {
+		w := want[i]
+		if e.ID != w.id || e.Rank != w.rank || e.Seed != w.seed {
+			t.Errorf("entry %v is subtimer %v ranked %v with seed %v, want subtimer %v ranked %v with seed %v", i, e.ID, e.Rank, e.Seed, w.id, w.rank, w.seed)
+		}
+		if e.Seed != 0 && e.Bracket != "upper" {
+			t.Errorf("entry %v is in bracket %q, want upper", i, e.Bracket)
+		}
+	}
+}
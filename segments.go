@@ -0,0 +1,115 @@
+package timer
+
+import "fmt"
+
+import "sort"
+
+import "time"
+
+// SplitStanding is the position of a single subtimer at a shared split
+type SplitStanding struct {
+	ID   int           `json:"id"`
+	Time time.Duration `json:"time"`
+	// Compensated is Time corrected by the stream delay of the runner. It is used for ranking
+	Compensated time.Duration `json:"compensated"`
+	Rank        int           `json:"rank"`
+	// Behind is the compensated difference to the leader at this split
+	Behind time.Duration `json:"behind"`
+}
+
+// SegmentStandings holds the standings of all subtimers which reached a shared split
+type SegmentStandings struct {
+	Segment   string          `json:"segment"`
+	Standings []SplitStanding `json:"standings"`
+}
+
+// SetSegments sets the segments shared by all subtimers
+// only possible when timer is in Reset state
+func (t *Timer) SetSegments(names ...string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != Reset {
+		return &StateError{Op: "SetSegments", Current: t.state}
+	}
+	t.segments = append([]string(nil), names...)
+
+	return nil
+}
+
+// SplitSubTimer records the split of a subtimer for its next shared segment
+// splitting the last segment stops the subtimer. Only works when subtimer and timer are running
+func (t *Timer) SplitSubTimer(id int) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	d, err := t.splitSubTimerLocked(id)
+
+	return d, t.logFailed("SplitSubTimer", err, "subtimer", id)
+}
+
+func (t *Timer) splitSubTimerLocked(id int) (time.Duration, error) {
+	if t.closed {
+		return 0, ErrClosed
+	}
+	t.refreshElapsed()
+	s, ok := t.subtimers[id]
+	if !ok {
+		return 0, errSubtimerNotFound(id)
+	}
+	if t.state != Running || s.state != Running {
+		return 0, t.subtimerStateError("SplitSubTimer", s)
+	}
+	if len(s.splits) >= len(t.segments) {
+		return 0, fmt.Errorf("Subtimer with id %v has no segments left", id)
+	}
+
+	split := t.roundFrames(t.subtimerElapsed(s))
+	s.splits = append(s.splits, split)
+	t.emit(Event{Type: EventSubtimerSplit, Elapsed: t.elapsed, Subtimer: intPtr(id), Segment: intPtr(len(s.splits) - 1)})
+	if len(s.splits) == len(t.segments) {
+		return t.stopSubTimerLocked(id)
+	}
+
+	return split, nil
+}
+
+// SplitMatrix returns the standings at every shared split in segment order
+func (t *Timer) SplitMatrix() []SegmentStandings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	matrix := make([]SegmentStandings, len(t.segments))
+	for i, name := range t.segments {
+		standings := make([]SplitStanding, 0)
+		for id, s := range t.subtimers {
+			if i < len(s.splits) {
+				standings = append(standings, SplitStanding{ID: id, Time: s.splits[i], Compensated: s.compensate(s.splits[i])})
+			}
+		}
+		sort.Slice(standings, func(a, b int) bool {
+			if standings[a].Compensated == standings[b].Compensated {
+				return standings[a].ID < standings[b].ID
+			}
+			return standings[a].Compensated < standings[b].Compensated
+		})
+		for j := range standings {
+			standings[j].Rank = j + 1
+			standings[j].Behind = standings[j].Compensated - standings[0].Compensated
+			if j > 0 && standings[j].Compensated == standings[j-1].Compensated {
+				standings[j].Rank = standings[j-1].Rank
+			}
+		}
+		matrix[i] = SegmentStandings{Segment: name, Standings: standings}
+	}
+
+	return matrix
+}
+
+func (s *subtimer) copySplits() []time.Duration {
+	if len(s.splits) == 0 {
+		return nil
+	}
+
+	return append([]time.Duration(nil), s.splits...)
+}
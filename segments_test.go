@@ -0,0 +1,69 @@
+package timer_test
+
+import "errors"
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func split(id int) func(tm *timer.Timer) error {
+	return func(tm *timer.Timer) error {
+		_, err := tm.SplitSubTimer(id)
+		return err
+	}
+}
+
+func TestSplitMatrix(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2, 3))
+	defer tm.Close()
+	if err := tm.SetSegments("Level", "Boss"); err != nil {
+		t.Fatal(err)
+	}
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second, Do: split(1)},
+		timertest.Step{After: time.Second, Do: split(2)},
+		timertest.Step{Do: split(3)},
+		timertest.Step{After: 2 * time.Second, Do: split(2)},
+		timertest.Step{After: time.Second, Do: split(1)},
+	)
+
+	want := []timer.SegmentStandings{
+		{Segment: "Level", Standings: []timer.SplitStanding{
+			{ID: 1, Time: 2 * time.Second, Compensated: 2 * time.Second, Rank: 1},
+			{ID: 2, Time: 3 * time.Second, Compensated: 3 * time.Second, Rank: 2, Behind: time.Second},
+			{ID: 3, Time: 3 * time.Second, Compensated: 3 * time.Second, Rank: 2, Behind: time.Second},
+		}},
+		{Segment: "Boss", Standings: []timer.SplitStanding{
+			{ID: 2, Time: 5 * time.Second, Compensated: 5 * time.Second, Rank: 1},
+			{ID: 1, Time: 6 * time.Second, Compensated: 6 * time.Second, Rank: 2, Behind: time.Second},
+		}},
+	}
+	if got := tm.SplitMatrix(); !reflect.DeepEqual(got, want) {
+		t.Errorf("split matrix is %+v, want %+v", got, want)
+	}
+
+	// splitting the last segment stopped the subtimer
+	info, err := tm.SubTimer(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State != timer.Stopped || info.Time != 6*time.Second {
+		t.Errorf("subtimer 1 is %v at %v, want %v at 6s", info.State, info.Time, timer.Stopped)
+	}
+	if err := split(1)(tm); err == nil {
+		t.Errorf("splitting a finished subtimer succeeded, want an error")
+	}
+	var stateErr *timer.StateError
+	if err := tm.SetSegments("Level"); !errors.As(err, &stateErr) {
+		t.Errorf("setting segments of a running timer returned %v, want a state error", err)
+	}
+}
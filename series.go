@@ -0,0 +1,140 @@
+package timer
+
+import "sort"
+
+import "sync"
+
+import "time"
+
+// Aggregate combines the times of a participant over the heats of a series into a single score
+// times holds the compensated finishing times in heat order. ok is false if the times are not sufficient for a score
+type Aggregate func(times []time.Duration) (score time.Duration, ok bool)
+
+// BestOf scores a participant with the best time of their first n finishes
+func BestOf(n int) Aggregate {
+	return func(times []time.Duration) (time.Duration, bool) {
+		if len(times) == 0 {
+			return 0, false
+		}
+		if len(times) > n {
+			times = times[:n]
+		}
+		best := times[0]
+		for _, d := range times[1:] {
+			if d < best {
+				best = d
+			}
+		}
+
+		return best, true
+	}
+}
+
+// SumOf scores a participant with the sum of their first n finishes
+// participants with less than n finishes don't get a score
+func SumOf(n int) Aggregate {
+	return func(times []time.Duration) (time.Duration, bool) {
+		if len(times) < n {
+			return 0, false
+		}
+		var sum time.Duration
+		for _, d := range times[:n] {
+			sum += d
+		}
+
+		return sum, true
+	}
+}
+
+// SeriesStanding is the position of a single participant in a series
+type SeriesStanding struct {
+	ID int `json:"id"`
+	// Rank is 0 for participants without a score
+	Rank     int           `json:"rank"`
+	Score    time.Duration `json:"score"`
+	Scored   bool          `json:"scored"`
+	Heats    int           `json:"heats"`
+	Finishes int           `json:"finishes"`
+}
+
+// Series tracks the results of multiple heats and aggregates them into series standings
+// participants are identified by their subtimer id across heats
+type Series struct {
+	aggregate Aggregate
+	mu        sync.Mutex
+	heats     []RaceResult
+}
+
+// NewSeries returns a new series scoring participants with aggregate
+func NewSeries(aggregate Aggregate) *Series {
+	return &Series{aggregate: aggregate}
+}
+
+// AddHeat adds the results of a heat to the series
+func (s *Series) AddHeat(r RaceResult) {
+	s.mu.Lock()
+	s.heats = append(s.heats, r)
+	s.mu.Unlock()
+}
+
+// AddTimer adds the results of the current run of t as a heat to the series
+func (s *Series) AddTimer(t *Timer) {
+	s.AddHeat(t.RaceResult())
+}
+
+// Heats returns the results of all heats in the order they were added
+func (s *Series) Heats() []RaceResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]RaceResult(nil), s.heats...)
+}
+
+// Standings returns the series standings ordered by rank, participants without a score are last
+func (s *Series) Standings() []SeriesStanding {
+	times := make(map[int][]time.Duration)
+	entries := make(map[int]*SeriesStanding)
+	for _, heat := range s.Heats() {
+		for _, e := range heat.Entries {
+			st, ok := entries[e.ID]
+			if !ok {
+				st = &SeriesStanding{ID: e.ID}
+				entries[e.ID] = st
+			}
+			st.Heats++
+			if e.Rank > 0 {
+				st.Finishes++
+				times[e.ID] = append(times[e.ID], e.Compensated)
+			}
+		}
+	}
+
+	standings := make([]SeriesStanding, 0, len(entries))
+	for id, st := range entries {
+		st.Score, st.Scored = s.aggregate(times[id])
+		standings = append(standings, *st)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Scored != b.Scored {
+			return a.Scored
+		}
+		if a.Scored && a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		return a.ID < b.ID
+	})
+
+	for i := range standings {
+		st := &standings[i]
+		if !st.Scored {
+			continue
+		}
+		st.Rank = i + 1
+		if i > 0 && standings[i-1].Scored && standings[i-1].Score == st.Score {
+			st.Rank = standings[i-1].Rank
+		}
+	}
+
+	return standings
+}
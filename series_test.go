@@ -0,0 +1,99 @@
+package timer_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+// seriesHeat returns the result of a heat in which the participants finished with the given times in seconds, 0 didn't finish
+func seriesHeat(times map[int]int) timer.RaceResult {
+	var r timer.RaceResult
+	for id, s := range times {
+		e := timer.RaceEntry{ID: id, State: timer.Forfeited}
+		if s > 0 {
+			e.State = timer.Stopped
+			e.Rank = 1
+			e.Compensated = time.Duration(s) * time.Second
+		}
+		r.Entries = append(r.Entries, e)
+	}
+
+	return r
+}
+
+func TestSeriesStandings(t *testing.T) {
+	heats := []timer.RaceResult{
+		seriesHeat(map[int]int{1: 30, 2: 25, 3: 40}),
+		seriesHeat(map[int]int{1: 20, 2: 0, 3: 35}),
+		seriesHeat(map[int]int{1: 50, 2: 25, 3: 0, 4: 10}),
+	}
+
+	tests := []struct {
+		name      string
+		aggregate timer.Aggregate
+		want      []timer.SeriesStanding
+	}{
+		{
+			name:      "best of 2",
+			aggregate: timer.BestOf(2),
+			want: []timer.SeriesStanding{
+				{ID: 4, Rank: 1, Score: 10 * time.Second, Scored: true, Heats: 1, Finishes: 1},
+				{ID: 1, Rank: 2, Score: 20 * time.Second, Scored: true, Heats: 3, Finishes: 3},
+				{ID: 2, Rank: 3, Score: 25 * time.Second, Scored: true, Heats: 3, Finishes: 2},
+				{ID: 3, Rank: 4, Score: 35 * time.Second, Scored: true, Heats: 3, Finishes: 2},
+			},
+		},
+		{
+			name:      "sum of 2",
+			aggregate: timer.SumOf(2),
+			want: []timer.SeriesStanding{
+				// 1 and 2 tie and share the rank
+				{ID: 1, Rank: 1, Score: 50 * time.Second, Scored: true, Heats: 3, Finishes: 3},
+				{ID: 2, Rank: 1, Score: 50 * time.Second, Scored: true, Heats: 3, Finishes: 2},
+				{ID: 3, Rank: 3, Score: 75 * time.Second, Scored: true, Heats: 3, Finishes: 2},
+				{ID: 4, Heats: 1, Finishes: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := timer.NewSeries(test.aggregate)
+			for _, h := range heats {
+				s.AddHeat(h)
+			}
+			if got := s.Standings(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSeriesAddTimer(t *testing.T) {
+	s := timer.NewSeries(timer.SumOf(2))
+	for _, stops := range [][]int{{1, 2}, {2, 1}} {
+		clock := timertest.NewClock(time.Now())
+		tm := newTimer(t, clock, timer.WithSubtimers(1, 2))
+		timertest.Run(t, tm, clock,
+			timertest.Step{Do: timertest.Start},
+			timertest.Step{After: 10 * time.Second, Do: timertest.StopSubTimer(stops[0])},
+			timertest.Step{After: 5 * time.Second, Do: timertest.StopSubTimer(stops[1])},
+		)
+		s.AddTimer(tm)
+		tm.Close()
+	}
+
+	if n := len(s.Heats()); n != 2 {
+		t.Fatalf("series has %v heats, want 2", n)
+	}
+	for _, st := range s.Standings() {
+		if st.Score != 25*time.Second || st.Rank != 1 {
+			t.Errorf("participant %v has score %v and rank %v, want %v and 1", st.ID, st.Score, st.Rank, 25*time.Second)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package timer
+
+import "encoding/json"
+
+import "fmt"
+
+import "io"
+
+import "sort"
+
+import "sync"
+
+import "time"
+
+// Simulation drives a timer through virtual time in fixed steps and records every emitted event
+// the timer doesn't run its own goroutine, so identical simulations produce identical events
+type Simulation struct {
+	// Timer is the simulated timer. It is in Reset state when the simulation is created
+	Timer   *Timer
+	clock   *simClock
+	step    time.Duration
+	actions []simAction
+	events  []Event
+	err     error
+}
+
+type simAction struct {
+	at time.Duration
+	do func(t *Timer) error
+}
+
+// NewSimulation returns a new simulation starting at the wall clock time start and ticking every step
+func NewSimulation(start time.Time, step time.Duration) (*Simulation, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("Only positive values for step are allowed")
+	}
+
+	s := &Simulation{
+		Timer: New(),
+		clock: &simClock{now: start},
+		step:  step,
+	}
+	s.Timer.manual = true
+	s.Timer.SetClock(s.clock)
+	// the simulation ticks every step, so intervals and thresholds derived from them follow it
+	s.Timer.updateInterval = step
+	s.Timer.tickerInterval = step
+	s.Timer.events.record = func(e Event) {
+		s.events = append(s.events, e)
+	}
+	s.Timer.ResetTimer()
+
+	return s, nil
+}
+
+// At schedules do to be called once the simulation reached at
+// actions scheduled for the same time are called in the order they were scheduled, before the tick at that time
+func (s *Simulation) At(at time.Duration, do func(t *Timer) error) {
+	s.actions = append(s.actions, simAction{at: at, do: do})
+}
+
+// Run advances the simulation by total and returns all events emitted so far
+// it stops at the first action returning an error
+func (s *Simulation) Run(total time.Duration) ([]Event, error) {
+	sort.SliceStable(s.actions, func(i, j int) bool {
+		return s.actions[i].at < s.actions[j].at
+	})
+
+	end := s.clock.offset + total
+	for s.err == nil && s.clock.offset <= end {
+		s.clock.fireAlarms()
+		for len(s.actions) > 0 && s.actions[0].at <= s.clock.offset {
+			action := s.actions[0]
+			s.actions = s.actions[1:]
+			if err := action.do(s.Timer); err != nil {
+				s.err = fmt.Errorf("Action at %v failed: %v", action.at, err)
+				break
+			}
+		}
+		s.Timer.mu.Lock()
+		s.Timer.advance(s.clock.Now())
+		s.Timer.mu.Unlock()
+		if s.clock.offset+s.step > end {
+			break
+		}
+		s.clock.advance(s.step)
+	}
+
+	return s.Events(), s.err
+}
+
+// Events returns all events emitted so far
+func (s *Simulation) Events() []Event {
+	return append([]Event(nil), s.events...)
+}
+
+// Encode writes all events emitted so far to w as one JSON document per line
+// the output is suitable for golden file comparisons
+func (s *Simulation) Encode(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, e := range s.events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// simClock is the virtual clock of a simulation. Alarms are fired synchronously by the simulation
+type simClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	offset time.Duration
+	alarms []*simAlarm
+}
+
+func (c *simClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTicker returns a ticker which never fires because the simulation ticks the timer itself
+func (c *simClock) NewTicker(d time.Duration) Ticker {
+	return simTicker{}
+}
+
+func (c *simClock) AfterFunc(d time.Duration, f func()) Alarm {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a := &simAlarm{at: c.offset + d, f: f, clock: c}
+	c.alarms = append(c.alarms, a)
+
+	return a
+}
+
+func (c *simClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.offset += d
+}
+
+// fireAlarms calls all due alarms in the order they are due
+// alarms are called without holding the lock so they can schedule new alarms
+func (c *simClock) fireAlarms() {
+	for {
+		a := c.nextDue()
+		if a == nil {
+			return
+		}
+		a.f()
+	}
+}
+
+// nextDue removes and returns the next due alarm or nil if none is due
+func (c *simClock) nextDue() *simAlarm {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sort.SliceStable(c.alarms, func(i, j int) bool {
+		return c.alarms[i].at < c.alarms[j].at
+	})
+	if len(c.alarms) == 0 || c.alarms[0].at > c.offset {
+		return nil
+	}
+	a := c.alarms[0]
+	c.alarms = c.alarms[1:]
+
+	return a
+}
+
+type simTicker struct{}
+
+func (simTicker) C() <-chan time.Time {
+	return nil
+}
+
+func (simTicker) Stop() {}
+
+type simAlarm struct {
+	at    time.Duration
+	f     func()
+	clock *simClock
+}
+
+func (a *simAlarm) Stop() bool {
+	a.clock.mu.Lock()
+	defer a.clock.mu.Unlock()
+
+	for i, other := range a.clock.alarms {
+		if other == a {
+			a.clock.alarms = append(a.clock.alarms[:i], a.clock.alarms[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
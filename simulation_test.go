@@ -0,0 +1,126 @@
+package timer_test
+
+import "bytes"
+
+import "errors"
+
+import "reflect"
+
+import "strings"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+func TestNewSimulationInvalidStep(t *testing.T) {
+	for _, step := range []time.Duration{0, -time.Second} {
+		if _, err := timer.NewSimulation(time.Now(), step); err == nil {
+			t.Errorf("creating a simulation with step %v succeeded, want an error", step)
+		}
+	}
+}
+
+// simulate runs a simulation which starts, pauses, resumes and stops the timer
+func simulate(t *testing.T, start time.Time) *timer.Simulation {
+	t.Helper()
+
+	s, err := timer.NewSimulation(start, 500*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.At(0, (*timer.Timer).StartTimer)
+	s.At(2*time.Second, (*timer.Timer).ResumeTimer)
+	// actions are called in the order of their time, not the order they were scheduled
+	s.At(time.Second, (*timer.Timer).PauseTimer)
+	s.At(3500*time.Millisecond, (*timer.Timer).StopTimer)
+	if _, err := s.Run(4 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestSimulation(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	s := simulate(t, start)
+	defer s.Timer.Close()
+
+	type change struct {
+		typ     timer.EventType
+		elapsed time.Duration
+	}
+	var changes []change
+	for _, e := range s.Events() {
+		switch e.Type {
+		case timer.EventStarted, timer.EventPaused, timer.EventResumed, timer.EventStopped:
+			changes = append(changes, change{e.Type, e.Elapsed})
+		}
+	}
+	want := []change{
+		{timer.EventStarted, 0},
+		{timer.EventPaused, time.Second},
+		{timer.EventResumed, time.Second},
+		{timer.EventStopped, 2500 * time.Millisecond},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("state changes are %v, want %v", changes, want)
+	}
+	if d := s.Timer.Elapsed(); d != 2500*time.Millisecond {
+		t.Errorf("elapsed is %v, want 2.5s", d)
+	}
+}
+
+func TestSimulationIsDeterministic(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	s := simulate(t, start)
+	defer s.Timer.Close()
+	other := simulate(t, start)
+	defer other.Timer.Close()
+
+	var first, second bytes.Buffer
+	if err := s.Encode(&first); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Encode(&second); err != nil {
+		t.Fatal(err)
+	}
+	if first.Len() == 0 {
+		t.Fatalf("simulation encoded no events")
+	}
+	if first.String() != second.String() {
+		t.Errorf("simulations encoded\n%s\nand\n%s", first.String(), second.String())
+	}
+	// one JSON document per event
+	if lines := strings.Count(first.String(), "\n"); lines != len(s.Events()) {
+		t.Errorf("encoded %v lines, want one per event", lines)
+	}
+}
+
+func TestSimulationActionError(t *testing.T) {
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Timer.Close()
+	errAction := errors.New("action failed")
+	later := false
+	s.At(time.Second, func(*timer.Timer) error { return errAction })
+	s.At(2*time.Second, func(*timer.Timer) error {
+		later = true
+		return nil
+	})
+
+	if _, err := s.Run(5 * time.Second); err == nil || !strings.Contains(err.Error(), errAction.Error()) {
+		t.Errorf("got error %v, want one containing %q", err, errAction)
+	}
+	if later {
+		t.Errorf("action after the failed one was called")
+	}
+	// the simulation stays failed
+	if _, err := s.Run(time.Second); err == nil {
+		t.Errorf("running a failed simulation succeeded, want an error")
+	}
+}
@@ -0,0 +1,35 @@
+//go:build go1.21
+// +build go1.21
+
+package timer
+
+import "context"
+
+import "log/slog"
+
+// WithLogger logs state transitions, subtimer events and failed operations of the timer to l
+// state transitions and failed operations are logged at info level, all other events at debug level
+func WithLogger(l *slog.Logger) Option {
+	return func(t *Timer) {
+		t.logHook = slogHook(l)
+	}
+}
+
+// SetLogger sets the logger of the timer, see WithLogger
+// Setting nil disables logging
+func (t *Timer) SetLogger(l *slog.Logger) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.logHook = slogHook(l)
+}
+
+func slogHook(l *slog.Logger) logHook {
+	if l == nil {
+		return nil
+	}
+
+	return func(level int, msg string, args ...interface{}) {
+		l.Log(context.Background(), slog.Level(level), msg, args...)
+	}
+}
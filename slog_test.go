@@ -0,0 +1,57 @@
+//go:build go1.21
+// +build go1.21
+
+package timer_test
+
+import "bytes"
+
+import "fmt"
+
+import "log/slog"
+
+import "strings"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1), timer.WithLogger(l))
+	defer tm.Close()
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+	)
+	if err := tm.StartTimer(); err == nil {
+		t.Fatal("starting a running timer succeeded")
+	}
+	tm.SetLogger(nil)
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+
+	// state changes and failed operations are logged at info level, other events at debug level
+	want := []string{
+		fmt.Sprintf(`level=INFO msg="Timer event" type=%v elapsed=0s`, timer.EventReset),
+		fmt.Sprintf(`level=INFO msg="Timer event" type=%v elapsed=0s`, timer.EventStarted),
+		fmt.Sprintf(`level=DEBUG msg="Timer event" type=%v elapsed=1s subtimer=1`, timer.EventSubtimerStopped),
+		`level=INFO msg="Operation failed" op=StartTimer state=Running error="StartTimer called with invalid state Running"`,
+	}
+	if got := strings.TrimSpace(buf.String()); got != strings.Join(want, "\n") {
+		t.Errorf("logged\n%v\nwant\n%v", got, strings.Join(want, "\n"))
+	}
+}
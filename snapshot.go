@@ -0,0 +1,346 @@
+package timer
+
+import "encoding/json"
+
+import "fmt"
+
+import "io"
+
+import "time"
+
+// SubtimerSnapshot holds the complete state of a single subtimer
+type SubtimerSnapshot struct {
+	ID    int           `json:"id"`
+	State State         `json:"state"`
+	Time  time.Duration `json:"time"`
+	// Start is the elapsed time of the timer at which the subtimer started
+	Start time.Duration `json:"start"`
+	// Paused is the total time the subtimer has been paused on its own, PausedAt the timer elapsed at the start of the ongoing pause
+	Paused          time.Duration     `json:"paused"`
+	PausedAt        time.Duration     `json:"pausedAt"`
+	PauseBudget     time.Duration     `json:"pauseBudget,omitempty"`
+	ForfeitOnBudget bool              `json:"forfeitOnBudget,omitempty"`
+	BudgetExceeded  bool              `json:"budgetExceeded,omitempty"`
+	Offset          time.Duration     `json:"offset,omitempty"`
+	Adjustment      time.Duration     `json:"adjustment,omitempty"`
+	Throttle        time.Duration     `json:"throttle,omitempty"`
+	Legs            []Leg             `json:"legs,omitempty"`
+	Splits          []time.Duration   `json:"splits,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	Tags            map[string]string `json:"tags,omitempty"`
+	Seed            int               `json:"seed,omitempty"`
+	Bracket         string            `json:"bracket,omitempty"`
+	Gold            bool              `json:"gold,omitempty"`
+	PreviousBest    time.Duration     `json:"previousBest,omitempty"`
+	Adjudicated     bool              `json:"adjudicated,omitempty"`
+	Note            string            `json:"note,omitempty"`
+}
+
+// Snapshot holds the state of a timer and all of its subtimers
+// a timer restored from a snapshot continues the run. Running timers count the time which passed since the snapshot was taken
+type Snapshot struct {
+	// Config is the configuration of the timer. Snapshots without one keep the configuration of the restoring timer
+	Config *ReportConfig `json:"config,omitempty"`
+	State  State         `json:"state"`
+	// StartTime is the wall clock time the run started at, shifted by all pauses
+	StartTime time.Time `json:"startTime"`
+	PauseTime time.Time `json:"pauseTime"`
+	// FirstStart is the wall clock time the run started at and StopTime the time it was stopped at
+	// StopLoss is the wall clock time the run was stopped before it was resumed
+	FirstStart time.Time     `json:"firstStart"`
+	StopTime   time.Time     `json:"stopTime"`
+	StopLoss   time.Duration `json:"stopLoss,omitempty"`
+	Elapsed    time.Duration `json:"elapsed"`
+	// GameLoss is the real time not counted as game time and GamePausedAt the real time the game time was paused at
+	GameLoss     time.Duration `json:"gameLoss,omitempty"`
+	GamePaused   bool          `json:"gamePaused,omitempty"`
+	GamePausedAt time.Duration `json:"gamePausedAt,omitempty"`
+	Pauses       []Pause       `json:"pauses"`
+	Laps         []LapResult   `json:"laps,omitempty"`
+	Segments     []string      `json:"segments,omitempty"`
+	Ledger       []Adjustment  `json:"ledger,omitempty"`
+	// Countdown is the time a countdown timer counts down from and Phase the current phase of a timer counting down phases
+	Countdown time.Duration `json:"countdown,omitempty"`
+	Phase     *Phase        `json:"phase,omitempty"`
+	// AutoStopped is set if the timer was stopped because all subtimers stopped
+	AutoStopped bool `json:"autoStopped,omitempty"`
+	// PersonalBest and BestSegments are the bests across runs. PreviousPersonalBest is the personal best replaced by the last stop if it set a new one
+	PersonalBest         time.Duration         `json:"personalBest,omitempty"`
+	PreviousPersonalBest time.Duration         `json:"previousPersonalBest,omitempty"`
+	PersonalBestImproved bool                  `json:"personalBestImproved,omitempty"`
+	BestSegments         map[int]time.Duration `json:"bestSegments,omitempty"`
+	// Comparison holds the comparison times keyed by subtimer id
+	Comparison map[int]time.Duration `json:"comparison,omitempty"`
+	// Subtimers holds all subtimers ordered by id
+	Subtimers []SubtimerSnapshot `json:"subtimers"`
+}
+
+// Snapshot returns the current state of the timer and all of its subtimers
+func (t *Timer) Snapshot() Snapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	config := t.reportConfig()
+	snap := Snapshot{
+		Config:               &config,
+		State:                t.state,
+		StartTime:            t.startTime,
+		PauseTime:            t.pauseTime,
+		FirstStart:           t.firstStart,
+		StopTime:             t.stopTime,
+		StopLoss:             t.stopLoss,
+		Elapsed:              t.elapsed,
+		GameLoss:             t.gameLoss,
+		GamePaused:           t.gamePaused,
+		GamePausedAt:         t.gamePausedAt,
+		Pauses:               make([]Pause, len(t.pauses)),
+		Laps:                 t.lapsLocked(),
+		Segments:             append([]string(nil), t.segments...),
+		Ledger:               t.ledgerLocked(),
+		Countdown:            t.countdown,
+		Phase:                t.currentPhase(),
+		AutoStopped:          t.autoStopped,
+		PersonalBest:         t.bests.pb,
+		PreviousPersonalBest: t.bests.previousPB,
+		PersonalBestImproved: t.bests.improved,
+		BestSegments:         copyDurations(t.bests.segments),
+		Comparison:           copyDurations(t.comparison),
+		Subtimers:            make([]SubtimerSnapshot, 0, len(t.subtimers)),
+	}
+	copy(snap.Pauses, t.pauses)
+
+	for _, id := range t.subtimerIDs() {
+		s := t.subtimers[id]
+		snap.Subtimers = append(snap.Subtimers, SubtimerSnapshot{
+			ID:              id,
+			State:           s.state,
+			Time:            s.Time,
+			Start:           s.start,
+			Paused:          s.paused,
+			PausedAt:        s.pausedAt,
+			PauseBudget:     s.pauseBudget,
+			ForfeitOnBudget: s.forfeitOnBudget,
+			BudgetExceeded:  s.budgetExceeded,
+			Offset:          s.offset,
+			Adjustment:      s.adjustment,
+			Throttle:        s.throttle,
+			Legs:            s.copyLegs(),
+			Splits:          s.copySplits(),
+			Name:            s.name,
+			Description:     s.description,
+			Tags:            copyTags(s.tags),
+			Seed:            s.seed,
+			Bracket:         s.bracket,
+			Gold:            s.gold,
+			PreviousBest:    s.previousBest,
+			Adjudicated:     s.adjudicated,
+			Note:            s.note,
+		})
+	}
+
+	return snap
+}
+
+// RestoreSnapshot replaces the state of the timer and all subtimers with snap
+// only possible when timer is in Reset or Stopped state. A pending automatic resume of a pause started by PauseFor is not restored.
+// A snapshot of a timer counting down phases can only be restored by a timer counting down the same phases, e.g. created by NewPomodoro with the same config
+func (t *Timer) RestoreSnapshot(snap Snapshot) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if t.state != Reset && t.state != Stopped {
+		return &StateError{Op: "RestoreSnapshot", Current: t.state}
+	}
+	if snap.State < Reset || snap.State > Stopped {
+		return fmt.Errorf("Snapshot has invalid state %v", snap.State)
+	}
+	if snap.State == Paused && (len(snap.Pauses) == 0 || snap.Pauses[len(snap.Pauses)-1].Duration != 0) {
+		return fmt.Errorf("Paused snapshot has no ongoing pause")
+	}
+	if snap.Countdown < 0 {
+		return fmt.Errorf("Snapshot has negative countdown")
+	}
+	if (snap.Phase != nil) != (t.phases != nil) {
+		return fmt.Errorf("Snapshot and timer have to both count down phases or both not")
+	}
+	if snap.Phase != nil {
+		if p, ok := t.phases(snap.Phase.Number - 1); !ok || p != *snap.Phase {
+			return fmt.Errorf("Snapshot has phase %v which is not part of the phases of the timer", snap.Phase.Number)
+		}
+	}
+	if snap.PersonalBest < 0 || snap.PreviousPersonalBest < 0 {
+		return fmt.Errorf("Snapshot has negative personal best")
+	}
+	for id, d := range snap.BestSegments {
+		if d <= 0 {
+			return fmt.Errorf("Snapshot has best segment of subtimer %v which is not positive", id)
+		}
+	}
+
+	if c := snap.Config; c != nil {
+		if c.ContinueCountingWhenStopped && !c.AllowResumeAfterStop {
+			return fmt.Errorf("Snapshot config has ContinueCountingWhenStopped without AllowResumeAfterStop")
+		}
+		if !c.PausePolicy.valid() {
+			return fmt.Errorf("Snapshot config has unknown pause policy %v", c.PausePolicy)
+		}
+		if c.FrameRate < 0 {
+			return fmt.Errorf("Snapshot config has negative frame rate")
+		}
+	}
+
+	subtimers := make(map[int]*subtimer, len(snap.Subtimers))
+	names := make(map[string]bool, len(snap.Subtimers))
+	for _, s := range snap.Subtimers {
+		if _, ok := subtimers[s.ID]; ok {
+			return fmt.Errorf("Snapshot contains subtimer with id %v twice", s.ID)
+		}
+		if s.Name != "" && names[s.Name] {
+			return fmt.Errorf("Snapshot contains subtimer with name %q twice", s.Name)
+		}
+		names[s.Name] = true
+		subtimers[s.ID] = &subtimer{
+			Time:            s.Time,
+			state:           s.State,
+			start:           s.Start,
+			paused:          s.Paused,
+			pausedAt:        s.PausedAt,
+			pauseBudget:     s.PauseBudget,
+			forfeitOnBudget: s.ForfeitOnBudget,
+			budgetExceeded:  s.BudgetExceeded,
+			offset:          s.Offset,
+			adjustment:      s.Adjustment,
+			throttle:        s.Throttle,
+			legs:            append([]Leg(nil), s.Legs...),
+			splits:          append([]time.Duration(nil), s.Splits...),
+			name:            s.Name,
+			description:     s.Description,
+			tags:            copyTags(s.Tags),
+			seed:            s.Seed,
+			bracket:         s.Bracket,
+			gold:            s.Gold,
+			previousBest:    s.PreviousBest,
+			adjudicated:     s.Adjudicated,
+			note:            s.Note,
+		}
+	}
+
+	if c := snap.Config; c != nil {
+		if c.UpdateIntervalDuration > 0 {
+			t.updateInterval = c.UpdateIntervalDuration
+		} else if c.UpdateInterval > 0 {
+			t.updateInterval = time.Duration(c.UpdateInterval) * time.Millisecond
+		}
+		if c.TickerIntervalDuration > 0 {
+			t.tickerInterval = c.TickerIntervalDuration
+		} else if c.TickerInterval > 0 {
+			t.tickerInterval = time.Duration(c.TickerInterval) * time.Millisecond
+		}
+		t.allowResumeAfterStop = c.AllowResumeAfterStop
+		t.continueCountingWhenStopped = c.ContinueCountingWhenStopped
+		t.stopOnSubtimersStop = c.StopOnSubtimersStop
+		t.pausePolicy = c.PausePolicy
+		t.startOffset = c.StartOffset
+		t.target = c.TargetDuration
+		t.frameRate = c.FrameRate
+		t.frameRounding = c.FrameRounding
+	}
+	t.subtimers = subtimers
+	t.startTime = snap.StartTime
+	t.pauseTime = snap.PauseTime
+	t.firstStart = snap.FirstStart
+	t.stopTime = snap.StopTime
+	t.stopLoss = snap.StopLoss
+	t.elapsed = snap.Elapsed
+	t.gameLoss = snap.GameLoss
+	t.gamePaused = snap.GamePaused
+	t.gamePausedAt = snap.GamePausedAt
+	t.seek = t.startOffset
+	if snap.State == Reset {
+		t.seek = snap.Elapsed
+	}
+	t.pauses = append([]Pause(nil), snap.Pauses...)
+	t.laps = append([]LapResult(nil), snap.Laps...)
+	t.segments = append([]string(nil), snap.Segments...)
+	t.ledger = append([]Adjustment(nil), snap.Ledger...)
+	t.countdown = snap.Countdown
+	if snap.Phase != nil {
+		t.phase = *snap.Phase
+	}
+	t.autoStopped = snap.AutoStopped
+	t.bests = bests{
+		pb:         snap.PersonalBest,
+		segments:   copyDurations(snap.BestSegments),
+		improved:   snap.PersonalBestImproved,
+		previousPB: snap.PreviousPersonalBest,
+	}
+	t.comparison = copyDurations(snap.Comparison)
+	t.lastTick = time.Time{}
+	t.lastUpdate = time.Time{}
+	t.state = snap.State
+
+	if t.state == Running || (t.state == Paused && t.pausedUpdates != PausedSilent) {
+		t.startLoop()
+	}
+	t.armAlarms()
+
+	return nil
+}
+
+// MarshalJSON encodes the complete state of the timer as its snapshot
+func (t *Timer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Snapshot())
+}
+
+// UnmarshalJSON restores the timer from a snapshot encoded by MarshalJSON
+// the timer has to be created by New and be in Reset or Stopped state
+func (t *Timer) UnmarshalJSON(data []byte) error {
+	if t.quit == nil {
+		return fmt.Errorf("UnmarshalJSON requires a timer created by New")
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+
+	return t.RestoreSnapshot(snap)
+}
+
+// Save writes the complete state of the timer to w
+// the snapshot holds absolute timestamps, so a timer restored from it counts the time which passed in between
+func (t *Timer) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t.Snapshot())
+}
+
+// Restore creates a new timer with the given options applied and restores the state written by Save from r
+// a running timer continues running, e.g. after the process was restarted
+func Restore(r io.Reader, opts ...Option) (*Timer, error) {
+	var snap Snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("Reading snapshot: %v", err)
+	}
+
+	t := New(opts...)
+	if err := t.RestoreSnapshot(snap); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// copyDurations returns a copy of durations or nil if it is empty
+func copyDurations(durations map[int]time.Duration) map[int]time.Duration {
+	if len(durations) == 0 {
+		return nil
+	}
+	c := make(map[int]time.Duration, len(durations))
+	for id, d := range durations {
+		c[id] = d
+	}
+
+	return c
+}
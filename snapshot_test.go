@@ -0,0 +1,432 @@
+package timer_test
+
+import "bytes"
+
+import "encoding/json"
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []timertest.Step
+		state   timer.State
+		elapsed time.Duration
+	}{
+		{
+			name:  "reset",
+			state: timer.Reset,
+		},
+		{
+			name: "running",
+			steps: []timertest.Step{
+				{Do: timertest.Start},
+				{After: 1500 * time.Millisecond},
+			},
+			state:   timer.Running,
+			elapsed: 1500 * time.Millisecond,
+		},
+		{
+			name: "paused",
+			steps: []timertest.Step{
+				{Do: timertest.Start},
+				{After: time.Second, Do: timertest.Pause},
+				{After: 2 * time.Second},
+			},
+			state:   timer.Paused,
+			elapsed: time.Second,
+		},
+		{
+			name: "stopped after pause",
+			steps: []timertest.Step{
+				{Do: timertest.Start},
+				{After: time.Second, Do: timertest.Pause},
+				{After: time.Second, Do: timertest.Resume},
+				{After: 500 * time.Millisecond, Do: timertest.Stop},
+			},
+			state:   timer.Stopped,
+			elapsed: 1500 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			src := newTimer(t, clock)
+			defer src.Close()
+			timertest.Run(t, src, clock, test.steps...)
+
+			data, err := json.Marshal(src.Snapshot())
+			if err != nil {
+				t.Fatalf("encoding snapshot: %v", err)
+			}
+			var snap timer.Snapshot
+			if err := json.Unmarshal(data, &snap); err != nil {
+				t.Fatalf("decoding snapshot: %v", err)
+			}
+
+			dst := newTimer(t, clock)
+			defer dst.Close()
+			if err := dst.RestoreSnapshot(snap); err != nil {
+				t.Fatalf("restoring snapshot: %v", err)
+			}
+			if s := dst.State(); s != test.state {
+				t.Errorf("state is %v, want %v", s, test.state)
+			}
+			if d := dst.Elapsed(); d != test.elapsed {
+				t.Errorf("elapsed is %v, want %v", d, test.elapsed)
+			}
+			if len(dst.Report().Pauses) != len(src.Report().Pauses) {
+				t.Errorf("restored %v pauses, want %v", len(dst.Report().Pauses), len(src.Report().Pauses))
+			}
+		})
+	}
+}
+
+func TestRestorePausedSnapshotRequiresOngoingPause(t *testing.T) {
+	tests := []struct {
+		name   string
+		pauses []timer.Pause
+		ok     bool
+	}{
+		{"no pauses", nil, false},
+		{"last pause finished", []timer.Pause{{Elapsed: time.Second, Duration: time.Second}}, false},
+		{"last pause ongoing", []timer.Pause{{Elapsed: time.Second, Duration: time.Second}, {Elapsed: 2 * time.Second}}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock)
+			defer tm.Close()
+
+			snap := tm.Snapshot()
+			snap.State = timer.Paused
+			snap.Elapsed = 2 * time.Second
+			snap.Pauses = test.pauses
+			err := tm.RestoreSnapshot(snap)
+			if test.ok && err != nil {
+				t.Errorf("restoring snapshot: %v", err)
+			}
+			if !test.ok && err == nil {
+				t.Errorf("restoring snapshot succeeded, want an error")
+			}
+		})
+	}
+}
+
+// waitPhase waits until tm counts down the phase with number n
+func waitPhase(t *testing.T, tm *timer.Timer, n int, within time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(within)
+	for {
+		p, _ := tm.Phase()
+		if p.Number == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("phase is %v after %v, want %v", p.Number, within, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// roundTrip restores the JSON encoded snapshot of src into dst
+func roundTrip(t *testing.T, src, dst *timer.Timer) {
+	t.Helper()
+
+	data, err := json.Marshal(src.Snapshot())
+	if err != nil {
+		t.Fatalf("encoding snapshot: %v", err)
+	}
+	var snap timer.Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		t.Fatalf("decoding snapshot: %v", err)
+	}
+	if err := dst.RestoreSnapshot(snap); err != nil {
+		t.Fatalf("restoring snapshot: %v", err)
+	}
+}
+
+func TestSnapshotRoundTripCountdownModes(t *testing.T) {
+	tests := []struct {
+		name   string
+		create func(opts ...timer.Option) (*timer.Timer, error)
+		// after is the time the source runs before it is paused in the phase with number phase, remaining is left of it
+		after     time.Duration
+		phase     int
+		remaining time.Duration
+	}{
+		{
+			name: "countdown",
+			create: func(opts ...timer.Option) (*timer.Timer, error) {
+				return timer.NewCountdown(5*time.Second, opts...), nil
+			},
+			after:     1500 * time.Millisecond,
+			remaining: 3500 * time.Millisecond,
+		},
+		{
+			name: "pomodoro",
+			create: func(opts ...timer.Option) (*timer.Timer, error) {
+				return timer.NewPomodoro(timer.PomodoroConfig{Work: 2 * time.Second, ShortBreak: time.Second, LongBreak: 3 * time.Second}, opts...)
+			},
+			after:     2500 * time.Millisecond,
+			phase:     2,
+			remaining: 500 * time.Millisecond,
+		},
+		{
+			name: "intervals",
+			create: func(opts ...timer.Option) (*timer.Timer, error) {
+				return timer.NewIntervals(timer.IntervalConfig{Intervals: []timer.Interval{{Work: 2 * time.Second, Rest: time.Second}}, Rounds: 2}, opts...)
+			},
+			after:     3500 * time.Millisecond,
+			phase:     3,
+			remaining: 1500 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			create := func() *timer.Timer {
+				tm, err := test.create(timer.WithClock(clock))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return prepare(t, tm)
+			}
+			src := create()
+			defer src.Close()
+			timertest.Run(t, src, clock,
+				timertest.Step{Do: timertest.Start},
+				timertest.Step{After: test.after, Do: func(tm *timer.Timer) error {
+					if test.phase > 0 {
+						waitPhase(t, tm, test.phase, time.Second)
+					}
+					return tm.PauseTimer()
+				}},
+			)
+
+			dst := create()
+			defer dst.Close()
+			roundTrip(t, src, dst)
+
+			if d := dst.Remaining(); d != test.remaining {
+				t.Errorf("remaining is %v, want %v", d, test.remaining)
+			}
+			want, _ := src.Phase()
+			if p, _ := dst.Phase(); p != want {
+				t.Errorf("phase is %+v, want %+v", p, want)
+			}
+
+			// the restored timer continues with the next phase or expires
+			timertest.Run(t, dst, clock,
+				timertest.Step{Do: timertest.Resume},
+				timertest.Step{After: test.remaining},
+			)
+			if test.phase > 0 {
+				waitPhase(t, dst, test.phase+1, time.Second)
+				return
+			}
+			waitState(t, dst, timer.Stopped, time.Second)
+		})
+	}
+}
+
+func TestRestorePhasesRequiresPhaseTimer(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm, err := timer.NewPomodoro(timer.PomodoroConfig{Work: 2 * time.Second, ShortBreak: time.Second, LongBreak: 3 * time.Second}, timer.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := prepare(t, tm)
+	defer src.Close()
+	dst := newTimer(t, clock)
+	defer dst.Close()
+
+	if err := dst.RestoreSnapshot(src.Snapshot()); err == nil {
+		t.Errorf("restoring the snapshot of a pomodoro timer into a timer without phases succeeded, want an error")
+	}
+}
+
+func TestSnapshotRoundTripBests(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	src := newTimer(t, clock, timer.WithStopOnSubtimersStop())
+	defer src.Close()
+	if err := src.AddSubTimer(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetBests(10*time.Second, map[int]time.Duration{1: 5 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	src.SetComparison(map[int]time.Duration{1: 4 * time.Second})
+	timertest.Run(t, src, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 3 * time.Second, Do: timertest.StopSubTimer(1)},
+	)
+
+	dst := newTimer(t, clock)
+	defer dst.Close()
+	roundTrip(t, src, dst)
+
+	if pb, _ := dst.PersonalBest(); pb != 3*time.Second {
+		t.Errorf("personal best is %v, want %v", pb, 3*time.Second)
+	}
+	if d := dst.BestSegments()[1]; d != 3*time.Second {
+		t.Errorf("best segment is %v, want %v", d, 3*time.Second)
+	}
+	if d, want := dst.PredictedFinish(), src.PredictedFinish(); d == 0 || d != want {
+		t.Errorf("predicted finish is %v, want %v", d, want)
+	}
+	// undoing the stop which stopped the timer resumes it and brings back the replaced personal best
+	if err := dst.UndoStopSubTimer(1); err != nil {
+		t.Fatalf("undoing stop: %v", err)
+	}
+	if s := dst.State(); s != timer.Running {
+		t.Errorf("state is %v, want %v", s, timer.Running)
+	}
+	if pb, _ := dst.PersonalBest(); pb != 10*time.Second {
+		t.Errorf("personal best is %v after undo, want %v", pb, 10*time.Second)
+	}
+}
+
+func TestSnapshotRoundTripSubtimers(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	src := newTimer(t, clock, timer.WithSubtimers(1, 2, 3, 4))
+	defer src.Close()
+	if err := src.SetSegments("Level", "Boss"); err != nil {
+		t.Fatal(err)
+	}
+	setup := []error{
+		src.SetSubTimerName(1, "alice"),
+		src.SetSubTimerMetadata(1, "defending champion", map[string]string{"country": "SE"}),
+		src.SetSubTimerSeed(1, 1, "upper"),
+		src.SetSubTimerOffset(2, time.Second),
+		src.Handoff(3, "bob"),
+	}
+	for _, err := range setup {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	timertest.Run(t, src, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: split(3)},
+		timertest.Step{Do: func(tm *timer.Timer) error { return tm.Handoff(3, "carol") }},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{Do: func(tm *timer.Timer) error { return tm.PauseSubTimer(2) }},
+		timertest.Step{Do: func(tm *timer.Timer) error { return tm.SkipSubTimer(4) }},
+	)
+
+	dst := newTimer(t, clock)
+	defer dst.Close()
+	roundTrip(t, src, dst)
+
+	if got, want := dst.Report().Subtimers, src.Report().Subtimers; !reflect.DeepEqual(got, want) {
+		t.Errorf("restored subtimers %+v, want %+v", got, want)
+	}
+	if id, err := dst.SubTimerID("alice"); err != nil || id != 1 {
+		t.Errorf("subtimer alice has id %v, %v, want 1", id, err)
+	}
+
+	// the paused subtimer continues from where it was paused
+	clock.Advance(time.Second)
+	if err := dst.ResumeSubTimer(2); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	d, err := dst.StopSubTimer(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != 3*time.Second {
+		t.Errorf("subtimer 2 stopped at %v, want 3s", d)
+	}
+	// the running subtimer kept going throughout
+	if d, err := dst.StopSubTimer(3); err != nil || d != 4*time.Second {
+		t.Errorf("subtimer 3 stopped at %v, %v, want 4s", d, err)
+	}
+}
+
+func TestTimerJSON(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	src := newTimer(t, clock, timer.WithSubtimers(1))
+	defer src.Close()
+	timertest.Run(t, src, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)},
+		timertest.Step{After: time.Second, Do: timertest.Pause},
+	)
+
+	// the timer can be embedded in the responses of a host application
+	data, err := json.Marshal(struct {
+		Timer *timer.Timer `json:"timer"`
+	}{src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := newTimer(t, clock)
+	defer dst.Close()
+	response := struct {
+		Timer *timer.Timer `json:"timer"`
+	}{dst}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	if s, d := dst.State(), dst.Elapsed(); s != timer.Paused || d != 2*time.Second {
+		t.Errorf("decoded timer is %v at %v, want paused at 2s", s, d)
+	}
+	if got, want := dst.Report().Subtimers, src.Report().Subtimers; !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded subtimers %+v, want %+v", got, want)
+	}
+
+	if err := json.Unmarshal(data, &struct{ Timer *timer.Timer }{&timer.Timer{}}); err == nil {
+		t.Errorf("decoding into a timer not created by New succeeded, want an error")
+	}
+}
+
+func TestSaveRestore(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	src := newTimer(t, clock, timer.WithSubtimers(1))
+	timertest.Run(t, src, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second},
+	)
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	src.Close()
+
+	// the time the process was down is counted
+	clock.Advance(3 * time.Second)
+	dst, err := timer.Restore(&buf, timer.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	go func() {
+		for range dst.Updates {
+		}
+	}()
+	if s, d := dst.State(), dst.Elapsed(); s != timer.Running || d != 5*time.Second {
+		t.Errorf("restored timer is %v at %v, want running at 5s", s, d)
+	}
+	timertest.Run(t, dst, clock, timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)})
+	if r := dst.Report(); len(r.Splits) != 1 || r.Splits[0].Time != 6*time.Second {
+		t.Errorf("splits are %+v, want subtimer 1 at 6s", r.Splits)
+	}
+
+	if _, err := timer.Restore(bytes.NewBufferString("{")); err == nil {
+		t.Errorf("restoring a truncated snapshot succeeded, want an error")
+	}
+}
@@ -0,0 +1,47 @@
+package timer
+
+import "time"
+
+// WithStartOffset sets the elapsed time every run starts at
+// a negative offset is the start delay of a speedrun, the timer counts up from -offset and EventZeroCrossed is emitted when real timing begins
+func WithStartOffset(offset time.Duration) Option {
+	return func(t *Timer) {
+		t.startOffset = offset
+	}
+}
+
+// SetStartOffset sets the elapsed time every run starts at
+// only possible when in Reset or Stopped state, it applies to the next run
+func (t *Timer) SetStartOffset(offset time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if t.state != Reset && t.state != Stopped {
+		return t.logFailed("SetStartOffset", &StateError{Op: "SetStartOffset", Current: t.state})
+	}
+	t.startOffset = offset
+	if t.state == Reset {
+		t.seek = offset
+		t.elapsed = offset
+	}
+
+	return nil
+}
+
+// StartOffset returns the elapsed time every run starts at
+func (t *Timer) StartOffset() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.startOffset
+}
+
+// checkZeroCrossing emits EventZeroCrossed once the elapsed time of a run started with a negative offset reaches zero
+func (t *Timer) checkZeroCrossing(previous time.Duration) {
+	if previous < 0 && t.elapsed >= 0 {
+		t.emit(Event{Type: EventZeroCrossed, Elapsed: t.elapsed})
+	}
+}
@@ -0,0 +1,81 @@
+package timer_test
+
+import "errors"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestStartDelay(t *testing.T) {
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Timer.Close()
+	if err := s.Timer.SetStartOffset(-3 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if d := s.Timer.Elapsed(); d != -3*time.Second {
+		t.Errorf("elapsed is %v before the start, want -3s", d)
+	}
+	s.At(0, (*timer.Timer).StartTimer)
+	events, err := s.Run(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crossed := 0
+	for _, e := range events {
+		switch e.Type {
+		case timer.EventZeroCrossed:
+			crossed++
+			if e.Elapsed != 0 {
+				t.Errorf("zero crossed at %v, want 0", e.Elapsed)
+			}
+		case timer.EventAnomaly:
+			t.Errorf("negative elapsed time reported as anomaly %+v", e)
+		}
+	}
+	if crossed != 1 {
+		t.Errorf("got %v zero crossings, want 1", crossed)
+	}
+	if d := s.Timer.Elapsed(); d != 2*time.Second {
+		t.Errorf("elapsed is %v, want 2s", d)
+	}
+}
+
+func TestStartOffset(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithStartOffset(-5*time.Second))
+	defer tm.Close()
+
+	// the start delay is the lower bound of adjustments
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start}, timertest.Step{After: time.Second})
+	if err := tm.Adjust(-time.Second); err != nil {
+		t.Errorf("adjusting into the start delay returned %v", err)
+	}
+	if err := tm.Adjust(-time.Second); err == nil {
+		t.Errorf("adjusting below the start delay succeeded, want an error")
+	}
+	var stateErr *timer.StateError
+	if err := tm.SetStartOffset(time.Second); !errors.As(err, &stateErr) {
+		t.Errorf("setting the start offset while running returned %v, want a state error", err)
+	}
+
+	// a new offset applies to the next run
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+	if err := tm.SetStartOffset(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if d := tm.Elapsed(); d != -5*time.Second {
+		t.Errorf("elapsed of the stopped run is %v, want -5s", d)
+	}
+	prepare(t, tm)
+	if d, offset := tm.Elapsed(), tm.StartOffset(); d != time.Minute || offset != time.Minute {
+		t.Errorf("next run starts at %v with offset %v, want 1m", d, offset)
+	}
+}
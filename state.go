@@ -0,0 +1,57 @@
+package timer
+
+import "encoding/json"
+
+import "fmt"
+
+var stateNames = map[State]string{
+	Reset:     "Reset",
+	Running:   "Running",
+	Paused:    "Paused",
+	Stopped:   "Stopped",
+	Forfeited: "Forfeited",
+	Skipped:   "Skipped",
+}
+
+// String returns the name of the state, e.g. Running
+func (s State) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// MarshalJSON encodes the state as its name
+func (s State) MarshalJSON() ([]byte, error) {
+	if _, ok := stateNames[s]; !ok {
+		return nil, fmt.Errorf("Unknown state %d", int(s))
+	}
+
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a state from its name or, as written by earlier versions, its number
+func (s *State) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		if _, ok := stateNames[State(n)]; !ok {
+			return fmt.Errorf("Unknown state %d", n)
+		}
+		*s = State(n)
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("State must be a name or a number: %v", err)
+	}
+	for state, stateName := range stateNames {
+		if stateName == name {
+			*s = state
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown state %q", name)
+}
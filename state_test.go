@@ -0,0 +1,79 @@
+package timer_test
+
+import "encoding/json"
+
+import "testing"
+
+import "github.com/onestay/timer-core"
+
+func TestStateUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want timer.State
+		ok   bool
+	}{
+		{`"Running"`, timer.Running, true},
+		{`"Skipped"`, timer.Skipped, true},
+		{`2`, timer.Paused, true},
+		{`"Finished"`, 0, false},
+		{`99`, 0, false},
+		{`-1`, 0, false},
+		{`true`, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.data, func(t *testing.T) {
+			var s timer.State
+			err := json.Unmarshal([]byte(test.data), &s)
+			if !test.ok {
+				if err == nil {
+					t.Fatalf("decoded %v, want an error", s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != test.want {
+				t.Errorf("decoded %v, want %v", s, test.want)
+			}
+		})
+	}
+}
+
+func TestStateMarshalJSON(t *testing.T) {
+	tests := []struct {
+		state timer.State
+		name  string
+	}{
+		{timer.Reset, "Reset"},
+		{timer.Running, "Running"},
+		{timer.Paused, "Paused"},
+		{timer.Stopped, "Stopped"},
+		{timer.Forfeited, "Forfeited"},
+		{timer.Skipped, "Skipped"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if s := test.state.String(); s != test.name {
+				t.Errorf("name is %q, want %q", s, test.name)
+			}
+			data, err := json.Marshal(test.state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var s timer.State
+			if err := json.Unmarshal(data, &s); err != nil || s != test.state {
+				t.Errorf("encoded as %s and decoded to %v, %v", data, s, err)
+			}
+		})
+	}
+
+	if s := timer.State(42).String(); s != "State(42)" {
+		t.Errorf("unknown state is named %q, want State(42)", s)
+	}
+	if _, err := json.Marshal(timer.State(42)); err == nil {
+		t.Errorf("encoding an unknown state succeeded, want an error")
+	}
+}
@@ -0,0 +1,104 @@
+// Package stats provides analysis of runs recorded by timer-core
+package stats
+
+import "sort"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// PBEntry is a single improvement of the personal best
+type PBEntry struct {
+	// Run is the index of the run in the analyzed history, ordered by start time
+	Run       int           `json:"run"`
+	StartTime time.Time     `json:"startTime"`
+	Time      time.Duration `json:"time"`
+	// Improvement is the time saved compared to the previous personal best. It is 0 for the first entry
+	Improvement time.Duration `json:"improvement"`
+}
+
+// HistoryStats holds aggregate statistics over a run history
+type HistoryStats struct {
+	Runs      int `json:"runs"`
+	Completed int `json:"completed"`
+	// CompletionRate is the fraction of completed runs between 0 and 1
+	CompletionRate float64 `json:"completionRate"`
+	// AverageResetTime is the average elapsed time at which incomplete runs were abandoned
+	AverageResetTime time.Duration `json:"averageResetTime"`
+	// AverageResetSplit is the average number of splits incomplete runs reached before being abandoned
+	AverageResetSplit float64   `json:"averageResetSplit"`
+	PBProgression     []PBEntry `json:"pbProgression"`
+}
+
+// Completed reports whether a run was finished
+// a run is finished if the timer was stopped and every subtimer was either stopped, forfeited or skipped
+func Completed(r timer.Report) bool {
+	return r.Completed()
+}
+
+// AnalyzeHistory computes aggregate statistics over the given runs
+func AnalyzeHistory(runs []timer.Report) HistoryStats {
+	runs = byStartTime(runs)
+	stats := HistoryStats{
+		Runs:          len(runs),
+		PBProgression: PBProgression(runs),
+	}
+
+	var resetTime time.Duration
+	var resetSplits int
+	for _, r := range runs {
+		if Completed(r) {
+			stats.Completed++
+			continue
+		}
+		resetTime += r.FinalTime
+		resetSplits += len(r.Splits)
+	}
+
+	if stats.Runs > 0 {
+		stats.CompletionRate = float64(stats.Completed) / float64(stats.Runs)
+	}
+	if resets := stats.Runs - stats.Completed; resets > 0 {
+		stats.AverageResetTime = resetTime / time.Duration(resets)
+		stats.AverageResetSplit = float64(resetSplits) / float64(resets)
+	}
+
+	return stats
+}
+
+// PBProgression returns every completed run which improved the personal best, ordered by start time
+func PBProgression(runs []timer.Report) []PBEntry {
+	runs = byStartTime(runs)
+	progression := make([]PBEntry, 0)
+	for i, r := range runs {
+		if !Completed(r) {
+			continue
+		}
+		if len(progression) == 0 {
+			progression = append(progression, PBEntry{Run: i, StartTime: r.StartTime, Time: r.FinalTime})
+			continue
+		}
+		best := progression[len(progression)-1].Time
+		if r.FinalTime < best {
+			progression = append(progression, PBEntry{
+				Run:         i,
+				StartTime:   r.StartTime,
+				Time:        r.FinalTime,
+				Improvement: best - r.FinalTime,
+			})
+		}
+	}
+
+	return progression
+}
+
+// byStartTime returns a copy of runs ordered by start time
+func byStartTime(runs []timer.Report) []timer.Report {
+	sorted := make([]timer.Report, len(runs))
+	copy(sorted, runs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	return sorted
+}
@@ -0,0 +1,89 @@
+package stats_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/stats"
+
+var day = time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// run returns the report of a run started n hours after day
+// completed runs are stopped at final, abandoned runs were reset at final after reaching the given number of splits
+func run(n int, final time.Duration, completed bool, splits int) timer.Report {
+	r := timer.Report{StartTime: day.Add(time.Duration(n) * time.Hour), FinalTime: final, State: timer.Stopped}
+	if !completed {
+		r.State = timer.Running
+	}
+	for i := 0; i < splits; i++ {
+		r.Splits = append(r.Splits, timer.SubtimerResult{ID: i + 1, State: timer.Stopped})
+	}
+
+	return r
+}
+
+func TestAnalyzeHistory(t *testing.T) {
+	// the runs are passed out of order, they are analyzed by start time
+	runs := []timer.Report{
+		run(3, 50*time.Minute, true, 3),
+		run(0, 60*time.Minute, true, 3),
+		run(1, 10*time.Minute, false, 1),
+		run(2, 55*time.Minute, true, 3),
+		run(4, 20*time.Minute, false, 2),
+		run(5, 58*time.Minute, true, 3),
+	}
+
+	got := stats.AnalyzeHistory(runs)
+	want := stats.HistoryStats{
+		Runs:              6,
+		Completed:         4,
+		CompletionRate:    4.0 / 6,
+		AverageResetTime:  15 * time.Minute,
+		AverageResetSplit: 1.5,
+		PBProgression: []stats.PBEntry{
+			{Run: 0, StartTime: day, Time: 60 * time.Minute},
+			{Run: 2, StartTime: day.Add(2 * time.Hour), Time: 55 * time.Minute, Improvement: 5 * time.Minute},
+			{Run: 3, StartTime: day.Add(3 * time.Hour), Time: 50 * time.Minute, Improvement: 5 * time.Minute},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestAnalyzeEmptyHistory(t *testing.T) {
+	got := stats.AnalyzeHistory(nil)
+	if got.Runs != 0 || got.CompletionRate != 0 || got.AverageResetTime != 0 || len(got.PBProgression) != 0 {
+		t.Errorf("got %+v for no runs, want zero statistics", got)
+	}
+}
+
+func TestCompleted(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     timer.State
+		subtimers []timer.State
+		want      bool
+	}{
+		{"stopped", timer.Stopped, nil, true},
+		{"running", timer.Running, nil, false},
+		{"finished subtimers", timer.Stopped, []timer.State{timer.Stopped, timer.Forfeited, timer.Skipped}, true},
+		{"unfinished subtimer", timer.Stopped, []timer.State{timer.Stopped, timer.Running}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := timer.Report{State: test.state}
+			for i, s := range test.subtimers {
+				r.Subtimers = append(r.Subtimers, timer.SubtimerResult{ID: i + 1, State: s})
+			}
+			if got := stats.Completed(r); got != test.want {
+				t.Errorf("Completed is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package stats
+
+import "sort"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// Scoring awards points for a single race entry
+// entrants is the number of entries in the heat the entry is part of
+type Scoring interface {
+	Points(e timer.RaceEntry, entrants int) float64
+}
+
+// ScoringFunc allows the use of ordinary functions as Scoring
+type ScoringFunc func(e timer.RaceEntry, entrants int) float64
+
+// Points calls f(e, entrants)
+func (f ScoringFunc) Points(e timer.RaceEntry, entrants int) float64 {
+	return f(e, entrants)
+}
+
+// PointsTable awards points[rank-1] to every finisher. Finishers ranked below the table and entries which didn't finish get 0 points
+func PointsTable(points ...float64) Scoring {
+	return ScoringFunc(func(e timer.RaceEntry, entrants int) float64 {
+		if e.Rank < 1 || e.Rank > len(points) {
+			return 0
+		}
+		return points[e.Rank-1]
+	})
+}
+
+// LinearScoring awards one point for every entrant a finisher placed ahead of plus one for finishing
+var LinearScoring Scoring = ScoringFunc(func(e timer.RaceEntry, entrants int) float64 {
+	if e.Rank < 1 {
+		return 0
+	}
+	return float64(entrants - e.Rank + 1)
+})
+
+// LeaderboardEntry holds the combined results of a single participant over all heats
+type LeaderboardEntry struct {
+	ID     int     `json:"id"`
+	Rank   int     `json:"rank"`
+	Points float64 `json:"points"`
+	// Heats is the number of heats the participant took part in
+	Heats    int `json:"heats"`
+	Finishes int `json:"finishes"`
+	// TotalTime is the sum of all finishing times and is used to break ties
+	TotalTime time.Duration `json:"totalTime"`
+	// Seed and Bracket are taken from the last heat the participant took part in. Seeds break remaining ties
+	Seed    int    `json:"seed,omitempty"`
+	Bracket string `json:"bracket,omitempty"`
+}
+
+// Leaderboard combines the results of multiple heats into a single ranking using scoring
+// participants are identified by their subtimer id across heats. Ties in points are broken by finishes and total time,
+// participants which are still tied share a rank and are ordered by seed
+func Leaderboard(heats []timer.RaceResult, scoring Scoring) []LeaderboardEntry {
+	entries := make(map[int]*LeaderboardEntry)
+	for _, heat := range heats {
+		for _, e := range heat.Entries {
+			l, ok := entries[e.ID]
+			if !ok {
+				l = &LeaderboardEntry{ID: e.ID}
+				entries[e.ID] = l
+			}
+			l.Heats++
+			l.Seed, l.Bracket = e.Seed, e.Bracket
+			l.Points += scoring.Points(e, len(heat.Entries))
+			if e.Rank > 0 {
+				l.Finishes++
+				l.TotalTime += e.Time
+			}
+		}
+	}
+
+	board := make([]LeaderboardEntry, 0, len(entries))
+	for _, l := range entries {
+		board = append(board, *l)
+	}
+	sort.Slice(board, func(i, j int) bool {
+		a, b := board[i], board[j]
+		if ahead(a, b) || ahead(b, a) {
+			return ahead(a, b)
+		}
+		if a.Seed != b.Seed {
+			return seedBefore(a, b)
+		}
+		return a.ID < b.ID
+	})
+
+	for i := range board {
+		board[i].Rank = i + 1
+		if i > 0 && !ahead(board[i-1], board[i]) {
+			board[i].Rank = board[i-1].Rank
+		}
+	}
+
+	return board
+}
+
+// ahead reports whether a is ranked strictly ahead of b
+func ahead(a, b LeaderboardEntry) bool {
+	if a.Points != b.Points {
+		return a.Points > b.Points
+	}
+	if a.Finishes != b.Finishes {
+		return a.Finishes > b.Finishes
+	}
+	return a.TotalTime < b.TotalTime
+}
+
+// seedBefore reports whether a is ordered before b by seed. Unseeded participants are ordered last
+func seedBefore(a, b LeaderboardEntry) bool {
+	if a.Seed == 0 || b.Seed == 0 {
+		return a.Seed != 0 && b.Seed == 0
+	}
+	return a.Seed < b.Seed
+}
@@ -0,0 +1,86 @@
+package stats_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/stats"
+
+// heat returns a race result in which the entries finished in the given order, ids after 0 didn't finish
+func heat(order ...int) timer.RaceResult {
+	var r timer.RaceResult
+	finished := true
+	for i, id := range order {
+		if id == 0 {
+			finished = false
+			continue
+		}
+		e := timer.RaceEntry{ID: id, State: timer.Forfeited}
+		if finished {
+			e.State = timer.Stopped
+			e.Rank = i + 1
+			e.Time = time.Duration(i+1) * time.Minute
+		}
+		r.Entries = append(r.Entries, e)
+	}
+
+	return r
+}
+
+func TestLeaderboard(t *testing.T) {
+	tests := []struct {
+		name    string
+		heats   []timer.RaceResult
+		scoring stats.Scoring
+		want    []stats.LeaderboardEntry
+	}{
+		{
+			name:    "points table",
+			heats:   []timer.RaceResult{heat(1, 2, 3), heat(2, 1, 0, 3)},
+			scoring: stats.PointsTable(10, 6, 4),
+			want: []stats.LeaderboardEntry{
+				{ID: 1, Rank: 1, Points: 16, Heats: 2, Finishes: 2, TotalTime: 3 * time.Minute},
+				{ID: 2, Rank: 1, Points: 16, Heats: 2, Finishes: 2, TotalTime: 3 * time.Minute},
+				{ID: 3, Rank: 3, Points: 4, Heats: 2, Finishes: 1, TotalTime: 3 * time.Minute},
+			},
+		},
+		{
+			name:    "linear scoring",
+			heats:   []timer.RaceResult{heat(1, 2, 3), heat(3, 1, 2)},
+			scoring: stats.LinearScoring,
+			want: []stats.LeaderboardEntry{
+				{ID: 1, Rank: 1, Points: 5, Heats: 2, Finishes: 2, TotalTime: 3 * time.Minute},
+				// 3 and 2 share the points, 3 wins on total time
+				{ID: 3, Rank: 2, Points: 4, Heats: 2, Finishes: 2, TotalTime: 4 * time.Minute},
+				{ID: 2, Rank: 3, Points: 3, Heats: 2, Finishes: 2, TotalTime: 5 * time.Minute},
+			},
+		},
+		{
+			name:  "custom scoring",
+			heats: []timer.RaceResult{heat(1, 2), heat(1, 0, 2)},
+			scoring: stats.ScoringFunc(func(e timer.RaceEntry, entrants int) float64 {
+				if e.Rank == 1 {
+					return 1
+				}
+				return 0
+			}),
+			want: []stats.LeaderboardEntry{
+				{ID: 1, Rank: 1, Points: 2, Heats: 2, Finishes: 2, TotalTime: 2 * time.Minute},
+				{ID: 2, Rank: 2, Points: 0, Heats: 2, Finishes: 1, TotalTime: 2 * time.Minute},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := stats.Leaderboard(test.heats, test.scoring)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
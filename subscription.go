@@ -0,0 +1,441 @@
+package timer
+
+import "fmt"
+
+import "sort"
+
+import "sync"
+
+import "time"
+
+// Subscription receives the events emitted by a timer
+// tick events are coalesced if the consumer falls behind, all other events are always delivered
+type Subscription struct {
+	// C delivers the events. It is closed when the subscription is closed
+	C        <-chan Event
+	c        chan Event
+	t        *Timer
+	filter   Filter
+	buffer   int
+	overflow OverflowPolicy
+	// rate and precision downsample periodic events. next is only accessed by the dispatcher
+	rate      time.Duration
+	precision time.Duration
+	next      time.Duration
+
+	mu     sync.Mutex
+	queue  []Event
+	ticks  int
+	stats  SubscriptionStats
+	notify chan struct{}
+	done   chan struct{}
+	// flush is closed when the timer is closed, the remaining events except periodic ones are still delivered
+	flush chan struct{}
+}
+
+// SubscriptionStats holds delivery statistics of a single subscription
+type SubscriptionStats struct {
+	ID        uint64 `json:"id"`
+	Delivered uint64 `json:"delivered"`
+	// Dropped is the number of tick events which were dropped because the consumer fell behind
+	Dropped uint64 `json:"dropped"`
+}
+
+// OverflowPolicy describes how a subscription handles tick events when its buffer is full
+type OverflowPolicy int
+
+const (
+	// DropOldest drops the oldest queued tick in favor of the new one
+	DropOldest OverflowPolicy = iota
+	// DropNewest keeps the queued ticks and drops the new one
+	DropNewest
+	// Coalesce drops all queued ticks so only the newest one is delivered
+	Coalesce
+	// Unbounded never drops ticks and grows the queue as needed
+	Unbounded
+)
+
+// SubscribeOption configures a subscription
+type SubscribeOption func(s *Subscription)
+
+// WithFilter only delivers events matching f to the subscription
+func WithFilter(f Filter) SubscribeOption {
+	return func(s *Subscription) {
+		s.filter = f
+	}
+}
+
+// WithBuffer sets how many tick events are queued for the subscription before the overflow policy applies
+// Setting 0 for size sets it back to the default of 64
+func WithBuffer(size int) SubscribeOption {
+	return func(s *Subscription) {
+		if size <= 0 {
+			size = defaultSubscriptionBuffer
+		}
+		s.buffer = size
+	}
+}
+
+// WithOverflow sets how the subscription handles tick events when its buffer is full
+func WithOverflow(policy OverflowPolicy) SubscribeOption {
+	return func(s *Subscription) {
+		s.overflow = policy
+	}
+}
+
+// WithRate only delivers tick events to the subscription once every rate of elapsed time
+// all streams are generated from the same ticks, so the effective rate is limited by the update interval of the timer
+func WithRate(rate time.Duration) SubscribeOption {
+	return func(s *Subscription) {
+		s.rate = rate
+	}
+}
+
+// WithPrecision truncates the times of periodic events delivered to the subscription to multiples of precision
+func WithPrecision(precision time.Duration) SubscribeOption {
+	return func(s *Subscription) {
+		s.precision = precision
+	}
+}
+
+// dispatcher delivers events to subscriptions and keeps a log of all non tick events of the current run
+type dispatcher struct {
+	mu            sync.Mutex
+	subs          map[*Subscription]struct{}
+	log           []Event
+	nextID        uint64
+	seq           uint64
+	dropThreshold uint64
+	// record is called synchronously for every event if set
+	record func(e Event)
+}
+
+// Subscribe returns a new subscription receiving the events of the timer
+// by default at most 64 tick events are queued for a subscription and older ticks are dropped in favor of newer ones.
+// Subscriptions are closed when the timer is closed after delivering the events emitted until then except ticks,
+// subscribing to a closed timer returns a closed subscription.
+// Subscribing also stops the timer from blocking on the updates channel, see UpdateBlock
+func (t *Timer) Subscribe(opts ...SubscribeOption) *Subscription {
+	c := make(chan Event)
+	s := &Subscription{
+		C:      c,
+		c:      c,
+		t:      t,
+		buffer: defaultSubscriptionBuffer,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		flush:  make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events.mu.Lock()
+	t.events.nextID++
+	s.stats.ID = t.events.nextID
+	if t.closed {
+		close(s.done)
+	} else {
+		if t.events.subs == nil {
+			t.events.subs = make(map[*Subscription]struct{})
+		}
+		t.events.subs[s] = struct{}{}
+	}
+	t.events.mu.Unlock()
+	if !t.closed {
+		t.markSubscribed()
+	}
+	t.goWorker(roleSubscription, s.run)
+
+	return s
+}
+
+// Close removes the subscription from the timer and closes its channel
+// events which haven't been received yet are discarded
+func (s *Subscription) Close() {
+	s.t.events.mu.Lock()
+	defer s.t.events.mu.Unlock()
+	if _, ok := s.t.events.subs[s]; !ok {
+		return
+	}
+	delete(s.t.events.subs, s)
+	close(s.done)
+}
+
+// Stats returns the delivery statistics of the subscription
+func (s *Subscription) Stats() SubscriptionStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stats
+}
+
+// push queues e for delivery and reports whether the subscription just exceeded threshold dropped events
+func (s *Subscription) push(e Event, threshold uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lagging := false
+	if e.Type.periodic() && s.ticks >= s.buffer && s.overflow != Unbounded {
+		if s.overflow == DropNewest {
+			return s.drop(1, threshold)
+		}
+
+		n := 1
+		if s.overflow == Coalesce {
+			n = s.ticks
+		}
+		for i := 0; i < n; i++ {
+			s.dropOldestTick()
+		}
+		lagging = s.drop(n, threshold)
+	}
+
+	if e.Type.periodic() {
+		s.ticks++
+	}
+	s.queue = append(s.queue, e)
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+
+	return lagging
+}
+
+// downsample applies the rate and precision of the subscription to e and reports whether e should be delivered
+func (s *Subscription) downsample(e Event) (Event, bool) {
+	if e.Type == EventStarted || e.Type == EventReset {
+		s.next = 0
+	}
+	if !e.Type.periodic() {
+		return e, true
+	}
+
+	if s.rate > 0 && e.Type == EventTick {
+		if e.Elapsed < s.next {
+			return e, false
+		}
+		s.next = e.Elapsed - e.Elapsed%s.rate + s.rate
+	}
+	if s.precision > 0 {
+		e.Elapsed = e.Elapsed.Truncate(s.precision)
+		e.Remaining = e.Remaining.Truncate(s.precision)
+		if e.Live != nil {
+			live := *e.Live
+			live.Delta = live.Delta.Truncate(s.precision)
+			e.Live = &live
+		}
+		if e.Subtimers != nil {
+			subtimers := make(map[int]time.Duration, len(e.Subtimers))
+			for id, d := range e.Subtimers {
+				subtimers[id] = d.Truncate(s.precision)
+			}
+			e.Subtimers = subtimers
+		}
+	}
+
+	return e, true
+}
+
+// drop counts n dropped events and reports whether the count crossed a multiple of threshold
+func (s *Subscription) drop(n int, threshold uint64) bool {
+	before := s.stats.Dropped / threshold
+	s.stats.Dropped += uint64(n)
+
+	return s.stats.Dropped/threshold > before
+}
+
+func (s *Subscription) dropOldestTick() {
+	for i, e := range s.queue {
+		if e.Type.periodic() {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			s.ticks--
+			return
+		}
+	}
+}
+
+func (s *Subscription) pop() (Event, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 {
+		return Event{}, false
+	}
+	e := s.queue[0]
+	s.queue[0] = Event{}
+	s.queue = s.queue[1:]
+	if e.Type.periodic() {
+		s.ticks--
+	}
+
+	return e, true
+}
+
+// run delivers queued events to the consumer until the subscription is closed
+func (s *Subscription) run() {
+	defer close(s.c)
+	for {
+		e, ok := s.pop()
+		if !ok {
+			select {
+			case <-s.notify:
+				continue
+			case <-s.done:
+				return
+			case <-s.flush:
+				return
+			}
+		}
+
+		select {
+		case s.c <- e:
+			s.delivered()
+		case <-s.done:
+			return
+		case <-s.flush:
+			s.drain(e)
+			return
+		}
+	}
+}
+
+// drain delivers e and all remaining queued events except periodic ones once the timer was closed
+// it gives up if the consumer doesn't take an event within flushTimeout
+func (s *Subscription) drain(e Event) {
+	for ok := true; ok; e, ok = s.pop() {
+		if e.Type.periodic() {
+			continue
+		}
+		select {
+		case s.c <- e:
+			s.delivered()
+		case <-time.After(flushTimeout):
+			return
+		}
+	}
+}
+
+func (s *Subscription) delivered() {
+	s.mu.Lock()
+	s.stats.Delivered++
+	s.mu.Unlock()
+}
+
+// closeSubscriptions closes all subscriptions of the timer once they delivered the remaining events
+// it must be called with t.mu held
+func (t *Timer) closeSubscriptions() {
+	t.events.mu.Lock()
+	defer t.events.mu.Unlock()
+
+	for s := range t.events.subs {
+		close(s.flush)
+	}
+	t.events.subs = nil
+}
+
+// SubscriptionStats returns the delivery statistics of all open subscriptions ordered by id
+func (t *Timer) SubscriptionStats() []SubscriptionStats {
+	t.events.mu.Lock()
+	defer t.events.mu.Unlock()
+
+	stats := make([]SubscriptionStats, 0, len(t.events.subs))
+	for s := range t.events.subs {
+		stats = append(stats, s.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].ID < stats[j].ID
+	})
+
+	return stats
+}
+
+// SetDropThreshold sets after how many dropped events of a subscription an EventSubscriptionLagging event is emitted
+// Setting 0 for threshold sets it back to the default
+func (t *Timer) SetDropThreshold(threshold int) error {
+	if threshold < 0 {
+		return fmt.Errorf("Only positive values for threshold are allowed")
+	}
+
+	t.events.mu.Lock()
+	t.events.dropThreshold = uint64(threshold)
+	t.events.mu.Unlock()
+
+	return nil
+}
+
+// eventLog returns a copy of all non tick events emitted during the current run
+func (t *Timer) eventLog() []Event {
+	t.events.mu.Lock()
+	defer t.events.mu.Unlock()
+
+	log := make([]Event, len(t.events.log))
+	copy(log, t.events.log)
+
+	return log
+}
+
+func (t *Timer) clearEventLog() {
+	t.events.mu.Lock()
+	t.events.log = nil
+	t.events.mu.Unlock()
+}
+
+func (t *Timer) emit(e Event) {
+	if e.Time.IsZero() {
+		e.Time = t.clock.Now()
+	}
+	if !t.epoch.IsZero() {
+		e.Monotonic = e.Time.Sub(t.epoch)
+	}
+	if e.Payload == nil && !e.Type.periodic() {
+		e.Payload = t.payload
+	}
+
+	t.logEvent(e)
+	lagging := t.dispatch(e)
+	for i := range lagging {
+		t.emit(Event{Type: EventSubscriptionLagging, Severity: SeverityWarning, Elapsed: e.Elapsed, Subscription: &lagging[i]})
+	}
+}
+
+// dispatch queues e for all matching subscriptions
+// it returns the statistics of all subscriptions which just exceeded the drop threshold
+func (t *Timer) dispatch(e Event) []SubscriptionStats {
+	t.events.mu.Lock()
+	defer t.events.mu.Unlock()
+
+	t.events.seq++
+	e.Seq = t.events.seq
+	if !e.Type.periodic() {
+		t.events.log = append(t.events.log, e)
+	}
+	if t.events.record != nil {
+		t.events.record(e)
+	}
+
+	threshold := t.events.dropThreshold
+	if threshold == 0 {
+		threshold = defaultDropThreshold
+	}
+
+	var lagging []SubscriptionStats
+	for s := range t.events.subs {
+		if !s.filter.Match(e) {
+			continue
+		}
+		se, ok := s.downsample(s.filter.Apply(e))
+		if !ok {
+			continue
+		}
+		if s.push(se, threshold) {
+			lagging = append(lagging, s.Stats())
+		}
+	}
+
+	return lagging
+}
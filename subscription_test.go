@@ -0,0 +1,250 @@
+package timer_test
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestCloseDeliversQueuedEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []timertest.Step
+	}{
+		{"running", []timertest.Step{{Do: timertest.Start}, {After: time.Second}}},
+		{"paused", []timertest.Step{{Do: timertest.Start}, {After: time.Second, Do: timertest.Pause}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock)
+			sub := tm.Subscribe(timer.WithFilter(timer.StateChangesOnly()))
+			timertest.Run(t, tm, clock, test.steps...)
+
+			closed := make(chan struct{})
+			go func() {
+				tm.Close()
+				close(closed)
+			}()
+			e := timertest.AssertEmitsType(t, sub.C, timer.EventStopped, 0)
+			if e.Elapsed != time.Second {
+				t.Errorf("stopped at %v, want %v", e.Elapsed, time.Second)
+			}
+			// the subscription is closed once all events were delivered
+			for range sub.C {
+			}
+			<-closed
+		})
+	}
+}
+
+func TestCloseWithoutSubscriptionConsumer(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	tm.Subscribe()
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+
+	closed := make(chan struct{})
+	go func() {
+		tm.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatalf("Close waits for a subscription nobody reads")
+	}
+}
+
+// count returns the number of events of type typ in events
+func count(events []timer.Event, typ timer.EventType) int {
+	n := 0
+	for _, e := range events {
+		if e.Type == typ {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestSubscriptionLagging(t *testing.T) {
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Timer.Close()
+	if err := s.Timer.SetDropThreshold(-1); err == nil {
+		t.Errorf("setting a negative drop threshold succeeded, want an error")
+	}
+	if err := s.Timer.SetDropThreshold(3); err != nil {
+		t.Fatal(err)
+	}
+	sub := s.Timer.Subscribe(timer.WithBuffer(1), timer.WithOverflow(timer.DropNewest))
+	s.At(0, (*timer.Timer).StartTimer)
+	events, err := s.Run(10 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// at most two ticks are queued or waiting for the consumer
+	ticks := count(events, timer.EventTick)
+	stats := s.Timer.SubscriptionStats()
+	if len(stats) != 1 || stats[0].ID != sub.Stats().ID {
+		t.Fatalf("stats are %+v, want the stats of the subscription", stats)
+	}
+	if int(stats[0].Dropped) < ticks-2 {
+		t.Errorf("dropped %v of %v ticks, want at least %v", stats[0].Dropped, ticks, ticks-2)
+	}
+	// a lagging event is emitted every 3 dropped ticks
+	if n := count(events, timer.EventSubscriptionLagging); n != int(stats[0].Dropped/3) {
+		t.Errorf("emitted %v lagging events for %v dropped ticks, want %v", n, stats[0].Dropped, stats[0].Dropped/3)
+	}
+	for _, e := range events {
+		if e.Type == timer.EventSubscriptionLagging && (e.Subscription == nil || e.Subscription.ID != sub.Stats().ID) {
+			t.Errorf("lagging event is about subscription %+v, want %v", e.Subscription, sub.Stats().ID)
+		}
+	}
+}
+
+// flood runs a simulation ticking every second for 10 seconds while nobody reads the subscription created with opts
+func flood(t *testing.T, opts ...timer.SubscribeOption) (*timer.Simulation, *timer.Subscription) {
+	t.Helper()
+
+	s, err := timer.NewSimulation(time.Now(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := s.Timer.Subscribe(opts...)
+	s.At(0, (*timer.Timer).StartTimer)
+	s.At(10*time.Second, (*timer.Timer).StopTimer)
+	if _, err := s.Run(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	return s, sub
+}
+
+// receive returns the events delivered by sub up to and including the first stop event
+func receive(t *testing.T, sub *timer.Subscription) []timer.Event {
+	t.Helper()
+
+	var events []timer.Event
+	for {
+		e := timertest.AssertEmits(t, sub.C, 0)
+		events = append(events, e)
+		if e.Type == timer.EventStopped {
+			return events
+		}
+	}
+}
+
+func TestStateChangesAreNeverDropped(t *testing.T) {
+	for _, policy := range []timer.OverflowPolicy{timer.DropOldest, timer.DropNewest, timer.Coalesce} {
+		s, sub := flood(t, timer.WithBuffer(1), timer.WithOverflow(policy))
+		defer s.Timer.Close()
+
+		events := receive(t, sub)
+		if count(events, timer.EventStarted) != 1 {
+			t.Errorf("policy %v dropped the start event", policy)
+		}
+		if sub.Stats().Dropped == 0 {
+			t.Errorf("policy %v dropped no ticks", policy)
+		}
+	}
+}
+
+func TestSubscriptionBuffer(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []timer.SubscribeOption
+		// newest reports whether the newest tick has to be delivered
+		newest bool
+		// maxTicks is the maximum number of delivered ticks, 0 means all of them
+		maxTicks int
+	}{
+		{"unbounded", []timer.SubscribeOption{timer.WithBuffer(1), timer.WithOverflow(timer.Unbounded)}, true, 0},
+		{"drop oldest", []timer.SubscribeOption{timer.WithBuffer(3), timer.WithOverflow(timer.DropOldest)}, true, 4},
+		{"drop newest", []timer.SubscribeOption{timer.WithBuffer(3), timer.WithOverflow(timer.DropNewest)}, false, 4},
+		{"coalesce", []timer.SubscribeOption{timer.WithBuffer(3), timer.WithOverflow(timer.Coalesce)}, true, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, sub := flood(t, test.opts...)
+			defer s.Timer.Close()
+
+			var last time.Duration
+			for _, e := range s.Events() {
+				if e.Type == timer.EventTick {
+					last = e.Elapsed
+				}
+			}
+			all := count(s.Events(), timer.EventTick)
+			events := receive(t, sub)
+			ticks := count(events, timer.EventTick)
+			if int(sub.Stats().Dropped)+ticks != all {
+				t.Errorf("delivered %v and dropped %v of %v ticks", ticks, sub.Stats().Dropped, all)
+			}
+			if test.maxTicks == 0 && ticks != all || test.maxTicks > 0 && ticks > test.maxTicks {
+				t.Errorf("delivered %v of %v ticks, want at most %v", ticks, all, test.maxTicks)
+			}
+
+			delivered := false
+			for _, e := range events {
+				if e.Type == timer.EventTick && e.Elapsed == last {
+					delivered = true
+				}
+			}
+			if delivered != test.newest {
+				t.Errorf("newest tick delivered is %v, want %v", delivered, test.newest)
+			}
+		})
+	}
+}
+
+func TestSubscriptionTiers(t *testing.T) {
+	// ticks every 30ms don't align with the rates, so the precision has to truncate them
+	s, err := timer.NewSimulation(time.Now(), 30*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Timer.Close()
+	tiers := []struct {
+		name string
+		sub  *timer.Subscription
+		want []time.Duration
+	}{
+		{
+			name: "scoreboard",
+			sub:  s.Timer.Subscribe(timer.WithFilter(timer.TicksOnly()), timer.WithOverflow(timer.Unbounded), timer.WithRate(time.Second), timer.WithPrecision(time.Second)),
+			want: []time.Duration{0, time.Second, 2 * time.Second},
+		},
+		{
+			name: "overlay",
+			sub:  s.Timer.Subscribe(timer.WithFilter(timer.TicksOnly()), timer.WithOverflow(timer.Unbounded), timer.WithRate(500*time.Millisecond), timer.WithPrecision(100*time.Millisecond)),
+			want: []time.Duration{0, 500 * time.Millisecond, time.Second, 1500 * time.Millisecond, 2 * time.Second, 2500 * time.Millisecond},
+		},
+	}
+	s.At(0, (*timer.Timer).StartTimer)
+	s.At(3*time.Second, (*timer.Timer).StopTimer)
+	if _, err := s.Run(3 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tier := range tiers {
+		var got []time.Duration
+		for len(got) < len(tier.want) {
+			got = append(got, timertest.AssertEmitsType(t, tier.sub.C, timer.EventTick, 0).Elapsed)
+		}
+		if !reflect.DeepEqual(got, tier.want) {
+			t.Errorf("%v received ticks at %v, want %v", tier.name, got, tier.want)
+		}
+		timertest.AssertNoEmit(t, tier.sub.C, 10*time.Millisecond)
+	}
+}
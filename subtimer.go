@@ -4,50 +4,240 @@ import "time"
 
 import "fmt"
 
+import "sort"
+
 type subtimer struct {
 	Time  time.Duration
 	state State
+	// start is the elapsed time of the timer at which the subtimer started
+	start time.Duration
+	// legs holds the legs of a relay subtimer
+	legs []Leg
+	// splits holds the times at the shared segments
+	splits []time.Duration
+	// offset is the stream delay of the runner
+	offset time.Duration
+	// throttle is the minimum time between two updates in tick events
+	throttle   time.Duration
+	lastUpdate time.Time
+	// paused is the total time the subtimer has been paused on its own, pausedAt the timer elapsed at the start of the ongoing pause
+	paused          time.Duration
+	pausedAt        time.Duration
+	pauseBudget     time.Duration
+	forfeitOnBudget bool
+	budgetExceeded  bool
+	// name is the optional human readable name of the subtimer, unique among all subtimers
+	name string
+	// description and tags are display data for integrations like overlays
+	description string
+	tags        map[string]string
+	// tournament metadata
+	seed    int
+	bracket string
+	// adjustment is the sum of all ledger adjustments of the subtimer
+	adjustment time.Duration
+	// gold is set if the subtimer finished with a best segment, previousBest is the best segment it replaced
+	gold         bool
+	previousBest time.Duration
+	// adjudicated subtimers have an official time and are locked against changes
+	adjudicated bool
+	note        string
 }
 
 // AddSubTimer adds a timer with an id to the subtimer pool
-// id has to be unique and can only be added when timer is in reset state
+// id has to be unique. Subtimers added while the timer is running or paused start counting from the moment they are added
 func (t *Timer) AddSubTimer(id int) error {
-	if t.State != Reset {
-		return fmt.Errorf("Subtimer can only be added when timer is in reset state")
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, err := t.addSubTimerLocked(id, 0)
+
+	return err
+}
+
+// AddSubTimerAt adds a subtimer to a running or paused timer which has already counted offset
+// e.g. for a participant who started before the subtimer could be added
+func (t *Timer) AddSubTimerAt(id int, offset time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != Running && t.state != Paused {
+		return &StateError{Op: "AddSubTimerAt", Current: t.state}
+	}
+	if offset < 0 {
+		return fmt.Errorf("Only positive values for offset are allowed")
+	}
+	_, err := t.addSubTimerLocked(id, offset)
+
+	return err
+}
+
+func (t *Timer) addSubTimerLocked(id int, offset time.Duration) (*subtimer, error) {
+	if t.closed {
+		return nil, ErrClosed
+	}
+	t.refreshElapsed()
+	if t.state != Reset && t.state != Running && t.state != Paused {
+		return nil, &StateError{Op: "AddSubTimer", Current: t.state}
 	}
 	if _, ok := t.subtimers[id]; ok {
-		return fmt.Errorf("Subtimer with key %v already exists", id)
+		return nil, &SubtimerError{ID: id, Err: ErrSubtimerExists}
 	}
 	s := subtimer{}
 	s.state = Reset
+	if t.state == Running || t.state == Paused {
+		// the credit of an ongoing pause is subtracted again, it only belongs to subtimers which were running before it
+		s.state = Running
+		s.start = t.elapsed + t.pauseCredit() - offset
+	}
 	t.subtimers[id] = &s
 
-	return nil
+	return &s, nil
 }
 
 // StopSubTimer will stop a specific subtimer
-// only works when subtimer and timer are running
+// only works when the timer is running or paused and the subtimer is running or paused
 func (t *Timer) StopSubTimer(id int) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	d, err := t.stopSubTimerLocked(id)
+
+	return d, t.logFailed("StopSubTimer", err, "subtimer", id)
+}
+
+func (t *Timer) stopSubTimerLocked(id int) (time.Duration, error) {
+	if t.closed {
+		return 0, ErrClosed
+	}
+	t.refreshElapsed()
 	s, ok := t.subtimers[id]
 	if !ok {
-		return time.Duration(0), fmt.Errorf("Subtimer with id %v does not exist", id)
+		return time.Duration(0), errSubtimerNotFound(id)
 	}
-	if t.State == Running && s.state == Running {
-
+	if s.adjudicated {
+		return s.Time, errSubtimerAdjudicated(id)
 	}
-	s.state = Stopped
-	s.Time = t.elapsed
+	if t.state == Reset || t.state == Stopped {
+		return 0, &StateError{Op: "StopSubTimer", Current: t.state}
+	}
+	if s.state != Running && s.state != Paused {
+		return s.Time, errSubtimerFinished(id)
+	}
+	t.stopSubTimer(s)
+	t.checkBestSegment(id, s)
+	t.emit(Event{Type: EventSubtimerStopped, Elapsed: t.elapsed, Subtimer: intPtr(id), Subtimers: map[int]time.Duration{id: s.Time}})
 
 	if t.stopOnSubtimersStop && t.checkSubTimerFinish() {
-		t.StopTimer()
+		if t.stopTimerLocked() == nil {
+			t.autoStopped = true
+		}
 	}
 
 	return s.Time, nil
 }
 
+// UndoStopSubTimer reverts the last stop of a subtimer and lets it continue running as if it had never been stopped
+// if the stop caused the timer to stop because of StopOnSubtimersStop the timer is resumed as well.
+// Only works when the timer is running or paused or was stopped by the subtimer
+func (t *Timer) UndoStopSubTimer(id int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	t.refreshElapsed()
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if s.state != Stopped {
+		return &StateError{Op: "UndoStopSubTimer", Current: s.state}
+	}
+	if s.adjudicated {
+		return errSubtimerAdjudicated(id)
+	}
+	// the resume is the only step which can fail, so it is done before anything is reverted
+	if t.state == Stopped && t.autoStopped {
+		if err := t.resumeAfterStop(true); err != nil {
+			return err
+		}
+		t.revertPersonalBest()
+	}
+	if t.state != Running && t.state != Paused {
+		return &StateError{Op: "UndoStopSubTimer", Current: t.state}
+	}
+
+	t.revertBestSegment(id, s)
+	s.Time = 0
+	s.state = Running
+	if len(s.legs) > 0 {
+		s.legs[len(s.legs)-1].Time = 0
+	}
+	t.emit(Event{Type: EventSubtimerStopUndone, Elapsed: t.elapsed, Subtimer: intPtr(id), Subtimers: map[int]time.Duration{id: t.subtimerElapsed(s)}})
+
+	return nil
+}
+
+// SkipSubTimer marks a subtimer as skipped
+// skipped subtimers have no time and don't hold up StopOnSubtimersStop. Only works for subtimers which haven't finished yet
+func (t *Timer) SkipSubTimer(id int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	t.refreshElapsed()
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if s.state != Reset && s.state != Running && s.state != Paused {
+		return &StateError{Op: "SkipSubTimer", Current: s.state}
+	}
+
+	if s.state == Paused {
+		s.endPause(t.elapsed)
+	}
+	s.Time = 0
+	s.state = Skipped
+	t.emit(Event{Type: EventSubtimerSkipped, Elapsed: t.elapsed, Subtimer: intPtr(id)})
+
+	if t.stopOnSubtimersStop && (t.state == Running || t.state == Paused) && t.checkSubTimerFinish() {
+		if t.stopTimerLocked() == nil {
+			t.autoStopped = true
+		}
+	}
+
+	return nil
+}
+
+// subtimerIDs returns the ids of all subtimers in ascending order
+func (t *Timer) subtimerIDs() []int {
+	ids := make([]int, 0, len(t.subtimers))
+	for id := range t.subtimers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	return ids
+}
+
+// stopSubTimer records the time of s and marks it as stopped
+func (t *Timer) stopSubTimer(s *subtimer) {
+	s.Time = t.roundFrames(t.subtimerElapsed(s))
+	if s.state == Paused {
+		s.endPause(t.elapsed)
+	}
+	s.state = Stopped
+	s.finishLeg(s.Time)
+}
+
 func (t *Timer) checkSubTimerFinish() bool {
 	for _, s := range t.subtimers {
-		if s.state != Stopped {
+		if s.state != Stopped && s.state != Forfeited && s.state != Skipped {
 			return false
 		}
 	}
@@ -61,6 +251,43 @@ func (t *Timer) startSubTimers() {
 	}
 
 	for _, s := range t.subtimers {
-		s.state = Running
+		// subtimers skipped before the start stay skipped
+		if s.state == Reset {
+			s.state = Running
+		}
+	}
+}
+
+// SetSubtimerUpdates sets whether tick events include the current time of every subtimer
+func (t *Timer) SetSubtimerUpdates(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.subtimerUpdates = enabled
+}
+
+// subtimerTimes returns the current time of every subtimer which is due for an update at now keyed by id
+func (t *Timer) subtimerTimes(now time.Time) map[int]time.Duration {
+	times := make(map[int]time.Duration, len(t.subtimers))
+	for id, s := range t.subtimers {
+		if t.due(s, now) {
+			times[id] = t.subtimerElapsed(s)
+		}
+	}
+
+	return times
+}
+
+// subtimerElapsed returns the current time of s
+func (t *Timer) subtimerElapsed(s *subtimer) time.Duration {
+	switch s.state {
+	case Running:
+		return t.elapsed - s.start - s.paused + t.pauseCredit()
+	case Paused:
+		return s.pausedAt - s.start - s.paused
+	case Stopped, Forfeited:
+		return s.Time
+	default:
+		return 0
 	}
 }
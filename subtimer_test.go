@@ -0,0 +1,261 @@
+package timer_test
+
+import "errors"
+
+import "reflect"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestStopFinishedSubTimer(t *testing.T) {
+	tests := []struct {
+		name   string
+		finish func(t *timer.Timer) error
+		state  timer.State
+	}{
+		{"stopped twice", timertest.StopSubTimer(1), timer.Stopped},
+		{"stopped after forfeit", func(t *timer.Timer) error {
+			_, err := t.ForfeitAllRunning()
+			return err
+		}, timer.Forfeited},
+		{"stopped after skip", func(t *timer.Timer) error {
+			return t.SkipSubTimer(1)
+		}, timer.Skipped},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock)
+			defer tm.Close()
+			if err := tm.AddSubTimer(1); err != nil {
+				t.Fatal(err)
+			}
+			if err := tm.AddSubTimer(2); err != nil {
+				t.Fatal(err)
+			}
+
+			timertest.Run(t, tm, clock,
+				timertest.Step{Do: timertest.Start},
+				timertest.Step{After: time.Second, Do: test.finish},
+				timertest.Step{After: time.Second},
+			)
+			_, err := tm.StopSubTimer(1)
+			if !errors.Is(err, timer.ErrSubtimerFinished) {
+				t.Fatalf("got error %v, want %v", err, timer.ErrSubtimerFinished)
+			}
+			info, err := tm.SubTimer(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.State != test.state {
+				t.Errorf("subtimer state is %v, want %v", info.State, test.state)
+			}
+			// the time recorded when the subtimer finished must not move
+			if test.state != timer.Skipped && info.Time != time.Second {
+				t.Errorf("subtimer time is %v, want %v", info.Time, time.Second)
+			}
+		})
+	}
+}
+
+func TestSubtimerUpdates(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2), timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+	defer tm.Close()
+	ticks := tm.Subscribe(timer.WithFilter(timer.TicksOnly()), timer.WithOverflow(timer.Unbounded))
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second},
+	)
+	// subtimer times are only included once enabled
+	if e := timertest.AssertEmitsType(t, ticks.C, timer.EventTick, 0); e.Subtimers != nil {
+		t.Errorf("tick includes subtimers %v, want none", e.Subtimers)
+	}
+
+	tm.SetSubtimerUpdates(true)
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.StopSubTimer(2)},
+		timertest.Step{After: time.Second},
+	)
+	e := timertest.AssertEmitsType(t, ticks.C, timer.EventTick, 0)
+	if want := map[int]time.Duration{1: 2 * time.Second, 2: time.Second}; !reflect.DeepEqual(e.Subtimers, want) {
+		t.Errorf("tick includes subtimers %v, want %v", e.Subtimers, want)
+	}
+}
+
+func TestAddSubTimerWhileRunning(t *testing.T) {
+	add := func(tm *timer.Timer) error { return tm.AddSubTimer(1) }
+	tests := []struct {
+		name  string
+		steps []timertest.Step
+		want  time.Duration
+	}{
+		{"running", []timertest.Step{
+			{After: 2 * time.Second, Do: add},
+		}, 3 * time.Second},
+		{"paused", []timertest.Step{
+			{After: 2 * time.Second, Do: timertest.Pause},
+			{After: time.Second, Do: add},
+			{After: time.Second, Do: timertest.Resume},
+		}, 3 * time.Second},
+		{"with offset", []timertest.Step{
+			{After: 2 * time.Second, Do: func(tm *timer.Timer) error { return tm.AddSubTimerAt(1, time.Second) }},
+		}, 4 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock)
+			defer tm.Close()
+
+			timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+			timertest.Run(t, tm, clock, test.steps...)
+			clock.Advance(5*time.Second - tm.ActiveTime())
+			d, err := tm.StopSubTimer(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d != test.want {
+				t.Errorf("subtimer stopped at %v, want %v", d, test.want)
+			}
+		})
+	}
+}
+
+func TestAddSubTimerInvalid(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+
+	var stateErr *timer.StateError
+	if err := tm.AddSubTimerAt(2, time.Second); !errors.As(err, &stateErr) {
+		t.Errorf("adding with an offset before start returned %v, want a state error", err)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	if err := tm.AddSubTimer(1); !errors.Is(err, timer.ErrSubtimerExists) {
+		t.Errorf("adding a duplicate id returned %v, want %v", err, timer.ErrSubtimerExists)
+	}
+	if err := tm.AddSubTimerAt(2, -time.Second); err == nil {
+		t.Errorf("adding with a negative offset succeeded, want an error")
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+	if err := tm.AddSubTimer(2); !errors.As(err, &stateErr) {
+		t.Errorf("adding to a stopped timer returned %v, want a state error", err)
+	}
+}
+
+func TestUndoStopSubTimer(t *testing.T) {
+	undo := func(tm *timer.Timer) error { return tm.UndoStopSubTimer(1) }
+	tests := []struct {
+		name string
+		opts []timer.Option
+	}{
+		{"running timer", nil},
+		{"timer stopped by the subtimer", []timer.Option{timer.WithStopOnSubtimersStop()}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock, append(test.opts, timer.WithSubtimers(1))...)
+			defer tm.Close()
+			undone := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventSubtimerStopUndone}}))
+
+			timertest.Run(t, tm, clock,
+				timertest.Step{Do: timertest.Start},
+				timertest.Step{After: 2 * time.Second, Do: timertest.StopSubTimer(1)},
+				timertest.Step{After: 2 * time.Second, Do: undo},
+			)
+			e := timertest.AssertEmitsType(t, undone.C, timer.EventSubtimerStopUndone, 0)
+			if e.Subtimer == nil || *e.Subtimer != 1 || e.Subtimers[1] != 4*time.Second {
+				t.Errorf("undo event has subtimer %v at %v, want subtimer 1 at 4s", e.Subtimer, e.Subtimers)
+			}
+			if s := tm.State(); s != timer.Running {
+				t.Errorf("timer is %v after the undo, want running", s)
+			}
+			if r := tm.Report(); len(r.Splits) != 0 {
+				t.Errorf("report has splits %+v after the undo, want none", r.Splits)
+			}
+
+			// the subtimer continues as if it had never been stopped
+			timertest.Run(t, tm, clock, timertest.Step{After: time.Second, Do: timertest.StopSubTimer(1)})
+			if r := tm.Report(); len(r.Splits) != 1 || r.Splits[0].Time != 5*time.Second {
+				t.Errorf("splits are %+v, want subtimer 1 at 5s", r.Splits)
+			}
+		})
+	}
+}
+
+func TestUndoStopSubTimerInvalid(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2))
+	defer tm.Close()
+
+	var stateErr *timer.StateError
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.StopSubTimer(2)},
+	)
+	if err := tm.UndoStopSubTimer(1); !errors.As(err, &stateErr) {
+		t.Errorf("undoing a running subtimer returned %v, want a state error", err)
+	}
+	if err := tm.UndoStopSubTimer(3); !errors.Is(err, timer.ErrSubtimerNotFound) {
+		t.Errorf("undoing an unknown subtimer returned %v, want %v", err, timer.ErrSubtimerNotFound)
+	}
+	// only stops of the timer caused by the subtimer are undone
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+	if err := tm.UndoStopSubTimer(2); !errors.As(err, &stateErr) {
+		t.Errorf("undoing after stopping the timer returned %v, want a state error", err)
+	}
+}
+
+func TestSkipSubTimer(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2, 3), timer.WithStopOnSubtimersStop())
+	defer tm.Close()
+	skipped := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventSubtimerSkipped}}))
+	skip := func(id int) func(tm *timer.Timer) error {
+		return func(tm *timer.Timer) error { return tm.SkipSubTimer(id) }
+	}
+
+	// subtimers can be skipped before the start and while paused on their own
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: skip(1)},
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: func(tm *timer.Timer) error { return tm.PauseSubTimer(2) }},
+		timertest.Step{After: time.Second, Do: skip(2)},
+	)
+	for _, id := range []int{1, 2} {
+		e := timertest.AssertEmitsType(t, skipped.C, timer.EventSubtimerSkipped, 0)
+		if e.Subtimer == nil || *e.Subtimer != id {
+			t.Errorf("skip event for subtimer %v, want %v", e.Subtimer, id)
+		}
+	}
+	var stateErr *timer.StateError
+	if err := tm.SkipSubTimer(2); !errors.As(err, &stateErr) {
+		t.Errorf("skipping twice returned %v, want a state error", err)
+	}
+
+	// skipped subtimers don't hold up the stop of the timer
+	timertest.Run(t, tm, clock, timertest.Step{After: time.Second, Do: timertest.StopSubTimer(3)})
+	if s := tm.State(); s != timer.Stopped {
+		t.Errorf("timer is %v after the last subtimer stopped, want stopped", s)
+	}
+	r := tm.Report()
+	for _, s := range r.Subtimers {
+		if s.ID != 3 && (s.State != timer.Skipped || s.Time != 0) {
+			t.Errorf("subtimer %v is %v at %v, want skipped without time", s.ID, s.State, s.Time)
+		}
+	}
+	if len(r.Splits) != 1 || r.Splits[0].ID != 3 {
+		t.Errorf("splits are %+v, want only subtimer 3", r.Splits)
+	}
+}
@@ -0,0 +1,171 @@
+package timer
+
+import "encoding/json"
+
+import "fmt"
+
+import "net/http"
+
+import "sync"
+
+import "time"
+
+// SyncResponse is the answer of the authoritative clock to a sync request
+type SyncResponse struct {
+	// Received is the server time at which the request was received
+	Received time.Time `json:"received"`
+	// Sent is the server time at which the response was sent
+	Sent time.Time `json:"sent"`
+}
+
+// SyncTransport sends a single sync request to the authoritative clock and returns its response
+// sent is the local time at which the request is sent
+type SyncTransport func(sent time.Time) (SyncResponse, error)
+
+// ClientSync estimates the offset of the local clock to an authoritative clock
+// the estimation follows the NTP algorithm and keeps the sample with the lowest round trip delay
+type ClientSync struct {
+	transport SyncTransport
+	mu        sync.RWMutex
+	offset    time.Duration
+	delay     time.Duration
+	synced    bool
+}
+
+// NewClientSync returns a new ClientSync using transport for exchanging sync requests
+func NewClientSync(transport SyncTransport) *ClientSync {
+	return &ClientSync{transport: transport}
+}
+
+// Sync performs rounds sync exchanges and updates the estimated offset
+// the estimation is only replaced if one of the new samples has a lower delay than the current one
+func (c *ClientSync) Sync(rounds int) error {
+	if rounds <= 0 {
+		return fmt.Errorf("Only positive values for rounds are allowed")
+	}
+
+	var lastErr error
+	for i := 0; i < rounds; i++ {
+		sent := time.Now()
+		res, err := c.transport(sent)
+		received := time.Now()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		// t0 = sent, t1 = res.Received, t2 = res.Sent, t3 = received
+		delay := received.Sub(sent) - res.Sent.Sub(res.Received)
+		offset := (res.Received.Sub(sent) + res.Sent.Sub(received)) / 2
+
+		c.mu.Lock()
+		if !c.synced || delay < c.delay {
+			c.offset, c.delay, c.synced = offset, delay, true
+		}
+		c.mu.Unlock()
+	}
+
+	if !c.Synced() {
+		return fmt.Errorf("Sync failed: %v", lastErr)
+	}
+
+	return nil
+}
+
+// Synced reports whether at least one sync exchange succeeded
+func (c *ClientSync) Synced() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.synced
+}
+
+// Offset returns the estimated offset which has to be added to the local clock to get the authoritative time
+func (c *ClientSync) Offset() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.offset
+}
+
+// Delay returns the round trip delay of the sample the offset was estimated from
+func (c *ClientSync) Delay() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.delay
+}
+
+// Now returns the current authoritative time
+func (c *ClientSync) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// SyncHandler answers sync requests over HTTP using the local clock as authoritative clock
+func SyncHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := SyncResponse{Received: time.Now()}
+		w.Header().Set("Content-Type", "application/json")
+		res.Sent = time.Now()
+		json.NewEncoder(w).Encode(res)
+	})
+}
+
+// HTTPSyncTransport returns a SyncTransport exchanging sync requests with a SyncHandler at url
+// if client is nil http.DefaultClient is used
+func HTTPSyncTransport(client *http.Client, url string) SyncTransport {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(sent time.Time) (SyncResponse, error) {
+		var res SyncResponse
+		resp, err := client.Get(url)
+		if err != nil {
+			return res, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return res, fmt.Errorf("Sync request failed with status %v", resp.Status)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&res)
+
+		return res, err
+	}
+}
+
+// Health reports the sync state as health of a time source, so c can be used with SetTimeSource
+// the uncertainty is half the round trip delay of the sample the offset was estimated from
+func (c *ClientSync) Health() SourceHealth {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.synced {
+		return SourceHealth{Status: SourceUnavailable}
+	}
+
+	return SourceHealth{Status: SourceLocked, Uncertainty: c.delay / 2}
+}
+
+// StartTimerAtSynced starts the timer at the authoritative instant ts estimated by c
+// timers on different machines synced to the same clock start at the same instant. If ts has already passed
+// the timer is started immediately and counts the time since ts. Only possible when timer is in Reset state
+func (t *Timer) StartTimerAtSynced(ts time.Time, c *ClientSync) error {
+	if !c.Synced() {
+		return fmt.Errorf("StartTimerAtSynced requires a synced clock")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if !t.checkValidState(startOp) {
+		return t.logFailed("StartTimerAtSynced", &StateError{Op: "StartTimerAtSynced", Current: t.state})
+	}
+
+	// the local instant is derived from the clock of the timer, so time sources and fake clocks are honored
+	delay := ts.Sub(c.Now())
+
+	return t.scheduleStart("StartTimerAtSynced", t.clock.Now().Add(delay), delay)
+}
@@ -0,0 +1,123 @@
+package timer_test
+
+import "errors"
+
+import "net/http/httptest"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+const syncTolerance = 10 * time.Millisecond
+
+func TestClientSyncKeepsLowestDelay(t *testing.T) {
+	// the first response claims the server sent it 50ms before receiving the request, which adds 50ms of delay
+	responses := []func(sent time.Time) timer.SyncResponse{
+		func(sent time.Time) timer.SyncResponse {
+			return timer.SyncResponse{Received: sent.Add(time.Hour + 100*time.Millisecond), Sent: sent.Add(time.Hour + 50*time.Millisecond)}
+		},
+		func(sent time.Time) timer.SyncResponse {
+			return timer.SyncResponse{Received: sent.Add(time.Hour), Sent: sent.Add(time.Hour)}
+		},
+		func(sent time.Time) timer.SyncResponse {
+			return timer.SyncResponse{Received: sent.Add(2 * time.Hour), Sent: sent.Add(time.Hour)}
+		},
+	}
+	round := 0
+	c := timer.NewClientSync(func(sent time.Time) (timer.SyncResponse, error) {
+		res := responses[round](sent)
+		round++
+		return res, nil
+	})
+
+	if c.Synced() {
+		t.Errorf("synced before the first sync")
+	}
+	if err := c.Sync(len(responses)); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Synced() {
+		t.Errorf("not synced after syncing")
+	}
+	if d := c.Offset() - time.Hour; d < -syncTolerance || d > syncTolerance {
+		t.Errorf("offset is %v, want 1h", c.Offset())
+	}
+	if d := c.Delay(); d < 0 || d > syncTolerance {
+		t.Errorf("delay is %v, want about 0", d)
+	}
+	if d := c.Now().Sub(time.Now().Add(time.Hour)); d < -syncTolerance || d > syncTolerance {
+		t.Errorf("authoritative time is off by %v", d)
+	}
+	if h := c.Health(); h.Status != timer.SourceLocked || h.Uncertainty != c.Delay()/2 {
+		t.Errorf("health is %+v, want locked with half the delay as uncertainty", h)
+	}
+}
+
+func TestClientSyncFails(t *testing.T) {
+	errTransport := errors.New("unreachable")
+	c := timer.NewClientSync(func(time.Time) (timer.SyncResponse, error) {
+		return timer.SyncResponse{}, errTransport
+	})
+
+	if err := c.Sync(0); err == nil {
+		t.Errorf("syncing 0 rounds succeeded, want an error")
+	}
+	if err := c.Sync(3); err == nil {
+		t.Errorf("syncing succeeded, want an error")
+	}
+	if c.Synced() {
+		t.Errorf("synced after failed exchanges")
+	}
+	if h := c.Health(); h.Status != timer.SourceUnavailable {
+		t.Errorf("health is %v, want %v", h.Status, timer.SourceUnavailable)
+	}
+	tm := timer.New()
+	defer tm.Close()
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.StartTimerAtSynced(time.Now(), c); err == nil {
+		t.Errorf("starting with an unsynced clock succeeded, want an error")
+	}
+}
+
+func TestHTTPSync(t *testing.T) {
+	srv := httptest.NewServer(timer.SyncHandler())
+	defer srv.Close()
+
+	c := timer.NewClientSync(timer.HTTPSyncTransport(srv.Client(), srv.URL))
+	if err := c.Sync(3); err != nil {
+		t.Fatal(err)
+	}
+	// client and server share the local clock
+	if d := c.Offset(); d < -c.Delay() || d > c.Delay() {
+		t.Errorf("offset is %v, want at most the delay of %v", d, c.Delay())
+	}
+}
+
+func TestStartTimerAtSynced(t *testing.T) {
+	c := timer.NewClientSync(func(sent time.Time) (timer.SyncResponse, error) {
+		return timer.SyncResponse{Received: sent.Add(time.Hour), Sent: sent.Add(time.Hour)}, nil
+	})
+	if err := c.Sync(1); err != nil {
+		t.Fatal(err)
+	}
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	// the start is scheduled in the local time of the timer's clock
+	if err := tm.StartTimerAtSynced(c.Now().Add(2*time.Second), c); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	if s := tm.State(); s != timer.Reset {
+		t.Errorf("state is %v before the synced instant, want %v", s, timer.Reset)
+	}
+	clock.Advance(time.Second)
+	waitState(t, tm, timer.Running, time.Second)
+}
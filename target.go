@@ -0,0 +1,101 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// SetTargetDuration sets the elapsed time at which the timer stops automatically
+// the timer stops exactly at d regardless of the update interval, sends a final update with d and emits EventTargetReached.
+// Setting 0 disables the target
+func (t *Timer) SetTargetDuration(d time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrClosed
+	}
+	if d < 0 {
+		return t.logFailed("SetTargetDuration", fmt.Errorf("Only positive values for d are allowed"))
+	}
+	t.target = d
+	t.armTarget()
+
+	return nil
+}
+
+// TargetDuration returns the elapsed time at which the timer stops automatically. It is 0 if no target is set
+func (t *Timer) TargetDuration() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.target
+}
+
+// armTarget schedules the tick stopping a running timer at its target
+func (t *Timer) armTarget() {
+	t.disarmTarget()
+	if t.target == 0 || t.state != Running {
+		return
+	}
+
+	delay := t.target - t.currentElapsed()
+	if delay < 0 {
+		delay = 0
+	}
+	t.targetAlarm = t.clock.AfterFunc(delay, func() {
+		t.mu.Lock()
+		// an alarm which fired while being disarmed finds the target not reached yet
+		if t.target == 0 || t.state != Running || t.currentElapsed() < t.target {
+			t.mu.Unlock()
+			return
+		}
+		t.alarmTick(t.startTime.Add(t.target))
+	})
+}
+
+// alarmTick ticks the timer at the instant at from an alarm and delivers the resulting update
+// it has to be called with t.mu held and releases it before sending the update. Like a loop the send is tracked by t.loops,
+// so shutdown doesn't close the updates channel under it
+func (t *Timer) alarmTick(at time.Time) {
+	update, send := t.tick(at)
+	var quit chan struct{}
+	if send {
+		t.fanOutUpdate(update)
+		quit = t.updateQuit()
+		t.loops.Add(1)
+	}
+	t.mu.Unlock()
+
+	if send {
+		defer t.loops.Done()
+		t.sendUpdate(update, quit)
+	}
+}
+
+func (t *Timer) disarmTarget() {
+	if t.targetAlarm == nil {
+		return
+	}
+	t.targetAlarm.Stop()
+	t.targetAlarm = nil
+}
+
+// checkTarget clamps the elapsed time of a timer which reached its target and reports whether it did
+func (t *Timer) checkTarget() bool {
+	if t.target == 0 || t.elapsed < t.target {
+		return false
+	}
+	t.elapsed = t.target
+
+	return true
+}
+
+// reachTarget stops the timer at the instant it reached its target
+func (t *Timer) reachTarget() {
+	if t.state != Running {
+		return
+	}
+	t.emit(Event{Type: EventTargetReached, Elapsed: t.elapsed})
+	t.stopTimerLocked()
+	t.stopTime = t.startTime.Add(t.target)
+}
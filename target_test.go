@@ -0,0 +1,135 @@
+package timer_test
+
+import "runtime"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestFinalUpdateDroppedOnReset(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := timer.New(timer.WithClock(clock))
+	defer tm.Close()
+	if err := tm.SetTargetDuration(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// nobody reads the updates, so the final update sent when the target is reached waits for a consumer
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Reset},
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second},
+	)
+	waitState(t, tm, timer.Stopped, time.Second)
+	time.Sleep(50 * time.Millisecond)
+	waiting := runtime.NumGoroutine()
+
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() >= waiting {
+		if time.Now().After(deadline) {
+			t.Fatalf("the final update is still waiting for a consumer after reset")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTargetDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions map[time.Duration]func(*timer.Timer) error
+		// reached is the simulated time at which the target has to be reached
+		reached time.Duration
+	}{
+		{"running", nil, 2 * time.Second},
+		{"paused before", map[time.Duration]func(*timer.Timer) error{
+			300 * time.Millisecond: (*timer.Timer).PauseTimer,
+			time.Second:            (*timer.Timer).ResumeTimer,
+		}, 2700 * time.Millisecond},
+		{"adjusted", map[time.Duration]func(*timer.Timer) error{
+			300 * time.Millisecond: func(tm *timer.Timer) error { return tm.Adjust(500 * time.Millisecond) },
+		}, 1500 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// ticks every 700ms never hit the target, the timer has to stop exactly at it anyway
+			s, err := timer.NewSimulation(time.Now(), 700*time.Millisecond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Timer.Close()
+			if err := s.Timer.SetTargetDuration(2 * time.Second); err != nil {
+				t.Fatal(err)
+			}
+			s.At(0, (*timer.Timer).StartTimer)
+			for at, do := range test.actions {
+				s.At(at, do)
+			}
+			events, err := s.Run(5 * time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reached := false
+			for _, e := range events {
+				if e.Type == timer.EventTargetReached {
+					reached = true
+					if e.Elapsed != 2*time.Second {
+						t.Errorf("target reached at %v, want 2s", e.Elapsed)
+					}
+				}
+			}
+			if !reached {
+				t.Fatalf("target not reached")
+			}
+			r := s.Timer.Report()
+			if r.State != timer.Stopped || r.FinalTime != 2*time.Second {
+				t.Errorf("timer is %v at %v, want stopped at 2s", r.State, r.FinalTime)
+			}
+			if r.WallTime != test.reached {
+				t.Errorf("timer stopped after %v, want %v", r.WallTime, test.reached)
+			}
+		})
+	}
+}
+
+func TestSetTargetDuration(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	if err := tm.SetTargetDuration(-time.Second); err == nil {
+		t.Errorf("setting a negative target succeeded, want an error")
+	}
+	if err := tm.SetTargetDuration(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if d := tm.TargetDuration(); d != time.Second {
+		t.Errorf("target is %v, want 1s", d)
+	}
+	// 0 disables the target
+	if err := tm.SetTargetDuration(0); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	clock.Advance(2 * time.Second)
+	if s := tm.State(); s != timer.Running {
+		t.Errorf("timer is %v without a target, want running", s)
+	}
+
+	// a target which already passed stops the timer right away
+	if err := tm.SetTargetDuration(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	waitState(t, tm, timer.Stopped, time.Second)
+	if d := tm.Elapsed(); d != time.Second {
+		t.Errorf("timer stopped at %v, want the target of 1s", d)
+	}
+}
@@ -0,0 +1,51 @@
+package timer
+
+import "fmt"
+
+import "time"
+
+// SetDefaultSubTimerThrottle sets the minimum time between two updates of a subtimer in tick events
+// it applies to all subtimers without their own throttle. Setting 0 includes subtimers in every tick event
+func (t *Timer) SetDefaultSubTimerThrottle(interval time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if interval < 0 {
+		return fmt.Errorf("Only positive values for interval are allowed")
+	}
+	t.subtimerThrottle = interval
+
+	return nil
+}
+
+// SetSubTimerThrottle sets the minimum time between two updates of a specific subtimer in tick events
+// Setting 0 sets it back to the default throttle
+func (t *Timer) SetSubTimerThrottle(id int, interval time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.subtimers[id]
+	if !ok {
+		return errSubtimerNotFound(id)
+	}
+	if interval < 0 {
+		return fmt.Errorf("Only positive values for interval are allowed")
+	}
+	s.throttle = interval
+
+	return nil
+}
+
+// due reports whether s should be included in the tick event at now and records the update if so
+func (t *Timer) due(s *subtimer, now time.Time) bool {
+	throttle := s.throttle
+	if throttle == 0 {
+		throttle = t.subtimerThrottle
+	}
+	if throttle > 0 && !s.lastUpdate.IsZero() && now.Sub(s.lastUpdate) < throttle {
+		return false
+	}
+	s.lastUpdate = now
+
+	return true
+}
@@ -0,0 +1,50 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestSubTimerThrottle(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2, 3), timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+	defer tm.Close()
+	ticks := tm.Subscribe(timer.WithFilter(timer.TicksOnly()), timer.WithOverflow(timer.Unbounded))
+	tm.SetSubtimerUpdates(true)
+
+	if err := tm.SetDefaultSubTimerThrottle(-time.Second); err == nil {
+		t.Errorf("setting a negative default throttle succeeded, want an error")
+	}
+	if err := tm.SetSubTimerThrottle(4, time.Second); err == nil {
+		t.Errorf("throttling an unknown subtimer succeeded, want an error")
+	}
+	if err := tm.SetDefaultSubTimerThrottle(2 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetSubTimerThrottle(2, 3*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetSubTimerThrottle(3, time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// included is the set of subtimers in each tick, subtimers are included in their first tick
+	included := [][]int{{1, 2, 3}, {3}, {1, 3}, {2, 3}, {1, 3}}
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Start})
+	for i, want := range included {
+		clock.Advance(time.Second)
+		e := timertest.AssertEmitsType(t, ticks.C, timer.EventTick, 0)
+		if len(e.Subtimers) != len(want) {
+			t.Errorf("tick %v includes subtimers %v, want %v", i+1, e.Subtimers, want)
+			continue
+		}
+		for _, id := range want {
+			if _, ok := e.Subtimers[id]; !ok {
+				t.Errorf("tick %v includes subtimers %v, want %v", i+1, e.Subtimers, want)
+			}
+		}
+	}
+}
@@ -2,6 +2,8 @@ package timer
 
 import "fmt"
 
+import "sync"
+
 import "time"
 
 // State describes the different Timerstates
@@ -17,6 +19,10 @@ const (
 	Paused
 	// Stopped represents a stopped timer
 	Stopped
+	// Forfeited represents a subtimer which was given up before finishing
+	Forfeited
+	// Skipped represents a subtimer which was skipped and has no time
+	Skipped
 )
 
 const (
@@ -28,8 +34,8 @@ const (
 )
 
 const (
-	defaultUpdateInterval = 10
-	defaultTickerInterval = 10
+	defaultUpdateInterval = 10 * time.Millisecond
+	defaultTickerInterval = 10 * time.Millisecond
 )
 
 // Config allows configuring various settings when creating a new timer
@@ -42,45 +48,197 @@ type Config struct {
 	ContinueCountingWhenStopped bool
 	// StopOnSubtimersFinish will stop the timer when all subtimers are set to stop
 	StopOnSubtimersStop bool
+	// PausePolicy sets which clocks freeze when the timer is paused
+	PausePolicy PausePolicy
+	// Bindings binds external trigger sources to subtimer actions
+	Bindings []Binding
 }
 
 // Timer is the main struct holding all relevant data
+// all methods are safe for concurrent use
 type Timer struct {
+	// mu guards all fields below
+	mu sync.Mutex
 	// internal ticker
-	updateInterval int
-	tickerInterval int
-	ticker         *time.Ticker
-	updateTicker   *time.Ticker
+	updateInterval time.Duration
+	tickerInterval time.Duration
+	ticker         Ticker
+	clock          Clock
+	// source disciplines clock if set, sourceHealth is its last reported health
+	source       *sourceClock
+	sourceHealth SourceHealth
+	// highResolution requests a higher system timer resolution while running
+	highResolution bool
+	resolutionHeld bool
+	jitter         jitter
+	// profiling
+	label        string
+	tickObserver TickObserver
+	// countdown is the target of countdown timers and 0 for timers counting up. countdownAlarm expires it exactly at zero
+	countdown      time.Duration
+	countdownAlarm Alarm
+	// quit is closed when the timer is shut down, closed is set at the same time. loops tracks the running loops
+	// loopQuit is closed to make the current loop exit and nil while no loop is running
+	// finalQuit is closed to drop the final update of a run which is still waiting for a consumer once the timer runs again or is reset
+	// workers tracks the goroutines of subscriptions, callbacks and samplers
+	// subscribed is closed once the timer got its first subscriber, from then on the updates channel doesn't block anymore
+	quit       chan struct{}
+	loopQuit   chan struct{}
+	finalQuit  chan struct{}
+	subscribed chan struct{}
+	closed     bool
+	loops      sync.WaitGroup
+	workers    sync.WaitGroup
+	// manual timers don't run their own loop but are ticked by their owner
+	manual bool
+	pump   *pump
 	// public
-	State   State
 	Updates chan time.Duration
 	// internal state
+	state     State
+	epoch     time.Time
 	startTime time.Time
-	elapsed   time.Duration
-	pauseTime time.Time
-	subtimers map[int]*subtimer
+	// seek is the elapsed time the next run starts at. It is set to startOffset on reset and by SetElapsed
+	seek        time.Duration
+	startOffset time.Duration
+	// firstStart is the wall clock time the run started at and stopTime the time it was stopped at. Unlike startTime they are not shifted by pauses
+	// stopLoss is the wall clock time the run was stopped before it was resumed
+	firstStart time.Time
+	stopTime   time.Time
+	stopLoss   time.Duration
+	elapsed    time.Duration
+	pauseTime  time.Time
+	pauses     []Pause
+	lastTick   time.Time
+	// lastUpdate is the time the last update was emitted at
+	lastUpdate time.Time
+	ticks      uint64
+	subtimers  map[int]*subtimer
+	// defaultSubtimers are added on every reset
+	defaultSubtimers []int
+	segments         []string
+	bindings         map[string]Binding
+	ledger           []Adjustment
+	laps             []LapResult
+	// payload attached to the operation currently in progress
+	payload interface{}
+	// armed destructive operation waiting for confirmation
+	armedOp       operation
+	armedDeadline time.Time
+	// autoResume is set while a pause started by PauseFor is ongoing
+	autoResume Alarm
+	// scheduledStart is set while a start scheduled for a later instant is pending
+	scheduledStart Alarm
+	startCountdown *Countdown
+	// target is the elapsed time at which the timer stops and targetAlarm the alarm stopping it exactly then
+	target      time.Duration
+	targetAlarm Alarm
+	// thresholds are the pending thresholds of NotifyAt ordered by elapsed time and thresholdAlarm the alarm firing the first one
+	thresholds     []threshold
+	thresholdAlarm Alarm
+	// comparison and prediction
+	comparison map[int]time.Duration
+	predictor  Predictor
+	// gameLoss is the real time not counted as game time. gamePausedAt is the real time at which the game time was paused
+	gameLoss     time.Duration
+	gamePaused   bool
+	gamePausedAt time.Duration
+	// frameRate is the frames per second of frame accurate timing and 0 if disabled
+	frameRate     float64
+	frameRounding FrameRounding
+	// phases is the sequence of phases counted down one after another and phase the current one
+	phases phaseSequence
+	phase  Phase
+	// logHook receives log records if a logger is set
+	logHook logHook
+	// history records every run on reset
+	history *History
+	bests   bests
+	// event subscribers
+	events dispatcher
+	// updateSubs receive a copy of every update
+	updateSubs map[chan time.Duration]struct{}
+	// anomaly detection
+	tickGapThreshold    time.Duration
+	divergenceThreshold time.Duration
 	// internal config
-	allowResumeAfterStop        bool
+	subtimerUpdates      bool
+	subtimerThrottle     time.Duration
+	updatePolicy         UpdatePolicy
+	pausedUpdates        PausedUpdates
+	pausedUpdateRate     time.Duration
+	lastFrozen           time.Time
+	pausePolicy          PausePolicy
+	allowResumeAfterStop bool
+	// autoStopped is set if the timer was stopped because all subtimers stopped
+	autoStopped                 bool
 	continueCountingWhenStopped bool
 	stopOnSubtimersStop         bool
 }
 
-// New initializes and returns a new timer with
-func New() *Timer {
-	return &Timer{
-		updateInterval: defaultUpdateInterval,
-		tickerInterval: defaultTickerInterval,
-		State:          Stopped,
-		Updates:        make(chan time.Duration),
-		subtimers:      make(map[int]*subtimer),
+// New initializes and returns a new timer with the given options applied
+func New(opts ...Option) *Timer {
+	t := &Timer{
+		updateInterval:      defaultUpdateInterval,
+		tickerInterval:      defaultTickerInterval,
+		state:               Stopped,
+		clock:               realClock{},
+		epoch:               time.Now(),
+		Updates:             make(chan time.Duration),
+		quit:                make(chan struct{}),
+		subscribed:          make(chan struct{}),
+		subtimers:           make(map[int]*subtimer),
+		divergenceThreshold: defaultDivergenceThreshold,
+		pausedUpdateRate:    defaultPausedUpdateRate,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// NewWithConfig initializes and returns a new timer with cfg and the given options applied
+// options are applied after cfg. It returns an error if cfg is inconsistent
+func NewWithConfig(cfg Config, opts ...Option) (*Timer, error) {
+	if cfg.ContinueCountingWhenStopped && !cfg.AllowResumeAfterStop {
+		return nil, fmt.Errorf("ContinueCountingWhenStopped requires AllowResumeAfterStop")
+	}
+	if !cfg.PausePolicy.valid() {
+		return nil, fmt.Errorf("Unknown pause policy %v", cfg.PausePolicy)
+	}
+
+	t := New()
+	t.allowResumeAfterStop = cfg.AllowResumeAfterStop
+	t.continueCountingWhenStopped = cfg.ContinueCountingWhenStopped
+	t.stopOnSubtimersStop = cfg.StopOnSubtimersStop
+	t.pausePolicy = cfg.PausePolicy
+	for _, b := range cfg.Bindings {
+		if err := t.Bind(b); err != nil {
+			return nil, err
+		}
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t, nil
 }
 
-// SetUpdateInterval sets a new updateInterval for the timer
+// SetUpdateInterval sets a new updateInterval in milliseconds for the timer
 // Only works when timer is stopped. Setting 0 for updateInterval sets it back to the default
 func (t *Timer) SetUpdateInterval(updateInterval int) error {
-	if t.State != Stopped {
-		return fmt.Errorf("UpdateInterval can only be changed when timer is stopped")
+	return t.SetUpdateIntervalDuration(time.Duration(updateInterval) * time.Millisecond)
+}
+
+// SetUpdateIntervalDuration sets a new updateInterval for the timer, it may be below one millisecond
+// Only works when timer is stopped. Setting 0 for updateInterval sets it back to the default
+func (t *Timer) SetUpdateIntervalDuration(updateInterval time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != Stopped {
+		return &StateError{Op: "SetUpdateInterval", Current: t.state}
 	}
 	if updateInterval < 0 {
 		return fmt.Errorf("Only positive values for updateInterval are allowed")
@@ -95,19 +253,71 @@ func (t *Timer) SetUpdateInterval(updateInterval int) error {
 	return nil
 }
 
+// SetTickerInterval sets a new tickerInterval in milliseconds for the timer
+// a shorter interval checks the timer more often at the cost of CPU time.
+// Only works when timer is stopped. Setting 0 for tickerInterval sets it back to the default
+func (t *Timer) SetTickerInterval(tickerInterval int) error {
+	return t.SetTickerIntervalDuration(time.Duration(tickerInterval) * time.Millisecond)
+}
+
+// SetTickerIntervalDuration sets a new tickerInterval for the timer, it may be below one millisecond
+// Only works when timer is stopped. Setting 0 for tickerInterval sets it back to the default
+func (t *Timer) SetTickerIntervalDuration(tickerInterval time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != Stopped {
+		return &StateError{Op: "SetTickerInterval", Current: t.state}
+	}
+	if tickerInterval < 0 {
+		return fmt.Errorf("Only positive values for tickerInterval are allowed")
+	}
+
+	if tickerInterval == 0 {
+		t.tickerInterval = defaultTickerInterval
+		return nil
+	}
+	t.tickerInterval = tickerInterval
+
+	return nil
+}
+
 // StartTimer starts the timer
 // only possible when timer is in Reset state
 func (t *Timer) StartTimer() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("StartTimer", t.startTimerLocked())
+}
+
+func (t *Timer) startTimerLocked() error {
+	return t.startTimerAtLocked(t.clock.Now())
+}
+
+// startTimerAtLocked starts the timer as if it had been started at the instant at of the clock of the timer
+func (t *Timer) startTimerAtLocked(at time.Time) error {
+	if t.closed {
+		return ErrClosed
+	}
 	if !t.checkValidState(startOp) {
-		return fmt.Errorf("StartTimer called with invalid state")
+		return &StateError{Op: "StartTimer", Current: t.state}
+	}
+
+	if err := t.acquireResolution(); err != nil {
+		return fmt.Errorf("Could not raise timer resolution: %v", err)
 	}
 
-	t.State = Running
-	t.ticker = time.NewTicker(time.Duration(t.tickerInterval) * time.Millisecond)
-	t.updateTicker = time.NewTicker(time.Duration(t.updateInterval) * time.Millisecond)
-	t.startTime = time.Now()
-	go t.startSubTimers()
-	go t.timerLoop()
+	t.stopScheduledStart()
+	t.state = Running
+	t.startTime = at.Add(-t.seek)
+	t.firstStart = at
+	t.lastTick = time.Time{}
+	t.lastUpdate = time.Time{}
+	t.emit(Event{Type: EventStarted, Time: t.startTime})
+	t.startSubTimers()
+	t.armAlarms()
+	t.startLoop()
 
 	return nil
 }
@@ -115,13 +325,32 @@ func (t *Timer) StartTimer() error {
 // StopTimer stops the timer
 // only possible when in Running state
 func (t *Timer) StopTimer() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("StopTimer", t.stopTimerLocked())
+}
+
+func (t *Timer) stopTimerLocked() error {
+	if t.closed {
+		return ErrClosed
+	}
+	t.refreshElapsed()
 	if !t.checkValidState(stopOp) {
-		return fmt.Errorf("StopTimer called with invalid state")
+		return &StateError{Op: "StopTimer", Current: t.state}
 	}
 
-	t.State = Stopped
-	t.ticker.Stop()
-	t.updateTicker.Stop()
+	t.stopTime = t.clock.Now()
+	// a pause ongoing at the stop ends with it
+	if t.state == Paused {
+		t.pauses[len(t.pauses)-1].Duration = t.stopTime.Sub(t.pauseTime)
+	}
+	t.state = Stopped
+	t.disarmAlarms()
+	t.stopLoop()
+	t.releaseResolution()
+	t.updateBests()
+	t.emit(Event{Type: EventStopped, Elapsed: t.elapsed})
 
 	return nil
 }
@@ -129,77 +358,362 @@ func (t *Timer) StopTimer() error {
 // ResetTimer resets the timer to it's default state
 // only possible when in Stopped state
 func (t *Timer) ResetTimer() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("ResetTimer", t.resetTimerLocked())
+}
+
+func (t *Timer) resetTimerLocked() error {
+	if t.closed {
+		return ErrClosed
+	}
 	if !t.checkValidState(resetOp) {
-		return fmt.Errorf("ResetTimer called with invalid state")
+		return &StateError{Op: "ResetTimer", Current: t.state}
 	}
 
+	t.recordHistory()
+	t.seek = t.startOffset
+	t.elapsed = t.startOffset
+	t.resetPhases()
+	t.resetGameTime()
 	t.subtimers = make(map[int]*subtimer)
-	t.State = Reset
-	t.ticker = nil
-	t.updateTicker = nil
+	for _, id := range t.defaultSubtimers {
+		t.subtimers[id] = &subtimer{state: Reset}
+	}
+	t.pauses = nil
+	t.ledger = nil
+	t.laps = nil
+	t.firstStart = time.Time{}
+	t.stopTime = time.Time{}
+	t.stopLoss = 0
+	t.jitter = jitter{}
+	t.clearEventLog()
+	t.autoStopped = false
+	t.dropFinalUpdate()
+	t.state = Reset
+	t.emit(Event{Type: EventReset})
 
 	return nil
 }
 
+// State returns the current state of the timer
+func (t *Timer) State() State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.state
+}
+
+// IsRunning reports whether the timer is running
+func (t *Timer) IsRunning() bool {
+	return t.State() == Running
+}
+
+// IsPaused reports whether the timer is paused
+func (t *Timer) IsPaused() bool {
+	return t.State() == Paused
+}
+
+// IsStopped reports whether the timer is stopped
+func (t *Timer) IsStopped() bool {
+	return t.State() == Stopped
+}
+
+// Elapsed returns the current elapsed time of the timer
+// unlike the updates channel it is measured on demand, so it is accurate between ticks
+func (t *Timer) Elapsed() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.currentElapsed()
+}
+
+// currentElapsed returns the elapsed time at the current time of the clock
+func (t *Timer) currentElapsed() time.Duration {
+	if t.state != Running {
+		return t.elapsed
+	}
+	d := t.clock.Now().Sub(t.startTime)
+	if t.countdown > 0 && d > t.countdown {
+		return t.countdown
+	}
+	if t.target > 0 && d > t.target {
+		return t.target
+	}
+
+	return d
+}
+
+// refreshElapsed measures the elapsed time of a running timer at the moment of the call
+// operations recording times call it so they don't use the value measured at the last tick
+func (t *Timer) refreshElapsed() {
+	if t.state != Running {
+		return
+	}
+	previous := t.elapsed
+	t.elapsed = t.currentElapsed()
+	t.checkZeroCrossing(previous)
+}
+
 // PauseTimer timer pauses the timer
 // only possible when in Running state
 func (t *Timer) PauseTimer() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("PauseTimer", t.pauseTimerLocked())
+}
+
+func (t *Timer) pauseTimerLocked() error {
+	if t.closed {
+		return ErrClosed
+	}
+	if t.gameOnlyPaused() {
+		return &StateError{Op: "PauseTimer", Current: Paused}
+	}
 	if !t.checkValidState(pauseOp) {
-		return fmt.Errorf("PauseTimer called with invalid state")
+		return &StateError{Op: "PauseTimer", Current: t.state}
 	}
-	t.State = Paused
-	t.pauseTime = time.Now()
+	t.pause(nil)
 
 	return nil
 }
 
+// pause pauses the timer. resumeAt is the time of a scheduled automatic resume and nil if there is none
+func (t *Timer) pause(resumeAt *time.Time) {
+	t.refreshElapsed()
+	if t.pausePolicy == PauseGameOnly {
+		t.pauseGame(resumeAt)
+		return
+	}
+	t.state = Paused
+	t.disarmAlarms()
+	t.pauseTime = t.clock.Now()
+	t.pauses = append(t.pauses, Pause{Start: t.pauseTime, Elapsed: t.elapsed})
+	t.lastFrozen = time.Time{}
+	if t.pausedUpdates == PausedSilent {
+		t.stopLoop()
+	}
+	t.emit(Event{Type: EventPaused, Time: t.pauseTime, Elapsed: t.elapsed, ResumeAt: resumeAt})
+}
+
 // ResumeTimer resumes the timer from a paused state
 // only possible when in Paused or Stopped state
 func (t *Timer) ResumeTimer() error {
-	if t.State == Paused {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.logFailed("ResumeTimer", t.resumeTimerLocked())
+}
+
+func (t *Timer) resumeTimerLocked() error {
+	if t.closed {
+		return ErrClosed
+	}
+	if t.state == Paused {
 		t.resumeAfterPause()
-	} else if t.State == Stopped && t.allowResumeAfterStop {
-		t.resumeAfterStop()
+	} else if t.state == Running && t.gameOnlyPaused() {
+		t.stopAutoResume()
+		t.resumeGame()
+	} else if t.state == Stopped && t.allowResumeAfterStop {
+		return t.resumeAfterStop(t.continueCountingWhenStopped)
+	} else {
+		return &StateError{Op: "ResumeTimer", Current: t.state}
 	}
 
 	return nil
 }
 
-func (t *Timer) resumeAfterStop() {
+// resumeAfterStop continues a stopped run
+// unless continueCounting is set the time the timer was stopped is not counted
+func (t *Timer) resumeAfterStop(continueCounting bool) error {
+	if err := t.acquireResolution(); err != nil {
+		return fmt.Errorf("Could not raise timer resolution: %v", err)
+	}
 
+	now := t.clock.Now()
+	t.autoStopped = false
+	t.stopLoss += now.Sub(t.stopTime)
+	if !continueCounting {
+		t.startTime = t.startTime.Add(now.Sub(t.stopTime))
+	}
+	t.stopTime = time.Time{}
+	t.lastTick = time.Time{}
+	t.lastUpdate = time.Time{}
+	t.state = Running
+	t.elapsed = now.Sub(t.startTime)
+	t.emit(Event{Type: EventResumed, Elapsed: t.elapsed})
+	t.armAlarms()
+	t.startLoop()
+
+	return nil
 }
 
 func (t *Timer) resumeAfterPause() {
-	t.startTime = t.startTime.Add(time.Since(t.pauseTime))
-	t.State = Running
-	go t.timerLoop()
+	t.stopAutoResume()
+	paused := t.clock.Now().Sub(t.pauseTime)
+	t.pauses[len(t.pauses)-1].Duration = paused
+	t.creditPause(paused)
+	t.startTime = t.startTime.Add(paused)
+	t.lastTick = time.Time{}
+	t.lastUpdate = time.Time{}
+	t.state = Running
+	t.emit(Event{Type: EventResumed, Elapsed: t.elapsed})
+	t.armAlarms()
+	t.startLoop()
+}
+
+// startLoop starts the goroutine ticking the timer unless the timer is ticked by its owner or the loop is already running
+func (t *Timer) startLoop() {
+	if t.manual || t.closed || t.loopQuit != nil {
+		return
+	}
+	t.dropFinalUpdate()
+	ticker := t.clock.NewTicker(t.loopInterval())
+	quit := make(chan struct{})
+	t.ticker = ticker
+	t.loopQuit = quit
+	t.loops.Add(1)
+	t.goLabeled(roleLoop, func() {
+		defer t.loops.Done()
+		t.timerLoop(ticker, quit)
+	})
+}
+
+// stopLoop makes the loop exit, also while it waits for a slow consumer to take an update
+func (t *Timer) stopLoop() {
+	if t.loopQuit == nil {
+		return
+	}
+	t.ticker.Stop()
+	close(t.loopQuit)
+	t.ticker = nil
+	t.loopQuit = nil
 }
 
-func (t *Timer) timerLoop() {
+func (t *Timer) timerLoop(ticker Ticker, quit chan struct{}) {
 	for {
 		select {
-		case <-t.ticker.C:
-
-			if t.State == Running {
-				t.elapsed = time.Now().Sub(t.startTime)
-				t.Updates <- t.elapsed
+		case <-t.quit:
+			return
+		case <-quit:
+			return
+		case <-ticker.C():
+			t.mu.Lock()
+			update, send := t.advance(t.clock.Now())
+			if send {
+				t.fanOutUpdate(update)
+			}
+			// the final update of a tick which stopped the timer is still delivered before the loop exits
+			waitFor := quit
+			if send && t.loopQuit != quit {
+				waitFor = t.updateQuit()
+			}
+			t.mu.Unlock()
+			// updates are sent without holding the lock so consumers can control the timer while receiving
+			if !send {
+				continue
+			}
+			if !t.sendUpdate(update, waitFor) {
+				return
 			}
 		}
 	}
 }
 
+// updateQuit returns the channel ending the wait for a consumer of an update which is sent after releasing the lock
+// while the loop runs that is its quit channel, the final update of a run is dropped once the timer runs again or is reset
+func (t *Timer) updateQuit() chan struct{} {
+	if t.loopQuit != nil {
+		return t.loopQuit
+	}
+	if t.finalQuit == nil {
+		t.finalQuit = make(chan struct{})
+	}
+
+	return t.finalQuit
+}
+
+// dropFinalUpdate stops waiting for a consumer of the final update of the previous run
+func (t *Timer) dropFinalUpdate() {
+	if t.finalQuit == nil {
+		return
+	}
+	close(t.finalQuit)
+	t.finalQuit = nil
+}
+
+// loopInterval returns the interval the loop wakes up at
+// it wakes at the ticker interval for accurate checks and at least as often as updates are due
+func (t *Timer) loopInterval() time.Duration {
+	if t.updateInterval < t.tickerInterval {
+		return t.updateInterval
+	}
+
+	return t.tickerInterval
+}
+
+// updateDue reports whether an update has to be emitted at now
+// timers ticked by their owner emit an update on every tick. Half a loop interval of slack keeps late wakeups from skipping an update
+func (t *Timer) updateDue(now time.Time) bool {
+	return t.manual || t.lastUpdate.IsZero() || now.Sub(t.lastUpdate)+t.loopInterval()/2 >= t.updateInterval
+}
+
+// tick updates the elapsed time to now and emits it if an update is due
+// it returns the value to send on the updates channel and whether it should be sent
+func (t *Timer) tick(now time.Time) (time.Duration, bool) {
+	if t.tickObserver != nil {
+		start := time.Now()
+		defer func() {
+			t.tickObserver(t.elapsed, time.Since(start))
+		}()
+	}
+
+	previous := t.elapsed
+	t.elapsed = now.Sub(t.startTime)
+	t.checkZeroCrossing(previous)
+	t.detectAnomalies(now)
+	t.checkTimeSource()
+	t.checkPauseBudgets()
+	expired := t.checkCountdown()
+	reached := t.checkTarget()
+	t.fireThresholds(t.elapsed)
+	// the final update is always emitted
+	if !expired && !reached && !t.updateDue(now) {
+		return 0, false
+	}
+	t.lastUpdate = now
+	update := t.update()
+	t.ticks++
+	prediction, delta := t.prediction()
+	e := Event{Type: EventTick, Tick: t.ticks, Time: now, Elapsed: t.elapsed, Remaining: t.remaining(), Frames: t.frames(t.elapsed), GameTime: t.gameTime(t.elapsed), Prediction: prediction, Delta: delta, Live: t.liveDelta(), Phase: t.currentPhase()}
+	if t.subtimerUpdates {
+		e.Subtimers = t.subtimerTimes(now)
+	}
+	t.emit(e)
+	if expired {
+		t.expire()
+	}
+	if reached {
+		t.reachTarget()
+	}
+
+	return update, !t.manual
+}
+
 func (t *Timer) checkValidState(op operation) bool {
 	switch op {
 	case resetOp:
-		return t.State == Stopped
+		return t.state == Stopped
 	case startOp:
-		return t.State == Reset
+		return t.state == Reset
 	case pauseOp:
-		return t.State == Running
+		return t.state == Running
 	case resumeOp:
-		return t.State == Paused || (t.State == Stopped && t.allowResumeAfterStop)
+		return t.state == Paused || (t.state == Stopped && t.allowResumeAfterStop)
 	case stopOp:
-		return t.State == Running || t.State == Paused
+		return t.state == Running || t.state == Paused
 	default:
 		return false
 	}
@@ -0,0 +1,412 @@
+package timer_test
+
+import "errors"
+
+import "sync"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+// newTimer returns a timer driven by clock which is in Reset state
+// its updates are discarded, so the loop never waits for a consumer
+func newTimer(tb testing.TB, clock *timertest.Clock, opts ...timer.Option) *timer.Timer {
+	tb.Helper()
+
+	return prepare(tb, timer.New(append([]timer.Option{timer.WithClock(clock)}, opts...)...))
+}
+
+// prepare resets t and discards its updates like newTimer, for timers created by other constructors
+func prepare(tb testing.TB, t *timer.Timer) *timer.Timer {
+	tb.Helper()
+
+	if err := t.ResetTimer(); err != nil {
+		tb.Fatalf("resetting timer: %v", err)
+	}
+	go func() {
+		for range t.Updates {
+		}
+	}()
+
+	return t
+}
+
+func TestElapsedAtStopAndPause(t *testing.T) {
+	tests := []struct {
+		name  string
+		after time.Duration
+		op    func(t *timer.Timer) error
+		state timer.State
+	}{
+		{"stop between ticks", 1300 * time.Millisecond, timertest.Stop, timer.Stopped},
+		{"pause between ticks", 1300 * time.Millisecond, timertest.Pause, timer.Paused},
+		{"stop before first tick", 400 * time.Millisecond, timertest.Stop, timer.Stopped},
+		{"pause before first tick", 400 * time.Millisecond, timertest.Pause, timer.Paused},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+			defer tm.Close()
+
+			timertest.Run(t, tm, clock,
+				timertest.Step{Do: timertest.Start},
+				timertest.Step{After: test.after, Do: test.op},
+			)
+			if s := tm.State(); s != test.state {
+				t.Fatalf("state is %v, want %v", s, test.state)
+			}
+			if d := tm.Elapsed(); d != test.after {
+				t.Errorf("elapsed is %v, want %v", d, test.after)
+			}
+			// the frozen time must not move while the clock continues
+			clock.Advance(time.Second)
+			if d := tm.Elapsed(); d != test.after {
+				t.Errorf("elapsed is %v after advancing the clock, want %v", d, test.after)
+			}
+		})
+	}
+}
+
+func TestLoopAcrossPauseAndResume(t *testing.T) {
+	const interval = 100 * time.Millisecond
+	const quiet = 50 * time.Millisecond
+
+	tests := []struct {
+		name   string
+		cycles int
+	}{
+		{"no pause", 0},
+		{"single pause", 1},
+		{"repeated pauses", 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(interval), timer.WithTickerIntervalDuration(interval))
+			defer tm.Close()
+			ticks := tm.Subscribe(timer.WithFilter(timer.TicksOnly()), timer.WithOverflow(timer.Unbounded))
+
+			if err := tm.StartTimer(); err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < test.cycles; i++ {
+				if err := tm.PauseTimer(); err != nil {
+					t.Fatal(err)
+				}
+				// a paused timer doesn't tick
+				clock.Advance(interval)
+				timertest.AssertNoEmit(t, ticks.C, quiet)
+				if err := tm.ResumeTimer(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// a single loop is running, so one interval produces a single tick
+			clock.Advance(interval)
+			timertest.AssertEmitsType(t, ticks.C, timer.EventTick, 0)
+			timertest.AssertNoEmit(t, ticks.C, quiet)
+
+			if err := tm.StopTimer(); err != nil {
+				t.Fatal(err)
+			}
+			clock.Advance(interval)
+			timertest.AssertNoEmit(t, ticks.C, quiet)
+		})
+	}
+}
+
+func TestNewWithConfig(t *testing.T) {
+	invalid := []struct {
+		name string
+		cfg  timer.Config
+	}{
+		{"continue counting without resume", timer.Config{ContinueCountingWhenStopped: true}},
+		{"unknown pause policy", timer.Config{PausePolicy: timer.PausePolicy(42)}},
+		{"empty binding source", timer.Config{Bindings: []timer.Binding{{SubTimer: 1, Action: timer.TriggerStop}}}},
+	}
+	for _, test := range invalid {
+		t.Run(test.name, func(t *testing.T) {
+			if tm, err := timer.NewWithConfig(test.cfg); err == nil {
+				tm.Close()
+				t.Errorf("created a timer, want an error")
+			}
+		})
+	}
+
+	clock := timertest.NewClock(time.Now())
+	cfg := timer.Config{AllowResumeAfterStop: true, ContinueCountingWhenStopped: true, StopOnSubtimersStop: true, PausePolicy: timer.PauseMainOnly}
+	tm, err := timer.NewWithConfig(cfg, timer.WithClock(clock), timer.WithPausePolicy(timer.PauseGameOnly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tm.Close()
+	prepare(t, tm)
+
+	c := tm.Report().Config
+	if !c.AllowResumeAfterStop || !c.ContinueCountingWhenStopped || !c.StopOnSubtimersStop {
+		t.Errorf("config is %+v, want the settings of %+v", c, cfg)
+	}
+	if c.PausePolicy != timer.PauseGameOnly {
+		t.Errorf("pause policy is %v, want the one set by the option", c.PausePolicy)
+	}
+
+	// the time between stopping and resuming is counted
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second, Do: timertest.Stop},
+		timertest.Step{After: 2 * time.Second, Do: timertest.Resume},
+	)
+	if d := tm.Elapsed(); d != 3*time.Second {
+		t.Errorf("elapsed is %v after resuming, want 3s", d)
+	}
+}
+
+func TestSetUpdateInterval(t *testing.T) {
+	defaults := timer.New().Report().Config
+	tests := []struct {
+		name string
+		set  func(tm *timer.Timer) error
+		want time.Duration
+	}{
+		{"milliseconds", func(tm *timer.Timer) error { return tm.SetUpdateInterval(50) }, 50 * time.Millisecond},
+		{"below a millisecond", func(tm *timer.Timer) error { return tm.SetUpdateIntervalDuration(250 * time.Microsecond) }, 250 * time.Microsecond},
+		{"default", func(tm *timer.Timer) error { return tm.SetUpdateInterval(0) }, defaults.UpdateIntervalDuration},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := timer.New(timer.WithUpdateInterval(20))
+			defer tm.Close()
+			if err := test.set(tm); err != nil {
+				t.Fatal(err)
+			}
+			c := tm.Report().Config
+			if c.UpdateIntervalDuration != test.want || c.UpdateInterval != int(test.want/time.Millisecond) {
+				t.Errorf("update interval is %v (%vms), want %v", c.UpdateIntervalDuration, c.UpdateInterval, test.want)
+			}
+		})
+	}
+}
+
+func TestSetUpdateIntervalInvalid(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := timer.New(timer.WithClock(clock))
+	defer tm.Close()
+
+	if err := tm.SetUpdateIntervalDuration(-time.Microsecond); err == nil {
+		t.Errorf("setting a negative update interval succeeded, want an error")
+	}
+	prepare(t, tm)
+	var stateErr *timer.StateError
+	if err := tm.SetUpdateInterval(10); !errors.As(err, &stateErr) {
+		t.Errorf("setting the update interval after reset returned %v, want a state error", err)
+	}
+}
+
+func TestSubMillisecondUpdates(t *testing.T) {
+	const interval = 250 * time.Microsecond
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(interval), timer.WithTickerIntervalDuration(interval))
+	defer tm.Close()
+	ticks := tm.Subscribe(timer.WithFilter(timer.TicksOnly()), timer.WithOverflow(timer.Unbounded))
+
+	if err := tm.StartTimer(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 4; i++ {
+		clock.Advance(interval)
+		if e := timertest.AssertEmitsType(t, ticks.C, timer.EventTick, 0); e.Elapsed != time.Duration(i)*interval {
+			t.Errorf("tick has elapsed %v, want %v", e.Elapsed, time.Duration(i)*interval)
+		}
+	}
+}
+
+func TestStateAccessors(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock)
+	defer tm.Close()
+
+	// readers may poll the state while it changes
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				tm.State()
+				tm.IsRunning()
+			}
+		}
+	}()
+
+	tests := []struct {
+		op      func(tm *timer.Timer) error
+		state   timer.State
+		running bool
+		paused  bool
+		stopped bool
+	}{
+		{nil, timer.Reset, false, false, false},
+		{timertest.Start, timer.Running, true, false, false},
+		{timertest.Pause, timer.Paused, false, true, false},
+		{timertest.Resume, timer.Running, true, false, false},
+		{timertest.Stop, timer.Stopped, false, false, true},
+	}
+	for _, test := range tests {
+		timertest.Run(t, tm, clock, timertest.Step{Do: test.op})
+		if s := tm.State(); s != test.state {
+			t.Errorf("state is %v, want %v", s, test.state)
+		}
+		if tm.IsRunning() != test.running || tm.IsPaused() != test.paused || tm.IsStopped() != test.stopped {
+			t.Errorf("%v timer reports running %v, paused %v and stopped %v", test.state, tm.IsRunning(), tm.IsPaused(), tm.IsStopped())
+		}
+	}
+}
+
+func TestSetTickerInterval(t *testing.T) {
+	defaults := timer.New().Report().Config
+	tests := []struct {
+		name string
+		set  func(tm *timer.Timer) error
+		want time.Duration
+	}{
+		{"milliseconds", func(tm *timer.Timer) error { return tm.SetTickerInterval(5) }, 5 * time.Millisecond},
+		{"below a millisecond", func(tm *timer.Timer) error { return tm.SetTickerIntervalDuration(100 * time.Microsecond) }, 100 * time.Microsecond},
+		{"default", func(tm *timer.Timer) error { return tm.SetTickerInterval(0) }, defaults.TickerIntervalDuration},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := timer.New(timer.WithTickerInterval(20))
+			defer tm.Close()
+			if err := test.set(tm); err != nil {
+				t.Fatal(err)
+			}
+			c := tm.Report().Config
+			if c.TickerIntervalDuration != test.want || c.TickerInterval != int(test.want/time.Millisecond) {
+				t.Errorf("ticker interval is %v (%vms), want %v", c.TickerIntervalDuration, c.TickerInterval, test.want)
+			}
+		})
+	}
+
+	tm := timer.New()
+	defer tm.Close()
+	if err := tm.SetTickerInterval(-1); err == nil {
+		t.Errorf("setting a negative ticker interval succeeded, want an error")
+	}
+	prepare(t, tm)
+	var stateErr *timer.StateError
+	if err := tm.SetTickerIntervalDuration(time.Millisecond); !errors.As(err, &stateErr) {
+		t.Errorf("setting the ticker interval after reset returned %v, want a state error", err)
+	}
+}
+
+func TestResumeAfterStop(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []timer.Option
+		// elapsed is the time one second after resuming, 0 if resuming has to fail
+		elapsed time.Duration
+	}{
+		{"not allowed", nil, 0},
+		{"stop time not counted", []timer.Option{timer.WithResumeAfterStop(false)}, 2 * time.Second},
+		{"stop time counted", []timer.Option{timer.WithResumeAfterStop(true)}, 4 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := newTimer(t, clock, test.opts...)
+			defer tm.Close()
+			timertest.Run(t, tm, clock,
+				timertest.Step{Do: timertest.Start},
+				timertest.Step{After: time.Second, Do: timertest.Stop},
+				timertest.Step{After: 2 * time.Second},
+			)
+
+			err := tm.ResumeTimer()
+			if test.elapsed == 0 {
+				var stateErr *timer.StateError
+				if !errors.As(err, &stateErr) {
+					t.Errorf("resuming returned %v, want a state error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			clock.Advance(time.Second)
+			if d := tm.Elapsed(); d != test.elapsed {
+				t.Errorf("elapsed is %v, want %v", d, test.elapsed)
+			}
+			// the resumed run can be stopped again
+			timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+			if r := tm.Report(); r.FinalTime != test.elapsed {
+				t.Errorf("final time is %v, want %v", r.FinalTime, test.elapsed)
+			}
+		})
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	tm := prepare(t, timer.New(timer.WithSubtimers(1, 2, 3, 4), timer.WithUpdateIntervalDuration(time.Millisecond), timer.WithTickerIntervalDuration(time.Millisecond)))
+	defer tm.Close()
+	if err := tm.StartTimer(); err != nil {
+		t.Fatal(err)
+	}
+
+	// run with -race, operations racing each other may fail with a state error but must not corrupt the timer
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				tm.PauseTimer()
+				tm.ResumeTimer()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				tm.Elapsed()
+				tm.Report()
+				tm.State()
+			}
+		}()
+		go func(id int) {
+			defer wg.Done()
+			time.Sleep(time.Duration(id) * time.Millisecond)
+			if _, err := tm.StopSubTimer(id); err != nil {
+				t.Errorf("stopping subtimer %v: %v", id, err)
+			}
+		}(i + 1)
+	}
+	wg.Wait()
+
+	if s := tm.State(); s != timer.Running && s != timer.Paused {
+		t.Fatalf("timer is %v, want running or paused", s)
+	}
+	tm.ResumeTimer()
+	if err := tm.StopTimer(); err != nil {
+		t.Fatal(err)
+	}
+	r := tm.Report()
+	if len(r.Splits) != 4 {
+		t.Errorf("report has %v splits, want 4", len(r.Splits))
+	}
+	for _, s := range r.Splits {
+		if s.Time > r.FinalTime {
+			t.Errorf("subtimer %v stopped at %v after the final time %v", s.ID, s.Time, r.FinalTime)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Package timerhttp provides an http.Handler exposing a timer as a REST API
+//
+// The handler serves the following endpoints relative to where it is mounted:
+//
+//	GET  /state                  state, elapsed time and personal best of the timer
+//	GET  /elapsed                elapsed time of the timer
+//	GET  /report                 complete report of the current run
+//	GET  /subtimers              all subtimers ordered by id
+//	GET  /subtimers/{id}         a single subtimer
+//	POST /{start,pause,resume,stop,reset,split}
+//	POST /subtimers/{id}/{stop,split,skip,undo}
+//
+// Control endpoints answer 204 on success. Errors are answered with the error message and
+// 404 for unknown subtimers, 410 once the timer is closed and 409 if the operation is not possible otherwise.
+package timerhttp
+
+import "encoding/json"
+
+import "errors"
+
+import "net/http"
+
+import "strconv"
+
+import "strings"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// State is the response of GET /state
+type State struct {
+	State   timer.State   `json:"state"`
+	Elapsed time.Duration `json:"elapsed"`
+	// PersonalBest is 0 if no run has been completed yet
+	PersonalBest time.Duration `json:"personalBest,omitempty"`
+}
+
+// Handler serves a timer as a REST API
+type Handler struct {
+	t   *timer.Timer
+	mux *http.ServeMux
+}
+
+// NewHandler returns a handler serving t
+// mount it below a prefix with http.StripPrefix. t.Updates doesn't have to be drained while the handler is used
+func NewHandler(t *timer.Timer) *Handler {
+	// the subscription is never read, it keeps the timer from blocking on t.Updates so reports stay current
+	t.SubscribeUpdates()
+
+	h := &Handler{t: t, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/state", h.handleState)
+	h.mux.HandleFunc("/elapsed", h.handleElapsed)
+	h.mux.HandleFunc("/report", h.handleReport)
+	h.mux.HandleFunc("/subtimers", h.handleSubtimers)
+	h.mux.HandleFunc("/subtimers/", h.handleSubtimer)
+	for _, op := range []string{"start", "pause", "resume", "stop", "reset", "split"} {
+		h.mux.HandleFunc("/"+op, h.handleControl)
+	}
+
+	return h
+}
+
+// ServeHTTP dispatches the request to the endpoint
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) handleState(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet) {
+		return
+	}
+	pb, _ := h.t.PersonalBest()
+	writeJSON(w, State{State: h.t.State(), Elapsed: h.t.Elapsed(), PersonalBest: pb})
+}
+
+func (h *Handler) handleElapsed(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, h.t.Elapsed())
+}
+
+func (h *Handler) handleReport(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, h.t.Report())
+}
+
+func (h *Handler) handleSubtimers(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet) {
+		return
+	}
+	report := h.t.Report()
+	subtimers := make([]timer.SubtimerInfo, 0, len(report.Subtimers))
+	for _, s := range report.Subtimers {
+		info, err := h.t.SubTimer(s.ID)
+		if err != nil {
+			// the subtimer was removed by a reset in the meantime
+			continue
+		}
+		subtimers = append(subtimers, info)
+	}
+	writeJSON(w, subtimers)
+}
+
+// handleControl handles POST /{start,pause,resume,stop,reset,split}
+func (h *Handler) handleControl(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodPost) {
+		return
+	}
+
+	var err error
+	switch strings.TrimPrefix(r.URL.Path, "/") {
+	case "start":
+		err = h.t.StartTimer()
+	case "pause":
+		err = h.t.PauseTimer()
+	case "resume":
+		err = h.t.ResumeTimer()
+	case "stop":
+		err = h.t.StopTimer()
+	case "reset":
+		err = h.t.ResetTimer()
+	case "split":
+		_, err = h.t.Split()
+	}
+	respond(w, err)
+}
+
+// handleSubtimer handles GET /subtimers/{id} and POST /subtimers/{id}/{stop,split,skip,undo}
+func (h *Handler) handleSubtimer(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/subtimers/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil || len(parts) > 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	if len(parts) == 1 {
+		if !allow(w, r, http.MethodGet) {
+			return
+		}
+		info, err := h.t.SubTimer(id)
+		if err != nil {
+			http.Error(w, err.Error(), status(err))
+			return
+		}
+		writeJSON(w, info)
+		return
+	}
+
+	if !allow(w, r, http.MethodPost) {
+		return
+	}
+	switch parts[1] {
+	case "stop":
+		_, err = h.t.StopSubTimer(id)
+	case "split":
+		_, err = h.t.SplitSubTimer(id)
+	case "skip":
+		err = h.t.SkipSubTimer(id)
+	case "undo":
+		err = h.t.UndoStopSubTimer(id)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	respond(w, err)
+}
+
+// allow reports whether r uses method and answers 405 otherwise
+func allow(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+
+	return true
+}
+
+// respond answers a control request with 204 or the status of err if it is set
+func respond(w http.ResponseWriter, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), status(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// status returns the response status for an error of the timer
+func status(err error) int {
+	switch {
+	case errors.Is(err, timer.ErrSubtimerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, timer.ErrClosed):
+		return http.StatusGone
+	case errors.Is(err, timer.ErrInvalidState):
+		return http.StatusConflict
+	default:
+		return http.StatusConflict
+	}
+}
+
+// writeJSON writes v as the response body
+// encoding errors can only be caused by the connection, so they are left to the server
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
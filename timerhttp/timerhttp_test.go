@@ -0,0 +1,81 @@
+package timerhttp_test
+
+import "encoding/json"
+
+import "net/http"
+
+import "net/http/httptest"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timerhttp"
+
+func do(t *testing.T, h http.Handler, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+
+	return w
+}
+
+func TestReportWithDefaultTimer(t *testing.T) {
+	tm := timer.New()
+	defer tm.Close()
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	h := timerhttp.NewHandler(tm)
+
+	if w := do(t, h, http.MethodPost, "/start"); w.Code != http.StatusNoContent {
+		t.Fatalf("POST /start answered %v", w.Code)
+	}
+	// nobody drains tm.Updates, the reported time still follows the running timer
+	time.Sleep(200 * time.Millisecond)
+	var r timer.Report
+	if err := json.NewDecoder(do(t, h, http.MethodGet, "/report").Body).Decode(&r); err != nil {
+		t.Fatal(err)
+	}
+	if r.FinalTime < 150*time.Millisecond {
+		t.Errorf("reported time is %v after 200ms", r.FinalTime)
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		closed bool
+		status int
+	}{
+		{"success", http.MethodPost, "/start", false, http.StatusNoContent},
+		{"invalid state", http.MethodPost, "/pause", false, http.StatusConflict},
+		{"unknown subtimer", http.MethodGet, "/subtimers/7", false, http.StatusNotFound},
+		{"unknown subtimer control", http.MethodPost, "/subtimers/7/stop", false, http.StatusNotFound},
+		{"closed timer", http.MethodPost, "/start", true, http.StatusGone},
+		{"wrong method", http.MethodGet, "/start", false, http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := timer.New()
+			defer tm.Close()
+			if err := tm.ResetTimer(); err != nil {
+				t.Fatal(err)
+			}
+			h := timerhttp.NewHandler(tm)
+			if test.closed {
+				tm.Close()
+			}
+
+			if w := do(t, h, test.method, test.path); w.Code != test.status {
+				t.Errorf("%v %v answered %v, want %v", test.method, test.path, w.Code, test.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package timertest provides utilities for testing code driven by timer-core timers
+package timertest
+
+import "sort"
+
+import "sync"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// Clock is a timer.Clock whose time only moves when Advance is called
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*ticker
+	alarms  []*alarm
+}
+
+// NewClock returns a new fake clock starting at start
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the current fake time
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTicker returns a ticker which fires whenever the clock is advanced past its next tick
+// like time.Ticker it drops ticks for slow receivers
+func (c *Clock) NewTicker(d time.Duration) timer.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &ticker{c: make(chan time.Time, 1), interval: d, next: c.now.Add(d), clock: c}
+	c.tickers = append(c.tickers, t)
+
+	return t
+}
+
+// AfterFunc calls f in its own goroutine once the clock has been advanced by d
+func (c *Clock) AfterFunc(d time.Duration, f func()) timer.Alarm {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	a := &alarm{at: c.now.Add(d), f: f, clock: c}
+	c.alarms = append(c.alarms, a)
+
+	return a
+}
+
+// Advance moves the clock forward by d, firing all tickers and alarms which are due on the way in chronological order
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		at, fire := c.nextEvent(target)
+		if fire == nil {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		c.now = at
+		c.mu.Unlock()
+		fire()
+	}
+}
+
+// nextEvent returns the earliest ticker or alarm due until target and a function firing it
+// it must be called with c.mu held
+func (c *Clock) nextEvent(target time.Time) (time.Time, func()) {
+	type due struct {
+		at   time.Time
+		fire func()
+	}
+	var events []due
+	for _, t := range c.tickers {
+		if !t.next.After(target) {
+			t := t
+			events = append(events, due{t.next, func() { t.fire() }})
+		}
+	}
+	for _, a := range c.alarms {
+		if !a.at.After(target) {
+			a := a
+			events = append(events, due{a.at, func() { a.fire() }})
+		}
+	}
+	if len(events) == 0 {
+		return time.Time{}, nil
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].at.Before(events[j].at)
+	})
+	return events[0].at, events[0].fire
+}
+
+type ticker struct {
+	c        chan time.Time
+	interval time.Duration
+	next     time.Time
+	clock    *Clock
+}
+
+func (t *ticker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *ticker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, other := range t.clock.tickers {
+		if other == t {
+			t.clock.tickers = append(t.clock.tickers[:i], t.clock.tickers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *ticker) fire() {
+	t.clock.mu.Lock()
+	now := t.next
+	t.next = t.next.Add(t.interval)
+	t.clock.mu.Unlock()
+
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+type alarm struct {
+	at    time.Time
+	f     func()
+	clock *Clock
+}
+
+func (a *alarm) Stop() bool {
+	a.clock.mu.Lock()
+	defer a.clock.mu.Unlock()
+	for i, other := range a.clock.alarms {
+		if other == a {
+			a.clock.alarms = append(a.clock.alarms[:i], a.clock.alarms[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *alarm) fire() {
+	if a.Stop() {
+		go a.f()
+	}
+}
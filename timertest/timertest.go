@@ -0,0 +1,130 @@
+package timertest
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// DefaultWithin is the time the assertion helpers wait for an emission by default
+const DefaultWithin = time.Second
+
+// New returns a timer driven by clock which is in Reset state and ready to be started
+// all values sent on its Updates channel are discarded, use Subscribe to observe the timer
+func New(tb testing.TB, clock *Clock) *timer.Timer {
+	tb.Helper()
+
+	t := timer.New()
+	if err := t.SetClock(clock); err != nil {
+		tb.Fatalf("setting clock: %v", err)
+	}
+	if err := t.ResetTimer(); err != nil {
+		tb.Fatalf("resetting timer: %v", err)
+	}
+	go func() {
+		for range t.Updates {
+		}
+	}()
+
+	return t
+}
+
+// Step is a single step of a scripted scenario
+type Step struct {
+	// After is the time the clock is advanced by before Do is called
+	After time.Duration
+	// Do performs the operation of the step. It may be nil for steps which only advance the clock
+	Do func(t *timer.Timer) error
+}
+
+// Run drives t through steps, advancing clock before every step
+// the test fails immediately if a step returns an error
+func Run(tb testing.TB, t *timer.Timer, clock *Clock, steps ...Step) {
+	tb.Helper()
+
+	for i, step := range steps {
+		clock.Advance(step.After)
+		if step.Do == nil {
+			continue
+		}
+		if err := step.Do(t); err != nil {
+			tb.Fatalf("step %v: %v", i, err)
+		}
+	}
+}
+
+// Start, Pause, Resume, Stop and Reset are operations for use in scripted steps
+var (
+	Start  = (*timer.Timer).StartTimer
+	Pause  = (*timer.Timer).PauseTimer
+	Resume = (*timer.Timer).ResumeTimer
+	Stop   = (*timer.Timer).StopTimer
+	Reset  = (*timer.Timer).ResetTimer
+)
+
+// StopSubTimer returns an operation stopping the subtimer id for use in scripted steps
+func StopSubTimer(id int) func(t *timer.Timer) error {
+	return func(t *timer.Timer) error {
+		_, err := t.StopSubTimer(id)
+		return err
+	}
+}
+
+// AssertEmits fails the test if ch doesn't deliver an event within the given time and returns the event otherwise
+// Setting 0 for within uses DefaultWithin
+func AssertEmits(tb testing.TB, ch <-chan timer.Event, within time.Duration) timer.Event {
+	tb.Helper()
+
+	if within == 0 {
+		within = DefaultWithin
+	}
+	select {
+	case e, ok := <-ch:
+		if !ok {
+			tb.Fatalf("channel closed while waiting for an event")
+		}
+		return e
+	case <-time.After(within):
+		tb.Fatalf("no event emitted within %v", within)
+	}
+
+	return timer.Event{}
+}
+
+// AssertEmitsType fails the test if ch doesn't deliver an event of type typ within the given time
+// events of other types are skipped. Setting 0 for within uses DefaultWithin
+func AssertEmitsType(tb testing.TB, ch <-chan timer.Event, typ timer.EventType, within time.Duration) timer.Event {
+	tb.Helper()
+
+	if within == 0 {
+		within = DefaultWithin
+	}
+	deadline := time.After(within)
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				tb.Fatalf("channel closed while waiting for event type %v", typ)
+			}
+			if e.Type == typ {
+				return e
+			}
+		case <-deadline:
+			tb.Fatalf("no event of type %v emitted within %v", typ, within)
+			return timer.Event{}
+		}
+	}
+}
+
+// AssertNoEmit fails the test if ch delivers an event within the given time
+func AssertNoEmit(tb testing.TB, ch <-chan timer.Event, within time.Duration) {
+	tb.Helper()
+
+	select {
+	case e, ok := <-ch:
+		if ok {
+			tb.Fatalf("unexpected event of type %v emitted", e.Type)
+		}
+	case <-time.After(within):
+	}
+}
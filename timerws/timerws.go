@@ -0,0 +1,166 @@
+// Package timerws exposes a timer over WebSocket
+//
+// Clients receive every tick of the timer as an update frame and all other events as event frames.
+// They control the timer by sending command frames and receive a result frame for every command.
+package timerws
+
+import "encoding/json"
+
+import "fmt"
+
+import "net/http"
+
+import "github.com/gorilla/websocket"
+
+import "github.com/onestay/timer-core"
+
+// Frame types sent to clients
+const (
+	FrameUpdate = "update"
+	FrameEvent  = "event"
+	FrameResult = "result"
+)
+
+// Frame is a single message sent to a client
+type Frame struct {
+	Type string `json:"type"`
+	// Event is set for update and event frames
+	Event *timer.Event `json:"event,omitempty"`
+	// Command is the command a result frame answers and Error its error. Error is empty if the command succeeded
+	Command string `json:"command,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Command is a single message sent by a client
+// Command is one of start, pause, resume, stop, reset and split. Split records a lap of the main timer,
+// or the next shared split of the subtimer ID if set
+type Command struct {
+	Command string `json:"command"`
+	ID      *int   `json:"id,omitempty"`
+}
+
+// Handler serves a timer over WebSocket
+type Handler struct {
+	t        *timer.Timer
+	upgrader websocket.Upgrader
+	readOnly bool
+}
+
+// Option configures a Handler
+type Option func(h *Handler)
+
+// WithCheckOrigin sets the function deciding whether a connection from another origin is accepted
+// by default only same origin connections are accepted
+func WithCheckOrigin(f func(r *http.Request) bool) Option {
+	return func(h *Handler) {
+		h.upgrader.CheckOrigin = f
+	}
+}
+
+// ReadOnly rejects all commands, clients only receive updates and events
+func ReadOnly() Option {
+	return func(h *Handler) {
+		h.readOnly = true
+	}
+}
+
+// NewHandler returns a handler serving t over WebSocket
+// clients are served from subscriptions of t, so t.Updates doesn't have to be drained
+func NewHandler(t *timer.Timer, opts ...Option) *Handler {
+	h := &Handler{t: t}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// ServeHTTP upgrades the connection and serves the timer until the client disconnects
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sub := h.t.Subscribe(timer.WithOverflow(timer.Coalesce))
+	defer sub.Close()
+
+	// results are written by the same goroutine as events, the connection only supports one writer
+	results := make(chan Frame)
+	closed := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(closed)
+		for {
+			var c Command
+			result := Frame{Type: FrameResult}
+			if err := conn.ReadJSON(&c); err != nil {
+				if _, ok := err.(*json.SyntaxError); !ok {
+					return
+				}
+				result.Error = err.Error()
+			} else {
+				result.Command = c.Command
+				if err := h.apply(c); err != nil {
+					result.Error = err.Error()
+				}
+			}
+			select {
+			case results <- result:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		var f Frame
+		select {
+		case e, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			f = Frame{Type: FrameEvent, Event: &e}
+			if e.Type == timer.EventTick {
+				f.Type = FrameUpdate
+			}
+		case f = <-results:
+		case <-closed:
+			return
+		}
+		if err := conn.WriteJSON(f); err != nil {
+			return
+		}
+	}
+}
+
+// apply executes the command c on the timer
+func (h *Handler) apply(c Command) error {
+	if h.readOnly {
+		return fmt.Errorf("Commands are not allowed")
+	}
+
+	switch c.Command {
+	case "start":
+		return h.t.StartTimer()
+	case "pause":
+		return h.t.PauseTimer()
+	case "resume":
+		return h.t.ResumeTimer()
+	case "stop":
+		return h.t.StopTimer()
+	case "reset":
+		return h.t.ResetTimer()
+	case "split":
+		if c.ID != nil {
+			_, err := h.t.SplitSubTimer(*c.ID)
+			return err
+		}
+		_, err := h.t.Split()
+		return err
+	default:
+		return fmt.Errorf("Unknown command %q", c.Command)
+	}
+}
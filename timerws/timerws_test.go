@@ -0,0 +1,108 @@
+package timerws_test
+
+import "net/http/httptest"
+
+import "strings"
+
+import "testing"
+
+import "time"
+
+import "github.com/gorilla/websocket"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timerws"
+
+// dial serves tm and connects a client to it. The returned function closes the connection and the server
+func dial(t *testing.T, tm *timer.Timer, opts ...timerws.Option) (*websocket.Conn, func()) {
+	t.Helper()
+
+	srv := httptest.NewServer(timerws.NewHandler(tm, opts...))
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		srv.Close()
+		t.Fatalf("dialing: %v", err)
+	}
+
+	return conn, func() {
+		conn.Close()
+		srv.Close()
+	}
+}
+
+func readFrame(t *testing.T, conn *websocket.Conn) timerws.Frame {
+	t.Helper()
+
+	var f timerws.Frame
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if err := conn.ReadJSON(&f); err != nil {
+		t.Fatalf("reading frame: %v", err)
+	}
+
+	return f
+}
+
+func TestUpdatesWithDefaultTimer(t *testing.T) {
+	tm := timer.New()
+	defer tm.Close()
+	if err := tm.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	conn, done := dial(t, tm)
+	defer done()
+
+	if err := conn.WriteJSON(timerws.Command{Command: "start"}); err != nil {
+		t.Fatal(err)
+	}
+	// nobody drains tm.Updates, the client still receives a steady stream of ticks
+	updates := 0
+	for updates < 10 {
+		if f := readFrame(t, conn); f.Type == timerws.FrameUpdate {
+			updates++
+		}
+	}
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []timerws.Option
+		commands []string
+		errs     []bool
+		state    timer.State
+	}{
+		{"start and stop", nil, []string{"start", "stop"}, []bool{false, false}, timer.Stopped},
+		{"invalid transition", nil, []string{"pause"}, []bool{true}, timer.Reset},
+		{"unknown command", nil, []string{"jump"}, []bool{true}, timer.Reset},
+		{"read only", []timerws.Option{timerws.ReadOnly()}, []string{"start"}, []bool{true}, timer.Reset},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := timer.New()
+			defer tm.Close()
+			if err := tm.ResetTimer(); err != nil {
+				t.Fatal(err)
+			}
+			conn, done := dial(t, tm, test.opts...)
+			defer done()
+
+			for i, c := range test.commands {
+				if err := conn.WriteJSON(timerws.Command{Command: c}); err != nil {
+					t.Fatal(err)
+				}
+				f := readFrame(t, conn)
+				for f.Type != timerws.FrameResult {
+					f = readFrame(t, conn)
+				}
+				if f.Command != c || (f.Error != "") != test.errs[i] {
+					t.Errorf("result of %v is %+v, want error %v", c, f, test.errs[i])
+				}
+			}
+			if s := tm.State(); s != test.state {
+				t.Errorf("state is %v, want %v", s, test.state)
+			}
+		})
+	}
+}
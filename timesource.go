@@ -0,0 +1,114 @@
+package timer
+
+import "sync"
+
+import "time"
+
+// SourceStatus describes the health of an external time source
+type SourceStatus int
+
+const (
+	// SourceLocked is reported while the source is synchronized to its reference
+	SourceLocked SourceStatus = iota
+	// SourceHoldover is reported while the source lost its reference but still provides usable time
+	SourceHoldover
+	// SourceUnavailable is reported when the source can't provide time. The timer falls back to the system clock
+	SourceUnavailable
+)
+
+// SourceHealth describes the current health of an external time source
+type SourceHealth struct {
+	Status SourceStatus `json:"status"`
+	// Uncertainty is the estimated maximum error of the time provided by the source
+	Uncertainty time.Duration `json:"uncertainty"`
+}
+
+// TimeSource provides precise wall clock time from external hardware like PTP or GPS-PPS receivers
+type TimeSource interface {
+	Now() time.Time
+	Health() SourceHealth
+}
+
+// SetTimeSource disciplines the time of the timer by src
+// tickers and alarms keep using the system clock, only time measurements use src. While src is unavailable
+// the system clock corrected by the last known offset of src is used. Changes of the health of src are emitted as EventTimeSourceHealth events.
+// Only works when timer is stopped. Setting nil sets it back to the real clock
+func (t *Timer) SetTimeSource(src TimeSource) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != Stopped {
+		return &StateError{Op: "SetTimeSource", Current: t.state}
+	}
+	if src == nil {
+		return t.setClockLocked(nil)
+	}
+
+	c := &sourceClock{src: src}
+	if err := t.setClockLocked(c); err != nil {
+		return err
+	}
+	t.source = c
+	t.sourceHealth = src.Health()
+
+	return nil
+}
+
+// TimeSourceHealth returns the health of the time source set by SetTimeSource
+// it reports SourceLocked if no time source is set
+func (t *Timer) TimeSourceHealth() SourceHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.source == nil {
+		return SourceHealth{}
+	}
+
+	return t.source.src.Health()
+}
+
+// checkTimeSource emits an event if the health status of the time source changed since the last check
+func (t *Timer) checkTimeSource() {
+	if t.source == nil {
+		return
+	}
+	health := t.source.src.Health()
+	if health.Status == t.sourceHealth.Status {
+		return
+	}
+	t.sourceHealth = health
+
+	severity := SeverityInfo
+	switch health.Status {
+	case SourceHoldover:
+		severity = SeverityWarning
+	case SourceUnavailable:
+		severity = SeverityError
+	}
+	t.emit(Event{Type: EventTimeSourceHealth, Severity: severity, Elapsed: t.elapsed, SourceHealth: &health})
+}
+
+// sourceClock measures time with a TimeSource and schedules with the system clock
+type sourceClock struct {
+	realClock
+	src TimeSource
+
+	mu     sync.Mutex
+	offset time.Duration
+}
+
+func (c *sourceClock) Now() time.Time {
+	local := time.Now()
+	if c.src.Health().Status == SourceUnavailable {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return local.Add(c.offset)
+	}
+
+	now := c.src.Now()
+	c.mu.Lock()
+	c.offset = now.Sub(local.Round(0))
+	c.mu.Unlock()
+
+	return now
+}
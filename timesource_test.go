@@ -0,0 +1,94 @@
+package timer_test
+
+import "errors"
+
+import "sync"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+// source is a time source which is ahead of the system clock by offset
+type source struct {
+	offset time.Duration
+
+	mu     sync.Mutex
+	health timer.SourceHealth
+}
+
+func (s *source) Now() time.Time {
+	return time.Now().Add(s.offset)
+}
+
+func (s *source) Health() timer.SourceHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.health
+}
+
+func (s *source) set(status timer.SourceStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.health.Status = status
+}
+
+func TestTimeSource(t *testing.T) {
+	src := &source{offset: time.Hour}
+	tm := timer.New(timer.WithUpdateIntervalDuration(time.Millisecond), timer.WithTickerIntervalDuration(time.Millisecond))
+	defer tm.Close()
+	if h := tm.TimeSourceHealth(); h != (timer.SourceHealth{}) {
+		t.Errorf("health without a time source is %+v, want the zero value", h)
+	}
+	if err := tm.SetTimeSource(src); err != nil {
+		t.Fatal(err)
+	}
+	prepare(t, tm)
+	var stateErr *timer.StateError
+	if err := tm.SetTimeSource(nil); !errors.As(err, &stateErr) {
+		t.Errorf("setting the time source after reset returned %v, want a state error", err)
+	}
+	sub := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventStarted, timer.EventTimeSourceHealth}}))
+
+	if err := tm.StartTimer(); err != nil {
+		t.Fatal(err)
+	}
+	e := <-sub.C
+	if d := e.Time.Sub(time.Now()); d < time.Hour-time.Second || d > time.Hour {
+		t.Errorf("start event is %v ahead of the system clock, want the time of the source", d)
+	}
+
+	tests := []struct {
+		status   timer.SourceStatus
+		severity timer.Severity
+	}{
+		{timer.SourceHoldover, timer.SeverityWarning},
+		{timer.SourceUnavailable, timer.SeverityError},
+		{timer.SourceLocked, timer.SeverityInfo},
+	}
+	for _, test := range tests {
+		src.set(test.status)
+		select {
+		case e := <-sub.C:
+			if e.Type != timer.EventTimeSourceHealth || e.SourceHealth == nil || e.SourceHealth.Status != test.status || e.Severity != test.severity {
+				t.Errorf("got %v event with health %+v and severity %v, want status %v with severity %v", e.Type, e.SourceHealth, e.Severity, test.status, test.severity)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no health event for status %v", test.status)
+		}
+		if h := tm.TimeSourceHealth(); h.Status != test.status {
+			t.Errorf("health is %+v, want status %v", h, test.status)
+		}
+	}
+
+	// while the source is unavailable the last known offset is kept
+	src.set(timer.SourceUnavailable)
+	before := tm.Elapsed()
+	time.Sleep(10 * time.Millisecond)
+	if after := tm.Elapsed(); after < before+10*time.Millisecond || after > before+time.Second {
+		t.Errorf("elapsed went from %v to %v in 10ms while the source was unavailable", before, after)
+	}
+}
@@ -0,0 +1,104 @@
+package timer
+
+import "fmt"
+
+import "net/http"
+
+import "time"
+
+// TriggerAction describes what a bound trigger does to its subtimer
+type TriggerAction int
+
+const (
+	// TriggerStop stops the subtimer
+	TriggerStop TriggerAction = iota
+	// TriggerSplit records the next shared split of the subtimer
+	TriggerSplit
+)
+
+// Binding binds an external trigger source to an action on a subtimer
+// Source is a free form identifier like "webhook:3f9a", "chat:runner1" or "gpio:17"
+type Binding struct {
+	Source   string
+	SubTimer int
+	Action   TriggerAction
+}
+
+// Bind binds an external trigger source to an action on a subtimer
+// a source can only be bound once
+func (t *Timer) Bind(b Binding) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if b.Source == "" {
+		return fmt.Errorf("Source of binding must not be empty")
+	}
+	if b.Action != TriggerStop && b.Action != TriggerSplit {
+		return fmt.Errorf("Unknown trigger action %v", b.Action)
+	}
+	if _, ok := t.bindings[b.Source]; ok {
+		return fmt.Errorf("Source %v is already bound", b.Source)
+	}
+	if t.bindings == nil {
+		t.bindings = make(map[string]Binding)
+	}
+	t.bindings[b.Source] = b
+
+	return nil
+}
+
+// Unbind removes the binding of source
+func (t *Timer) Unbind(source string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.bindings, source)
+}
+
+// Trigger performs the action bound to source and returns the recorded time
+func (t *Timer) Trigger(source string) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	d, err := t.triggerLocked(source)
+
+	return d, t.logFailed("Trigger", err, "source", source)
+}
+
+func (t *Timer) triggerLocked(source string) (time.Duration, error) {
+	if t.closed {
+		return 0, ErrClosed
+	}
+	b, ok := t.bindings[source]
+	if !ok {
+		return 0, fmt.Errorf("Source %v is not bound", source)
+	}
+
+	if b.Action == TriggerSplit {
+		return t.splitSubTimerLocked(b.SubTimer)
+	}
+	return t.stopSubTimerLocked(b.SubTimer)
+}
+
+// WebhookHandler returns a handler triggering the source "webhook:<token>" on POST requests
+// the token is read from the token query parameter
+func (t *Timer) WebhookHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			http.Error(w, "missing token", http.StatusBadRequest)
+			return
+		}
+
+		d, err := t.WithPayload("webhook:" + token).Trigger("webhook:" + token)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		fmt.Fprintf(w, "%d\n", d.Milliseconds())
+	})
+}
@@ -0,0 +1,130 @@
+package timer_test
+
+import "net/http"
+
+import "net/http/httptest"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestBind(t *testing.T) {
+	tm := timer.New()
+	defer tm.Close()
+
+	tests := []struct {
+		name    string
+		binding timer.Binding
+		ok      bool
+	}{
+		{"stop", timer.Binding{Source: "chat:runner1", SubTimer: 1}, true},
+		{"split", timer.Binding{Source: "gpio:17", SubTimer: 2, Action: timer.TriggerSplit}, true},
+		{"empty source", timer.Binding{SubTimer: 1}, false},
+		{"unknown action", timer.Binding{Source: "gpio:18", SubTimer: 1, Action: timer.TriggerAction(5)}, false},
+		{"bound twice", timer.Binding{Source: "chat:runner1", SubTimer: 2}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := tm.Bind(test.binding)
+			if test.ok && err != nil {
+				t.Errorf("got error %v, want nil", err)
+			}
+			if !test.ok && err == nil {
+				t.Errorf("binding succeeded, want an error")
+			}
+		})
+	}
+
+	// a removed binding can be bound again
+	tm.Unbind("chat:runner1")
+	if err := tm.Bind(timer.Binding{Source: "chat:runner1", SubTimer: 2}); err != nil {
+		t.Errorf("binding an unbound source returned %v, want nil", err)
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1, 2))
+	defer tm.Close()
+	if err := tm.SetSegments("Level", "Boss"); err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []timer.Binding{{Source: "chat:runner1", SubTimer: 1}, {Source: "gpio:17", SubTimer: 2, Action: timer.TriggerSplit}} {
+		if err := tm.Bind(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: time.Second},
+	)
+	if _, err := tm.Trigger("chat:runner2"); err == nil {
+		t.Errorf("triggering an unbound source succeeded, want an error")
+	}
+	if d, err := tm.Trigger("gpio:17"); err != nil || d != time.Second {
+		t.Errorf("split trigger returned %v, %v, want 1s", d, err)
+	}
+	clock.Advance(time.Second)
+	if d, err := tm.Trigger("chat:runner1"); err != nil || d != 2*time.Second {
+		t.Errorf("stop trigger returned %v, %v, want 2s", d, err)
+	}
+
+	if info, _ := tm.SubTimer(1); info.State != timer.Stopped {
+		t.Errorf("subtimer 1 is %v, want %v", info.State, timer.Stopped)
+	}
+	if info, _ := tm.SubTimer(2); info.State != timer.Running {
+		t.Errorf("subtimer 2 is %v after its first split, want %v", info.State, timer.Running)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithSubtimers(1))
+	defer tm.Close()
+	if err := tm.Bind(timer.Binding{Source: "webhook:3f9a", SubTimer: 1}); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 1500 * time.Millisecond},
+	)
+	stops := tm.Subscribe(timer.WithFilter(timer.Filter{Types: []timer.EventType{timer.EventSubtimerStopped}}))
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		status int
+		body   string
+	}{
+		{"get", http.MethodGet, "/?token=3f9a", http.StatusMethodNotAllowed, ""},
+		{"missing token", http.MethodPost, "/", http.StatusBadRequest, ""},
+		{"unknown token", http.MethodPost, "/?token=beef", http.StatusConflict, ""},
+		{"stop", http.MethodPost, "/?token=3f9a", http.StatusOK, "1500\n"},
+		{"stopped twice", http.MethodPost, "/?token=3f9a", http.StatusConflict, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tm.WebhookHandler().ServeHTTP(w, httptest.NewRequest(test.method, test.target, nil))
+			if w.Code != test.status {
+				t.Errorf("status is %v, want %v", w.Code, test.status)
+			}
+			if test.body != "" && w.Body.String() != test.body {
+				t.Errorf("body is %q, want %q", w.Body.String(), test.body)
+			}
+		})
+	}
+
+	// the stop is attributed to the webhook
+	if e := timertest.AssertEmitsType(t, stops.C, timer.EventSubtimerStopped, 0); e.Payload != "webhook:3f9a" {
+		t.Errorf("stop event has payload %v, want webhook:3f9a", e.Payload)
+	}
+}
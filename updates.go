@@ -0,0 +1,132 @@
+package timer
+
+import "time"
+
+// UpdatePolicy describes what the timer does when the consumer of the updates channel falls behind
+type UpdatePolicy int
+
+const (
+	// UpdateBlock waits until the consumer receives the update. Timekeeping stalls while it does.
+	// Once the timer has a subscriber of Subscribe or SubscribeUpdates updates are only sent to a waiting consumer,
+	// so subscribers don't depend on somebody draining the updates channel
+	UpdateBlock UpdatePolicy = iota
+	// UpdateDropNewest drops the new update if the buffer of the updates channel is full
+	UpdateDropNewest
+	// UpdateLatest drops the oldest buffered update so the consumer always receives the latest time
+	UpdateLatest
+)
+
+// WithUpdates sets the buffer size of the updates channel and the policy applied when it is full
+// non blocking policies use a buffer of at least 1
+func WithUpdates(buffer int, policy UpdatePolicy) Option {
+	return func(t *Timer) {
+		if buffer < 0 {
+			buffer = 0
+		}
+		if policy != UpdateBlock && buffer == 0 {
+			buffer = 1
+		}
+		t.Updates = make(chan time.Duration, buffer)
+		t.updatePolicy = policy
+	}
+}
+
+// sendUpdate delivers update on the updates channel according to the update policy
+// it reports false if the timer was closed or quit was closed while waiting for the consumer. A nil quit is never closed
+func (t *Timer) sendUpdate(update time.Duration, quit <-chan struct{}) bool {
+	switch t.updatePolicy {
+	case UpdateDropNewest:
+		select {
+		case t.Updates <- update:
+		default:
+		}
+	case UpdateLatest:
+		for {
+			select {
+			case t.Updates <- update:
+				return true
+			default:
+			}
+			// make room by discarding the oldest update, the consumer may have taken it in the meantime
+			select {
+			case <-t.Updates:
+			default:
+			}
+		}
+	default:
+		select {
+		case t.Updates <- update:
+		case <-t.subscribed:
+			select {
+			case t.Updates <- update:
+			default:
+			}
+		case <-t.quit:
+			return false
+		case <-quit:
+			return false
+		}
+	}
+
+	return true
+}
+
+// markSubscribed switches the updates channel to non blocking sends because the timer has a subscriber
+// a loop waiting for a consumer continues right away. It must be called with t.mu held
+func (t *Timer) markSubscribed() {
+	select {
+	case <-t.subscribed:
+	default:
+		close(t.subscribed)
+	}
+}
+
+// SubscribeUpdates returns a new stream of the values sent on the updates channel and a function to unsubscribe
+// every subscriber gets its own stream which always holds the latest update, so slow subscribers never stall the timer.
+// Subscribing also stops the timer from blocking on the updates channel, see UpdateBlock. The stream is closed when unsubscribing or when the timer is closed
+func (t *Timer) SubscribeUpdates() (<-chan time.Duration, func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := make(chan time.Duration, 1)
+	if t.closed {
+		close(c)
+		return c, func() {}
+	}
+	if t.updateSubs == nil {
+		t.updateSubs = make(map[chan time.Duration]struct{})
+	}
+	t.updateSubs[c] = struct{}{}
+	t.markSubscribed()
+
+	return c, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		if _, ok := t.updateSubs[c]; ok {
+			delete(t.updateSubs, c)
+			close(c)
+		}
+	}
+}
+
+// fanOutUpdate delivers update to all update subscribers replacing any update they haven't received yet
+// it must be called with t.mu held
+func (t *Timer) fanOutUpdate(update time.Duration) {
+	for c := range t.updateSubs {
+		select {
+		case <-c:
+		default:
+		}
+		c <- update
+	}
+}
+
+// closeUpdateSubs closes the streams of all update subscribers
+// it must be called with t.mu held
+func (t *Timer) closeUpdateSubs() {
+	for c := range t.updateSubs {
+		close(c)
+	}
+	t.updateSubs = nil
+}
@@ -0,0 +1,137 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestSubscribeUpdatesWithoutUpdatesReader(t *testing.T) {
+	tests := []struct {
+		name string
+		// wait is how long the timer runs before subscribing, so its loop is already blocked on the updates channel
+		wait time.Duration
+	}{
+		{"subscribe before start", 0},
+		{"subscribe while the loop is blocked", 100 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := timer.New()
+			defer tm.Close()
+			if err := tm.ResetTimer(); err != nil {
+				t.Fatal(err)
+			}
+
+			var updates <-chan time.Duration
+			if test.wait == 0 {
+				updates, _ = tm.SubscribeUpdates()
+			}
+			if err := tm.StartTimer(); err != nil {
+				t.Fatal(err)
+			}
+			if test.wait > 0 {
+				time.Sleep(test.wait)
+				updates, _ = tm.SubscribeUpdates()
+			}
+
+			// the default update interval is 10ms, so a running loop delivers many more than 5 updates in 300ms
+			n := 0
+			deadline := time.After(300 * time.Millisecond)
+			for n < 5 {
+				select {
+				case <-updates:
+					n++
+				case <-deadline:
+					t.Fatalf("received %v updates in 300ms, want at least 5", n)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdatePolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy timer.UpdatePolicy
+		want   []time.Duration
+	}{
+		{"drop newest", timer.UpdateDropNewest, []time.Duration{time.Second, 2 * time.Second}},
+		{"latest", timer.UpdateLatest, []time.Duration{4 * time.Second, 5 * time.Second}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := timertest.NewClock(time.Now())
+			tm := timer.New(timer.WithClock(clock), timer.WithUpdates(2, test.policy), timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+			defer tm.Close()
+			if err := tm.ResetTimer(); err != nil {
+				t.Fatal(err)
+			}
+			if err := tm.StartTimer(); err != nil {
+				t.Fatal(err)
+			}
+
+			// nobody reads the updates channel, the timer has to keep ticking anyway
+			for i := 0; i < 5; i++ {
+				clock.Advance(time.Second)
+				waitElapsed(t, tm, time.Duration(i+1)*time.Second)
+			}
+			for _, want := range test.want {
+				if got := <-tm.Updates; got != want {
+					t.Errorf("received update %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+// waitElapsed waits until the loop of tm measured an elapsed time of d
+func waitElapsed(t *testing.T, tm *timer.Timer, d time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for tm.Report().FinalTime != d {
+		if time.Now().After(deadline) {
+			t.Fatalf("timer didn't tick to %v", d)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSubscribeUpdatesFanOut(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithUpdateIntervalDuration(time.Second), timer.WithTickerIntervalDuration(time.Second))
+	defer tm.Close()
+	fast, _ := tm.SubscribeUpdates()
+	slow, _ := tm.SubscribeUpdates()
+	gone, unsubscribe := tm.SubscribeUpdates()
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-gone; ok {
+		t.Errorf("received an update after unsubscribing, want the stream closed")
+	}
+
+	if err := tm.StartTimer(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		if d := <-fast; d != time.Duration(i)*time.Second {
+			t.Errorf("fast subscriber received %v, want %v", d, time.Duration(i)*time.Second)
+		}
+	}
+	// the slow subscriber only holds the latest update. Elapsed waits for the fan out to finish
+	tm.Elapsed()
+	if d := <-slow; d != 3*time.Second {
+		t.Errorf("slow subscriber received %v, want 3s", d)
+	}
+
+	tm.Close()
+	if _, ok := <-fast; ok {
+		t.Errorf("received an update after closing the timer, want the stream closed")
+	}
+}
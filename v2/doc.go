@@ -0,0 +1,10 @@
+// Package timer is version 2 of timer-core
+//
+// v2 fixes design problems of v1 which can't be fixed without breaking the API:
+// constructors take a context which bounds the lifetime of the timer, its ticker only runs while the timer is running,
+// intervals are time.Duration, updates are structs delivered without ever blocking the timer,
+// all operations are safe for concurrent use and every Config field is honored.
+//
+// v1 stays available at github.com/onestay/timer-core and keeps receiving fixes.
+// Features of v1 are ported to v2 once they fit the new design.
+package timer
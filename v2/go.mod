@@ -0,0 +1,3 @@
+module github.com/onestay/timer-core/v2
+
+go 1.13
@@ -0,0 +1,380 @@
+package timer
+
+import "context"
+
+import "fmt"
+
+import "sync"
+
+import "time"
+
+// State describes the different Timerstates
+type State int
+
+const (
+	// Reset represents a timer which has been initialized but is currently not running
+	Reset State = iota
+	// Running represents a running timer
+	Running
+	// Paused represents a paused timer
+	Paused
+	// Stopped represents a stopped timer
+	Stopped
+)
+
+const defaultUpdateInterval = 10 * time.Millisecond
+
+// Config allows configuring various settings when creating a new timer
+type Config struct {
+	// UpdateInterval is the time between two updates. Setting 0 uses the default of 10ms
+	UpdateInterval time.Duration
+	// AllowResumeAfterStop will allow resuming the timer after it has been stopped
+	AllowResumeAfterStop bool
+	// ContinueCountingWhenStopped sets how the timer behaves after it is resumed after stopping
+	// if set to true the time between stopping and resuming is counted as well
+	ContinueCountingWhenStopped bool
+	// StopOnSubtimersStop will stop the timer once all subtimers are stopped
+	StopOnSubtimersStop bool
+}
+
+// Update is delivered on the updates channel while the timer is running
+type Update struct {
+	State   State
+	Elapsed time.Duration
+	// Subtimers holds the current time of every subtimer keyed by id
+	Subtimers map[int]time.Duration
+}
+
+// Timer is the main struct holding all relevant data
+// all methods are safe for concurrent use
+type Timer struct {
+	ctx     context.Context
+	cfg     Config
+	updates chan Update
+	done    chan struct{}
+	// loopQuit is closed to make the current loop exit and nil while no loop is running. loops tracks the running loops
+	loopQuit chan struct{}
+	loops    sync.WaitGroup
+
+	mu        sync.Mutex
+	state     State
+	startTime time.Time
+	stopTime  time.Time
+	pauseTime time.Time
+	elapsed   time.Duration
+	subtimers map[int]*subtimer
+}
+
+type subtimer struct {
+	state State
+	time  time.Duration
+}
+
+// New initializes and returns a new timer in Reset state
+// once ctx is done the timer stops sending updates, the updates channel is closed and all operations return ctx.Err()
+func New(ctx context.Context, cfg Config) *Timer {
+	if cfg.UpdateInterval <= 0 {
+		cfg.UpdateInterval = defaultUpdateInterval
+	}
+	t := &Timer{
+		ctx:       ctx,
+		cfg:       cfg,
+		updates:   make(chan Update, 1),
+		done:      make(chan struct{}),
+		subtimers: make(map[int]*subtimer),
+	}
+	go t.watch()
+
+	return t
+}
+
+// Updates returns the channel delivering updates of the running timer
+// updates are never queued: a consumer which falls behind only receives the latest one
+func (t *Timer) Updates() <-chan Update {
+	return t.updates
+}
+
+// Done returns a channel which is closed once the context of the timer is done
+func (t *Timer) Done() <-chan struct{} {
+	return t.done
+}
+
+// State returns the current state of the timer
+func (t *Timer) State() State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.state
+}
+
+// Elapsed returns the current time of the timer
+func (t *Timer) Elapsed() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.currentElapsed(time.Now())
+}
+
+// Start starts the timer
+// only possible when timer is in Reset state
+func (t *Timer) Start() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if t.state != Reset {
+		return fmt.Errorf("Start called with invalid state")
+	}
+	t.startTime = time.Now()
+	t.state = Running
+	for _, s := range t.subtimers {
+		s.state = Running
+	}
+	t.startLoop()
+
+	return nil
+}
+
+// Pause pauses the timer
+// only possible when in Running state
+func (t *Timer) Pause() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if t.state != Running {
+		return fmt.Errorf("Pause called with invalid state")
+	}
+	t.pauseTime = time.Now()
+	t.elapsed = t.pauseTime.Sub(t.startTime)
+	t.state = Paused
+	t.stopLoop()
+
+	return nil
+}
+
+// Resume resumes the timer from a paused state
+// resuming a stopped timer is only possible if allowed by the config
+func (t *Timer) Resume() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	switch {
+	case t.state == Paused:
+		t.startTime = t.startTime.Add(now.Sub(t.pauseTime))
+	case t.state == Stopped && t.cfg.AllowResumeAfterStop:
+		if !t.cfg.ContinueCountingWhenStopped {
+			t.startTime = t.startTime.Add(now.Sub(t.stopTime))
+		}
+	default:
+		return fmt.Errorf("Resume called with invalid state")
+	}
+	t.state = Running
+	t.startLoop()
+
+	return nil
+}
+
+// Stop stops the timer
+// only possible when in Running or Paused state
+func (t *Timer) Stop() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if t.state != Running && t.state != Paused {
+		return fmt.Errorf("Stop called with invalid state")
+	}
+	t.stop(time.Now())
+
+	return nil
+}
+
+func (t *Timer) stop(now time.Time) {
+	t.elapsed = t.currentElapsed(now)
+	t.stopTime = now
+	t.state = Stopped
+	t.stopLoop()
+}
+
+// Reset resets the timer and removes all subtimers
+// only possible when in Stopped state
+func (t *Timer) Reset() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if t.state != Stopped && t.state != Reset {
+		return fmt.Errorf("Reset called with invalid state")
+	}
+	t.state = Reset
+	t.elapsed = 0
+	t.subtimers = make(map[int]*subtimer)
+
+	return nil
+}
+
+// AddSubTimer adds a subtimer with an id
+// id has to be unique and can only be added when timer is in reset state
+func (t *Timer) AddSubTimer(id int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if t.state != Reset {
+		return fmt.Errorf("Subtimer can only be added when timer is in reset state")
+	}
+	if _, ok := t.subtimers[id]; ok {
+		return fmt.Errorf("Subtimer with id %v already exists", id)
+	}
+	t.subtimers[id] = &subtimer{state: Reset}
+
+	return nil
+}
+
+// StopSubTimer stops a running subtimer and returns its time
+// if configured the timer is stopped once all subtimers are stopped
+func (t *Timer) StopSubTimer(id int) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.ctx.Err(); err != nil {
+		return 0, err
+	}
+	s, ok := t.subtimers[id]
+	if !ok {
+		return 0, fmt.Errorf("Subtimer with id %v does not exist", id)
+	}
+	if t.state != Running || s.state != Running {
+		return s.time, fmt.Errorf("StopSubTimer called with invalid state")
+	}
+	now := time.Now()
+	s.time = t.currentElapsed(now)
+	s.state = Stopped
+
+	if t.cfg.StopOnSubtimersStop && t.subtimersStopped() {
+		t.stop(now)
+	}
+
+	return s.time, nil
+}
+
+func (t *Timer) subtimersStopped() bool {
+	for _, s := range t.subtimers {
+		if s.state != Stopped {
+			return false
+		}
+	}
+
+	return true
+}
+
+// currentElapsed returns the elapsed time at now. t.mu has to be held
+func (t *Timer) currentElapsed(now time.Time) time.Duration {
+	if t.state == Running {
+		return now.Sub(t.startTime)
+	}
+
+	return t.elapsed
+}
+
+// snapshot returns the current update. t.mu has to be held
+func (t *Timer) snapshot(now time.Time) Update {
+	u := Update{State: t.state, Elapsed: t.currentElapsed(now)}
+	if len(t.subtimers) == 0 {
+		return u
+	}
+
+	u.Subtimers = make(map[int]time.Duration, len(t.subtimers))
+	for id, s := range t.subtimers {
+		if s.state == Running {
+			u.Subtimers[id] = u.Elapsed
+		} else {
+			u.Subtimers[id] = s.time
+		}
+	}
+
+	return u
+}
+
+// watch shuts the timer down once its context is done
+// operations fail from then on, so no loop is started after the running one exited
+func (t *Timer) watch() {
+	<-t.ctx.Done()
+	t.mu.Lock()
+	t.stopLoop()
+	t.mu.Unlock()
+
+	// the loop may be publishing an update, so the channel is closed once it exited
+	t.loops.Wait()
+	close(t.updates)
+	close(t.done)
+}
+
+// startLoop starts the loop delivering updates while the timer is running. t.mu has to be held
+func (t *Timer) startLoop() {
+	if t.loopQuit != nil {
+		return
+	}
+	quit := make(chan struct{})
+	t.loopQuit = quit
+	t.loops.Add(1)
+	go t.loop(quit)
+}
+
+// stopLoop makes the running loop exit. t.mu has to be held
+func (t *Timer) stopLoop() {
+	if t.loopQuit == nil {
+		return
+	}
+	close(t.loopQuit)
+	t.loopQuit = nil
+}
+
+func (t *Timer) loop(quit chan struct{}) {
+	defer t.loops.Done()
+	ticker := time.NewTicker(t.cfg.UpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case now := <-ticker.C:
+			t.mu.Lock()
+			// a tick which raced with stopping the loop is dropped
+			running := t.loopQuit == quit
+			u := t.snapshot(now)
+			t.mu.Unlock()
+			if running {
+				t.publish(u)
+			}
+		}
+	}
+}
+
+// publish delivers u without blocking, replacing an update the consumer hasn't received yet
+func (t *Timer) publish(u Update) {
+	select {
+	case <-t.updates:
+	default:
+	}
+	select {
+	case t.updates <- u:
+	default:
+	}
+}
@@ -0,0 +1,150 @@
+package timer_test
+
+import "context"
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core/v2"
+
+const interval = 5 * time.Millisecond
+
+// assertUpdate fails the test if t doesn't deliver an update in state s within 200ms
+func assertUpdate(tb testing.TB, t *timer.Timer, s timer.State) timer.Update {
+	tb.Helper()
+
+	select {
+	case u, ok := <-t.Updates():
+		if !ok {
+			tb.Fatalf("updates channel closed while waiting for an update")
+		}
+		if u.State != s {
+			tb.Fatalf("update has state %v, want %v", u.State, s)
+		}
+		return u
+	case <-time.After(200 * time.Millisecond):
+		tb.Fatalf("no update within 200ms")
+	}
+
+	return timer.Update{}
+}
+
+// assertNoUpdate fails the test if t delivers an update after the pending one was discarded
+func assertNoUpdate(tb testing.TB, t *timer.Timer) {
+	tb.Helper()
+
+	select {
+	case <-t.Updates():
+	default:
+	}
+	select {
+	case u := <-t.Updates():
+		tb.Fatalf("unexpected update in state %v", u.State)
+	case <-time.After(10 * interval):
+	}
+}
+
+func TestUpdatesFollowRunningState(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tm := timer.New(ctx, timer.Config{UpdateInterval: interval})
+
+	assertNoUpdate(t, tm)
+	if err := tm.Start(); err != nil {
+		t.Fatal(err)
+	}
+	assertUpdate(t, tm, timer.Running)
+
+	if err := tm.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	assertNoUpdate(t, tm)
+	paused := tm.Elapsed()
+	time.Sleep(2 * interval)
+	if d := tm.Elapsed(); d != paused {
+		t.Errorf("elapsed moved from %v to %v while paused", paused, d)
+	}
+
+	if err := tm.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	if u := assertUpdate(t, tm, timer.Running); u.Elapsed < paused {
+		t.Errorf("update after resume has elapsed %v, want at least %v", u.Elapsed, paused)
+	}
+
+	if err := tm.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	assertNoUpdate(t, tm)
+	if s := tm.State(); s != timer.Stopped {
+		t.Errorf("state is %v, want %v", s, timer.Stopped)
+	}
+}
+
+func TestStopOnSubtimersStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tm := timer.New(ctx, timer.Config{UpdateInterval: interval, StopOnSubtimersStop: true})
+
+	if err := tm.AddSubTimer(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tm.StopSubTimer(1); err != nil {
+		t.Fatal(err)
+	}
+	if s := tm.State(); s != timer.Stopped {
+		t.Errorf("state is %v, want %v", s, timer.Stopped)
+	}
+	assertNoUpdate(t, tm)
+}
+
+func TestCancel(t *testing.T) {
+	tests := []struct {
+		name  string
+		start bool
+	}{
+		{"reset", false},
+		{"running", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			tm := timer.New(ctx, timer.Config{UpdateInterval: interval})
+			if test.start {
+				if err := tm.Start(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cancel()
+			select {
+			case <-tm.Done():
+			case <-time.After(200 * time.Millisecond):
+				t.Fatalf("timer not done within 200ms after cancel")
+			}
+			for range tm.Updates() {
+			}
+
+			ops := map[string]func() error{
+				"Start":  tm.Start,
+				"Pause":  tm.Pause,
+				"Resume": tm.Resume,
+				"Stop":   tm.Stop,
+				"Reset":  tm.Reset,
+				"AddSubTimer": func() error {
+					return tm.AddSubTimer(1)
+				},
+			}
+			for name, op := range ops {
+				if err := op(); err != context.Canceled {
+					t.Errorf("%v returned %v, want %v", name, err, context.Canceled)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package timer
+
+import "time"
+
+// ActiveTime returns the time the timer has been running in the current run, excluding pauses and stops
+// it is measured on the clock, so unlike the elapsed time it doesn't include the start offset or adjustments
+func (t *Timer) ActiveTime() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.activeTimeLocked()
+}
+
+func (t *Timer) activeTimeLocked() time.Duration {
+	active := t.wallTimeLocked() - t.stopLoss
+	for _, p := range t.pauses {
+		active -= p.Duration
+	}
+	if t.state == Paused {
+		active -= t.clock.Now().Sub(t.pauseTime)
+	}
+
+	return active
+}
+
+// WallTime returns the wall clock time since the timer was first started in the current run, including pauses
+// for stopped timers it is measured until the stop
+func (t *Timer) WallTime() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.wallTimeLocked()
+}
+
+func (t *Timer) wallTimeLocked() time.Duration {
+	switch {
+	case t.firstStart.IsZero():
+		return 0
+	case t.state == Stopped:
+		return t.stopTime.Sub(t.firstStart)
+	default:
+		return t.clock.Now().Sub(t.firstStart)
+	}
+}
@@ -0,0 +1,49 @@
+package timer_test
+
+import "testing"
+
+import "time"
+
+import "github.com/onestay/timer-core"
+
+import "github.com/onestay/timer-core/timertest"
+
+func TestActiveAndWallTime(t *testing.T) {
+	clock := timertest.NewClock(time.Now())
+	tm := newTimer(t, clock, timer.WithResumeAfterStop(false))
+	defer tm.Close()
+
+	check := func(active, wall time.Duration) {
+		t.Helper()
+		if a, w := tm.ActiveTime(), tm.WallTime(); a != active || w != wall {
+			t.Errorf("active time is %v and wall time %v, want %v and %v", a, w, active, wall)
+		}
+	}
+	check(0, 0)
+
+	timertest.Run(t, tm, clock,
+		timertest.Step{Do: timertest.Start},
+		timertest.Step{After: 2 * time.Second, Do: timertest.Pause},
+	)
+	clock.Advance(time.Second)
+	check(2*time.Second, 3*time.Second)
+
+	// adjustments change the elapsed time but not the time the run really took
+	if err := tm.Adjust(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	timertest.Run(t, tm, clock,
+		timertest.Step{After: time.Second, Do: timertest.Resume},
+		timertest.Step{After: time.Second, Do: timertest.Stop},
+	)
+	clock.Advance(5 * time.Second)
+	check(3*time.Second, 5*time.Second)
+
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Resume})
+	clock.Advance(time.Second)
+	check(4*time.Second, 11*time.Second)
+
+	timertest.Run(t, tm, clock, timertest.Step{Do: timertest.Stop})
+	prepare(t, tm)
+	check(0, 0)
+}